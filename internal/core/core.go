@@ -15,42 +15,131 @@
 // Package core provides a validator for being able to
 // check for core files on DUT's before and after test
 // modules runs.
+//
+// The location of core files on a DUT is taken from, in order of precedence,
+// the core_files of the device in the static binding, the core_files of the
+// test metadata for the vendor of the DUT, or the default for the vendor.
+//
+// With -core_download_dir, the core files found after the tests are
+// downloaded with gNOI File.Get, and summarized by the Symbolizer registered
+// for the vendor, if any.
 package core
 
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"text/template"
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/openconfig/featureprofiles/internal/metadata"
+	"github.com/openconfig/featureprofiles/internal/rundata"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/binding"
 	"github.com/openconfig/ondatra/eventlis"
 
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	fpb "github.com/openconfig/gnoi/file"
 	opb "github.com/openconfig/ondatra/proto"
 )
 
 var (
-	vendorCoreFilePath = map[opb.Device_Vendor]string{
-		opb.Device_JUNIPER: "/var/core/",
-		opb.Device_CISCO:   "/misc/disk1/",
-		opb.Device_NOKIA:   "/var/core/",
-		opb.Device_ARISTA:  "/var/core/",
+	downloadDir           = flag.String("core_download_dir", "", "If set, download the core files found on the DUTs after the tests into this directory using gNOI File.Get.")
+	downloadMaxBytes      = flag.Int64("core_download_max_bytes", 1<<30, "Maximum size of a single core file downloaded with -core_download_dir.")
+	downloadMaxTotalBytes = flag.Int64("core_download_max_total_bytes", 4<<30, "Maximum total size of the core files downloaded from each DUT with -core_download_dir.")
+)
+
+// location is where a DUT stores its core files.
+type location struct {
+	// path is the directory listed with gNOI File.Stat.
+	path string
+	// pattern matches the full path of the core files in the directory.
+	pattern *regexp.Regexp
+}
+
+var (
+	defaultLocations = map[opb.Device_Vendor]location{
+		opb.Device_JUNIPER: {"/var/core/", regexp.MustCompile(".*.tar.gz")},
+		opb.Device_CISCO:   {"/misc/disk1/", regexp.MustCompile("/misc/disk1/.*core.*")},
+		opb.Device_NOKIA:   {"/var/core/", regexp.MustCompile("/var/core/coredump-.*")},
+		opb.Device_ARISTA:  {"/var/core/", regexp.MustCompile("/var/core/core.*")},
+	}
+
+	// Stub out for unit tests.
+	metadataGetFn = metadata.Get
+)
+
+// coreFilesDUT is implemented by DUTs whose binding may specify the location
+// of core files, such as the static binding.
+type coreFilesDUT interface {
+	CoreFiles() *bindpb.CoreFiles
+}
+
+func newLocation(path, nameRegex string) (location, error) {
+	if nameRegex == "" {
+		nameRegex = ".*"
+	}
+	pattern, err := regexp.Compile(nameRegex)
+	if err != nil {
+		return location{}, fmt.Errorf("invalid core file name_regex: %w", err)
+	}
+	return location{path: path, pattern: pattern}, nil
+}
+
+// locationFor returns the location of core files on the DUT.
+func locationFor(dut binding.DUT) (location, error) {
+	if d, ok := dut.(coreFilesDUT); ok {
+		if cf := d.CoreFiles(); cf.GetPath() != "" {
+			return newLocation(cf.GetPath(), cf.GetNameRegex())
+		}
+	}
+	for _, cf := range metadataGetFn().GetCoreFiles() {
+		if cf.GetVendor() == dut.Vendor() && cf.GetPath() != "" {
+			return newLocation(cf.GetPath(), cf.GetNameRegex())
+		}
 	}
-	vendorCoreFileNamePattern = map[opb.Device_Vendor]*regexp.Regexp{
-		opb.Device_JUNIPER: regexp.MustCompile(".*.tar.gz"),
-		opb.Device_CISCO:   regexp.MustCompile("/misc/disk1/.*core.*"),
-		opb.Device_NOKIA:   regexp.MustCompile("/var/core/coredump-.*"),
-		opb.Device_ARISTA:  regexp.MustCompile("/var/core/core.*"),
+	if loc, ok := defaultLocations[dut.Vendor()]; ok {
+		return loc, nil
 	}
+	return location{}, fmt.Errorf("no core file location for vendor %v; add core_files to the binding or the test metadata", dut.Vendor())
+}
+
+// Symbolizer summarizes a core file downloaded from a DUT to localPath, e.g.
+// with a symbolized backtrace.  The summary is included in the core file
+// report.
+type Symbolizer func(ctx context.Context, dut binding.DUT, localPath string) (string, error)
+
+var (
+	symbolizersMu sync.Mutex
+	symbolizers   = map[opb.Device_Vendor]Symbolizer{}
 )
 
+// RegisterSymbolizer registers the symbolizer for the core files downloaded
+// from the DUTs of a vendor with -core_download_dir.
+func RegisterSymbolizer(vendor opb.Device_Vendor, s Symbolizer) {
+	symbolizersMu.Lock()
+	defer symbolizersMu.Unlock()
+	symbolizers[vendor] = s
+}
+
+func symbolizer(vendor opb.Device_Vendor) Symbolizer {
+	symbolizersMu.Lock()
+	defer symbolizersMu.Unlock()
+	return symbolizers[vendor]
+}
+
 var (
 	validator validatorImpl
 )
@@ -59,6 +148,11 @@ type fileInfo struct {
 	Name     string
 	Path     string
 	Modified uint64
+	Size     uint64
+	// The fields below are only set for downloaded core files.
+	SHA256    string
+	LocalPath string
+	Summary   string
 }
 
 type dutCoreFiles struct {
@@ -72,6 +166,7 @@ type coreFiles map[string]fileInfo
 type checker struct {
 	dut        binding.DUT
 	fileClient fpb.FileClient
+	loc        location
 
 	mu        sync.Mutex
 	startTime time.Time
@@ -79,13 +174,9 @@ type checker struct {
 }
 
 func newChecker(dut binding.DUT) (*checker, error) {
-	dutVendor := dut.Vendor()
-	// vendorCoreFilePath and vendorCoreProcName should be provided to fetch core file on dut.
-	if _, ok := vendorCoreFilePath[dutVendor]; !ok {
-		return nil, fmt.Errorf("add support for vendor %v in var vendorCoreFilePath", dutVendor)
-	}
-	if _, ok := vendorCoreFileNamePattern[dutVendor]; !ok {
-		return nil, fmt.Errorf("add support for vendor %v in var vendorCoreFileNamePattern", dutVendor)
+	loc, err := locationFor(dut)
+	if err != nil {
+		return nil, err
 	}
 	gClients, err := dut.DialGNOI(context.Background())
 	if err != nil {
@@ -94,6 +185,7 @@ func newChecker(dut binding.DUT) (*checker, error) {
 	return &checker{
 		dut:        dut,
 		fileClient: gClients.File(),
+		loc:        loc,
 		prevCores:  coreFiles{},
 		startTime:  time.Now(),
 	}, nil
@@ -106,14 +198,28 @@ func (c *checker) check() (coreFiles, error) {
 	if err != nil {
 		return nil, err
 	}
+	delta := newCores(c.prevCores, cores)
+	c.prevCores = cores
+	return delta, nil
+}
+
+// snapshot returns the core files on the DUT without affecting the new core
+// files returned by check.
+func (c *checker) snapshot() (coreFiles, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkCores()
+}
+
+// newCores returns the core files in cores that are not in prev.
+func newCores(prev, cores coreFiles) coreFiles {
 	delta := coreFiles{}
 	for k, v := range cores {
-		if _, ok := c.prevCores[k]; !ok {
+		if _, ok := prev[k]; !ok {
 			delta[k] = v
 		}
 	}
-	c.prevCores = cores
-	return delta, nil
+	return delta
 }
 
 type validatorImpl struct {
@@ -121,13 +227,15 @@ type validatorImpl struct {
 	duts map[string]*checker
 }
 
-func (v *validatorImpl) check() map[string]dutCoreFiles {
+// check returns the new core files of every DUT, by DUT ID.  If collect is
+// true, the new core files are also downloaded with -core_download_dir.
+func (v *validatorImpl) check(collect bool) map[string]dutCoreFiles {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	dutCores := map[string]dutCoreFiles{}
-	for _, c := range v.duts {
+	for id, c := range v.duts {
 		wg.Add(1)
-		go func(c *checker) {
+		go func(id string, c *checker) {
 			defer wg.Done()
 			cores, err := c.check()
 			status := "OK"
@@ -135,14 +243,20 @@ func (v *validatorImpl) check() map[string]dutCoreFiles {
 				status = fmt.Sprintf("DUT %q failed to check cores: %v", c.dut.Name(), err)
 				glog.Warning(status)
 			}
+			if collect && *downloadDir != "" && len(cores) > 0 {
+				if err := c.collect(context.Background(), cores); err != nil {
+					status = fmt.Sprintf("DUT %q failed to collect cores: %v", c.dut.Name(), err)
+					glog.Warning(status)
+				}
+			}
 			mu.Lock()
 			defer mu.Unlock()
-			dutCores[c.dut.Name()] = dutCoreFiles{
+			dutCores[id] = dutCoreFiles{
 				DUT:    c.dut.Name(),
 				Files:  cores,
 				Status: status,
 			}
-		}(c)
+		}(id, c)
 	}
 	wg.Wait()
 	return dutCores
@@ -161,7 +275,7 @@ func (v *validatorImpl) start(duts map[string]binding.DUT) map[string]dutCoreFil
 		}
 		v.duts[k] = c
 	}
-	return v.check()
+	return v.check(false)
 }
 
 // Stop ends the validator and returns a list of all DUTs that
@@ -169,7 +283,7 @@ func (v *validatorImpl) start(duts map[string]binding.DUT) map[string]dutCoreFil
 func (v *validatorImpl) stop() map[string]dutCoreFiles {
 	v.mu.Lock()
 	defer v.mu.Unlock()
-	return v.check()
+	return v.check(true)
 }
 
 func registerBefore(e *eventlis.BeforeTestsEvent) error {
@@ -184,7 +298,8 @@ const (
 	coreFmt = `
 Delta Core Files by DUT:{{range $key, $dut := .}} 
 DUT: {{$key}}{{ range $key, $cores := $dut.Files }}
-  {{ $key }}{{ end }}{{ end }}`
+  {{ $key }}{{ if $cores.Size }} ({{ $cores.Size }} bytes){{ end }}{{ if $cores.LocalPath }} downloaded to {{ $cores.LocalPath }} sha256:{{ $cores.SHA256 }}{{ end }}{{ if $cores.Summary }}
+{{ $cores.Summary }}{{ end }}{{ end }}{{ end }}`
 )
 
 var coreTemplate = template.Must(template.New("errorMsg").Parse(coreFmt))
@@ -212,6 +327,9 @@ func registerAfter(_ *eventlis.AfterTestsEvent) error {
 	glog.Infof(msg)
 	ondatra.Report().AddSuiteProperty("validator.core.end", report)
 	if foundCores {
+		for k, v := range rundata.Cores(context.Background(), runCores(cores)) {
+			ondatra.Report().AddSuiteProperty(k, v)
+		}
 		return errors.New(msg)
 	}
 	return nil
@@ -230,9 +348,8 @@ func Register() {
 
 // coreFileCheck function is used to check if cores are found on the DUT.
 func (c *checker) checkCores() (coreFiles, error) {
-	dutVendor := c.dut.Vendor()
-	corePath := vendorCoreFilePath[dutVendor]
-	fileMatch := vendorCoreFileNamePattern[dutVendor]
+	corePath := c.loc.path
+	fileMatch := c.loc.pattern
 	in := &fpb.StatRequest{
 		Path: corePath,
 	}
@@ -257,9 +374,114 @@ func (c *checker) checkCores() (coreFiles, error) {
 				cores[coreFileName] = fileInfo{
 					Name:     coreFileName,
 					Modified: fileStatsInfo.GetLastModified(),
+					Size:     filesMatched.GetSize(),
 				}
 			}
 		}
 	}
 	return cores, nil
 }
+
+// collect downloads the core files into -core_download_dir, within the size
+// limits, and summarizes them with the symbolizer of the vendor, if any.  The
+// cores are updated in place.  Every core file is attempted, and the errors
+// are joined.
+func (c *checker) collect(ctx context.Context, cores coreFiles) error {
+	if err := os.MkdirAll(*downloadDir, 0o755); err != nil {
+		return err
+	}
+	var errs []error
+	budget := *downloadMaxTotalBytes
+	for _, name := range sortedNames(cores) {
+		f := cores[name]
+		n, err := c.download(ctx, &f, min(*downloadMaxBytes, budget))
+		budget -= n
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if s := symbolizer(c.dut.Vendor()); s != nil {
+			summary, err := s(ctx, c.dut, f.LocalPath)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to symbolize %q: %w", f.Name, err))
+			}
+			f.Summary = summary
+		}
+		cores[name] = f
+	}
+	return errors.Join(errs...)
+}
+
+// download copies a core file from the DUT to -core_download_dir, failing if
+// it is larger than limit bytes.  It returns the number of bytes received.
+func (c *checker) download(ctx context.Context, f *fileInfo, limit int64) (int64, error) {
+	if f.Size > uint64(max(limit, 0)) {
+		return 0, fmt.Errorf("core file %q of %d bytes exceeds the download limit of %d bytes", f.Name, f.Size, limit)
+	}
+	stream, err := c.fileClient.Get(ctx, &fpb.GetRequest{RemoteFile: f.Name})
+	if err != nil {
+		return 0, fmt.Errorf("unable to get core file %q: %w", f.Name, err)
+	}
+	localPath := filepath.Join(*downloadDir, localName(c.dut.Name(), f.Name))
+	out, err := os.Create(localPath)
+	if err != nil {
+		return 0, err
+	}
+	h := sha256.New()
+	var n int64
+	err = func() error {
+		defer out.Close()
+		w := io.MultiWriter(out, h)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return out.Close()
+			}
+			if err != nil {
+				return fmt.Errorf("unable to get core file %q: %w", f.Name, err)
+			}
+			contents := resp.GetContents()
+			if n += int64(len(contents)); n > limit {
+				return fmt.Errorf("core file %q exceeds the download limit of %d bytes", f.Name, limit)
+			}
+			if _, err := w.Write(contents); err != nil {
+				return err
+			}
+		}
+	}()
+	if err != nil {
+		os.Remove(localPath)
+		return n, err
+	}
+	f.Size = uint64(n)
+	f.SHA256 = hex.EncodeToString(h.Sum(nil))
+	f.LocalPath = localPath
+	return n, nil
+}
+
+// localName returns the name of a downloaded core file, which is unique
+// across DUTs.
+func localName(dut, path string) string {
+	return dut + "_" + strings.ReplaceAll(strings.TrimPrefix(path, "/"), "/", "_")
+}
+
+func sortedNames(cores coreFiles) []string {
+	var names []string
+	for name := range cores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runCores returns the new core files of the DUTs as run data, by DUT ID.
+func runCores(cores map[string]dutCoreFiles) map[string][]rundata.CoreFile {
+	files := map[string][]rundata.CoreFile{}
+	for id, dut := range cores {
+		for _, f := range sortedNames(dut.Files) {
+			fi := dut.Files[f]
+			files[id] = append(files[id], rundata.CoreFile{Name: fi.Name, Size: fi.Size, SHA256: fi.SHA256})
+		}
+	}
+	return files
+}
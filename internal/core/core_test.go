@@ -16,10 +16,16 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/rundata"
 	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/gnoigo"
 	"github.com/openconfig/ondatra/binding"
@@ -27,6 +33,8 @@ import (
 	"github.com/openconfig/ondatra/fakebind"
 	"google.golang.org/grpc"
 
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	fpb "github.com/openconfig/gnoi/file"
 	opb "github.com/openconfig/ondatra/proto"
 )
@@ -43,6 +51,32 @@ func (f *fakeGNOI) File() fpb.FileClient {
 type fakeFileClient struct {
 	fpb.FileClient
 	statResponses []any
+	// getContents maps the remote file names to their contents.
+	getContents map[string][]byte
+}
+
+func (f *fakeFileClient) Get(_ context.Context, in *fpb.GetRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[fpb.GetResponse], error) {
+	contents, ok := f.getContents[in.GetRemoteFile()]
+	if !ok {
+		return nil, fmt.Errorf("no such file: %q", in.GetRemoteFile())
+	}
+	return &fakeGetStream{contents: contents}, nil
+}
+
+// fakeGetStream streams the contents of a file in chunks of 4 bytes.
+type fakeGetStream struct {
+	grpc.ServerStreamingClient[fpb.GetResponse]
+	contents []byte
+}
+
+func (s *fakeGetStream) Recv() (*fpb.GetResponse, error) {
+	if len(s.contents) == 0 {
+		return nil, io.EOF
+	}
+	n := min(4, len(s.contents))
+	resp := &fpb.GetResponse{Response: &fpb.GetResponse_Contents{Contents: s.contents[:n]}}
+	s.contents = s.contents[n:]
+	return resp, nil
 }
 
 func (f *fakeFileClient) Stat(_ context.Context, _ *fpb.StatRequest, _ ...grpc.CallOption) (*fpb.StatResponse, error) {
@@ -491,3 +525,366 @@ DUT: dut1
 
 	}
 }
+
+type fakeCoreFilesDUT struct {
+	*fakebind.DUT
+	coreFiles *bindpb.CoreFiles
+}
+
+func (d *fakeCoreFilesDUT) CoreFiles() *bindpb.CoreFiles {
+	return d.coreFiles
+}
+
+func TestLocationFor(t *testing.T) {
+	md := &mpb.Metadata{
+		CoreFiles: []*mpb.Metadata_CoreFiles{{
+			Vendor:    opb.Device_ARISTA,
+			Path:      "/mnt/flash/cores/",
+			NameRegex: "/mnt/flash/cores/.*",
+		}, {
+			Vendor: opb.Device_CISCO,
+			Path:   "/misc/scratch/",
+		}},
+	}
+	dut := func(vendor opb.Device_Vendor) *fakebind.DUT {
+		return &fakebind.DUT{
+			AbstractDUT: &binding.AbstractDUT{
+				Dims: &binding.Dims{Vendor: vendor, Name: "dut1"},
+			},
+		}
+	}
+	tests := []struct {
+		desc        string
+		dut         binding.DUT
+		md          *mpb.Metadata
+		wantPath    string
+		wantPattern string
+		wantErr     string
+	}{{
+		desc:        "default",
+		dut:         dut(opb.Device_NOKIA),
+		md:          md,
+		wantPath:    "/var/core/",
+		wantPattern: "/var/core/coredump-.*",
+	}, {
+		desc:        "metadata",
+		dut:         dut(opb.Device_ARISTA),
+		md:          md,
+		wantPath:    "/mnt/flash/cores/",
+		wantPattern: "/mnt/flash/cores/.*",
+	}, {
+		desc:        "metadata without name_regex",
+		dut:         dut(opb.Device_CISCO),
+		md:          md,
+		wantPath:    "/misc/scratch/",
+		wantPattern: ".*",
+	}, {
+		desc: "binding",
+		dut: &fakeCoreFilesDUT{
+			DUT:       dut(opb.Device_ARISTA),
+			coreFiles: &bindpb.CoreFiles{Path: "/tmp/cores/", NameRegex: ".*core.*"},
+		},
+		md:          md,
+		wantPath:    "/tmp/cores/",
+		wantPattern: ".*core.*",
+	}, {
+		desc:        "binding without core_files",
+		dut:         &fakeCoreFilesDUT{DUT: dut(opb.Device_ARISTA)},
+		wantPath:    "/var/core/",
+		wantPattern: "/var/core/core.*",
+	}, {
+		desc: "invalid name_regex",
+		dut: &fakeCoreFilesDUT{
+			DUT:       dut(opb.Device_ARISTA),
+			coreFiles: &bindpb.CoreFiles{Path: "/tmp/cores/", NameRegex: "*core"},
+		},
+		wantErr: "invalid core file name_regex",
+	}, {
+		desc:    "unknown vendor",
+		dut:     dut(opb.Device_VENDOR_UNSPECIFIED),
+		md:      md,
+		wantErr: "no core file location",
+	}}
+	origGetFn := metadataGetFn
+	defer func() { metadataGetFn = origGetFn }()
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			metadataGetFn = func() *mpb.Metadata { return tt.md }
+			loc, err := locationFor(tt.dut)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("locationFor() unexpected error: %s", s)
+			}
+			if err != nil {
+				return
+			}
+			if loc.path != tt.wantPath {
+				t.Errorf("locationFor() got path %q, want %q", loc.path, tt.wantPath)
+			}
+			if got := loc.pattern.String(); got != tt.wantPattern {
+				t.Errorf("locationFor() got pattern %q, want %q", got, tt.wantPattern)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	const (
+		core1 = "/var/core/core.1.tar.gz"
+		core2 = "/var/core/core.2.tar.gz"
+	)
+	contents := []byte("0123456789")
+	sum := sha256.Sum256(contents)
+	wantSHA256 := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		desc          string
+		maxBytes      int64
+		maxTotalBytes int64
+		symbolizer    Symbolizer
+		want          coreFiles
+		wantErr       string
+	}{{
+		desc:          "download",
+		maxBytes:      100,
+		maxTotalBytes: 100,
+		want: coreFiles{
+			core1: {Name: core1, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.1.tar.gz"},
+			core2: {Name: core2, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.2.tar.gz"},
+		},
+	}, {
+		desc:          "symbolize",
+		maxBytes:      100,
+		maxTotalBytes: 100,
+		symbolizer: func(_ context.Context, dut binding.DUT, localPath string) (string, error) {
+			return fmt.Sprintf("%s: %s", dut.Name(), filepath.Base(localPath)), nil
+		},
+		want: coreFiles{
+			core1: {Name: core1, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.1.tar.gz", Summary: "dut1: dut1_var_core_core.1.tar.gz"},
+			core2: {Name: core2, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.2.tar.gz", Summary: "dut1: dut1_var_core_core.2.tar.gz"},
+		},
+	}, {
+		desc:          "symbolize error",
+		maxBytes:      100,
+		maxTotalBytes: 100,
+		symbolizer: func(context.Context, binding.DUT, string) (string, error) {
+			return "", fmt.Errorf("no symbols")
+		},
+		want: coreFiles{
+			core1: {Name: core1, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.1.tar.gz"},
+			core2: {Name: core2, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.2.tar.gz"},
+		},
+		wantErr: "no symbols",
+	}, {
+		desc:          "file too large",
+		maxBytes:      5,
+		maxTotalBytes: 100,
+		want: coreFiles{
+			core1: {Name: core1},
+			core2: {Name: core2},
+		},
+		wantErr: "exceeds the download limit of 5 bytes",
+	}, {
+		desc:          "total too large",
+		maxBytes:      100,
+		maxTotalBytes: 15,
+		want: coreFiles{
+			core1: {Name: core1, Size: 10, SHA256: wantSHA256, LocalPath: "dut1_var_core_core.1.tar.gz"},
+			core2: {Name: core2},
+		},
+		wantErr: "exceeds the download limit of 5 bytes",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			*downloadDir = dir
+			*downloadMaxBytes = tt.maxBytes
+			*downloadMaxTotalBytes = tt.maxTotalBytes
+			RegisterSymbolizer(opb.Device_ARISTA, tt.symbolizer)
+			defer func() {
+				*downloadDir = ""
+				RegisterSymbolizer(opb.Device_ARISTA, nil)
+			}()
+
+			c := &checker{
+				dut: &fakebind.DUT{
+					AbstractDUT: &binding.AbstractDUT{
+						Dims: &binding.Dims{Vendor: opb.Device_ARISTA, Name: "dut1"},
+					},
+				},
+				fileClient: &fakeFileClient{
+					getContents: map[string][]byte{core1: contents, core2: contents},
+				},
+			}
+			cores := coreFiles{core1: {Name: core1}, core2: {Name: core2}}
+			err := c.collect(context.Background(), cores)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Errorf("collect() unexpected error: %s", s)
+			}
+			for name, f := range tt.want {
+				if f.LocalPath != "" {
+					f.LocalPath = filepath.Join(dir, f.LocalPath)
+					tt.want[name] = f
+				}
+			}
+			if diff := cmp.Diff(tt.want, cores); diff != "" {
+				t.Errorf("collect() cores -want,+got:\n%s", diff)
+			}
+			for _, f := range cores {
+				if f.LocalPath == "" {
+					continue
+				}
+				got, err := os.ReadFile(f.LocalPath)
+				if err != nil {
+					t.Fatalf("Could not read downloaded core file: %v", err)
+				}
+				if diff := cmp.Diff(contents, got); diff != "" {
+					t.Errorf("Downloaded core file %q -want,+got:\n%s", f.LocalPath, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCores(t *testing.T) {
+	cores := map[string]dutCoreFiles{
+		"dut1": {
+			DUT: "dut1",
+			Files: coreFiles{
+				"/var/core/core.2": {Name: "/var/core/core.2", Size: 20},
+				"/var/core/core.1": {Name: "/var/core/core.1", Size: 10, SHA256: "abc", LocalPath: "/tmp/dut1_core.1"},
+			},
+		},
+		"dut2": {DUT: "dut2", Files: coreFiles{}},
+	}
+	want := map[string][]rundata.CoreFile{
+		"dut1": {
+			{Name: "/var/core/core.1", Size: 10, SHA256: "abc"},
+			{Name: "/var/core/core.2", Size: 20},
+		},
+	}
+	if diff := cmp.Diff(want, runCores(cores)); diff != "" {
+		t.Errorf("runCores() -want,+got:\n%s", diff)
+	}
+}
+
+func TestValidatorCoresByID(t *testing.T) {
+	validator = validatorImpl{
+		duts: map[string]*checker{},
+	}
+	dut := &fakebind.DUT{
+		AbstractDUT: &binding.AbstractDUT{
+			Dims: &binding.Dims{
+				Vendor: opb.Device_ARISTA,
+				Name:   "dut-hostname",
+			},
+		},
+		DialGNOIFn: func(_ context.Context, _ ...grpc.DialOption) (gnoigo.Clients, error) {
+			return &fakeGNOI{
+				fakeFileClient: &fakeFileClient{
+					statResponses: []any{
+						&fpb.StatResponse{Stats: []*fpb.StatInfo{{Path: "/var/core/core.1.tar.gz"}}},
+						&fpb.StatResponse{Stats: []*fpb.StatInfo{{Path: "/var/core/core.1.tar.gz"}}},
+						&fpb.StatResponse{Stats: []*fpb.StatInfo{{Path: "/var/core/core.1.tar.gz"}, {Path: "/var/core/core.2.tar.gz"}}},
+						&fpb.StatResponse{Stats: []*fpb.StatInfo{{Path: "/var/core/core.1.tar.gz"}}},
+						&fpb.StatResponse{Stats: []*fpb.StatInfo{{Path: "/var/core/core.2.tar.gz", Size: 20}}},
+					},
+				},
+			}, nil
+		},
+	}
+	validator.start(map[string]binding.DUT{"dut": dut})
+	cores := validator.stop()
+	if got, want := cores["dut"].DUT, "dut-hostname"; got != want {
+		t.Errorf("stop() got DUT %q, want %q", got, want)
+	}
+	want := map[string]string{"dut.cores": "/var/core/core.2.tar.gz:20:"}
+	if diff := cmp.Diff(want, rundata.Cores(context.Background(), runCores(cores))); diff != "" {
+		t.Errorf("rundata.Cores() -want,+got:\n%s", diff)
+	}
+}
+
+func TestTestChecker(t *testing.T) {
+	stat := func(paths ...string) *fpb.StatResponse {
+		resp := &fpb.StatResponse{}
+		for _, p := range paths {
+			resp.Stats = append(resp.Stats, &fpb.StatInfo{Path: p})
+		}
+		return resp
+	}
+	tests := []struct {
+		desc          string
+		statResponses []any
+		wantErr       string
+	}{{
+		desc: "no new core",
+		statResponses: []any{
+			stat("/var/core/core.1.tar.gz"), stat("/var/core/core.1.tar.gz"),
+			stat("/var/core/core.1.tar.gz"), stat("/var/core/core.1.tar.gz"),
+		},
+	}, {
+		desc: "new core",
+		statResponses: []any{
+			stat("/var/core/core.1.tar.gz"), stat("/var/core/core.1.tar.gz"),
+			stat("/var/core/core.1.tar.gz", "/var/core/core.2.tar.gz"),
+			stat("/var/core/core.1.tar.gz"), stat("/var/core/core.2.tar.gz"),
+		},
+		wantErr: `core file check found cores during TestFoo:
+
+Delta Core Files by DUT: 
+DUT: dut1
+  /var/core/core.2.tar.gz`,
+	}, {
+		desc: "failed before",
+		statResponses: []any{
+			fmt.Errorf("gnoi.File.Stat failed"),
+			stat("/var/core/core.1.tar.gz", "/var/core/core.2.tar.gz"),
+			stat("/var/core/core.1.tar.gz"), stat("/var/core/core.2.tar.gz"),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			validator = validatorImpl{
+				duts: map[string]*checker{
+					"dut1": {
+						dut: &fakebind.DUT{
+							AbstractDUT: &binding.AbstractDUT{
+								Dims: &binding.Dims{Vendor: opb.Device_ARISTA, Name: "dut1"},
+							},
+						},
+						fileClient: &fakeFileClient{statResponses: tt.statResponses},
+						loc:        defaultLocations[opb.Device_ARISTA],
+						prevCores:  coreFiles{},
+					},
+				},
+			}
+			tc := StartTest("TestFoo")
+			err := tc.Stop()
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Errorf("Stop() unexpected error: %s", s)
+			}
+			if got := len(validator.duts["dut1"].prevCores); got != 0 {
+				t.Errorf("Stop() changed the cores of the validator, got %d cores, want 0", got)
+			}
+		})
+	}
+}
+
+func TestTestDelta(t *testing.T) {
+	before := map[string]dutCoreFiles{
+		"dut1": {DUT: "dut1", Files: coreFiles{"core.1": {Name: "core.1"}}, Status: "OK"},
+		"dut2": {DUT: "dut2", Status: "failed"},
+	}
+	after := map[string]dutCoreFiles{
+		"dut1": {DUT: "dut1", Files: coreFiles{"core.1": {Name: "core.1"}, "core.2": {Name: "core.2"}}, Status: "OK"},
+		"dut2": {DUT: "dut2", Files: coreFiles{"core.3": {Name: "core.3"}}, Status: "OK"},
+		"dut3": {DUT: "dut3", Files: coreFiles{"core.4": {Name: "core.4"}}, Status: "OK"},
+	}
+	want := map[string]dutCoreFiles{
+		"dut1": {DUT: "dut1", Files: coreFiles{"core.2": {Name: "core.2"}}, Status: "OK"},
+		"dut2": {DUT: "dut2", Files: coreFiles{}, Status: "failed"},
+		"dut3": {DUT: "dut3", Files: coreFiles{}, Status: `DUT "dut3" was not checked before the test`},
+	}
+	if diff := cmp.Diff(want, testDelta(before, after)); diff != "" {
+		t.Errorf("testDelta() -want,+got:\n%s", diff)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/openconfig/ondatra"
+)
+
+// TestChecker attributes the core files created on the DUTs while a test runs
+// to that test.  The DUTs are the ones watched by the validator installed with
+// Register, so a TestChecker finds no core files if Register was not called.
+// It may be used from t.Cleanup:
+//
+//	tc := core.StartTest(t.Name())
+//	t.Cleanup(func() {
+//	  if err := tc.Stop(); err != nil {
+//	    t.Error(err)
+//	  }
+//	})
+type TestChecker struct {
+	name   string
+	before map[string]dutCoreFiles
+}
+
+// StartTest snapshots the core files on the DUTs before the named test runs.
+func StartTest(name string) *TestChecker {
+	return &TestChecker{name: name, before: validator.snapshot()}
+}
+
+// Stop snapshots the core files on the DUTs again, and returns an error
+// listing the core files created since StartTest.  The new core files are
+// also recorded in the suite property validator.core.test.<name>.
+func (tc *TestChecker) Stop() error {
+	cores := testDelta(tc.before, validator.snapshot())
+	foundCores := false
+	for _, files := range cores {
+		if len(files.Files) > 0 {
+			foundCores = true
+			break
+		}
+	}
+	if !foundCores {
+		return nil
+	}
+	report := createReport(cores)
+	ondatra.Report().AddSuiteProperty("validator.core.test."+tc.name, report)
+	return fmt.Errorf("core file check found cores during %s:\n%s", tc.name, report)
+}
+
+// snapshot returns the core files on every DUT, by DUT ID, without affecting
+// the new core files returned by check.
+func (v *validatorImpl) snapshot() map[string]dutCoreFiles {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	dutCores := map[string]dutCoreFiles{}
+	for id, c := range v.duts {
+		wg.Add(1)
+		go func(id string, c *checker) {
+			defer wg.Done()
+			cores, err := c.snapshot()
+			status := "OK"
+			if err != nil {
+				status = fmt.Sprintf("DUT %q failed to check cores: %v", c.dut.Name(), err)
+				glog.Warning(status)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			dutCores[id] = dutCoreFiles{
+				DUT:    c.dut.Name(),
+				Files:  cores,
+				Status: status,
+			}
+		}(id, c)
+	}
+	wg.Wait()
+	return dutCores
+}
+
+// testDelta returns the core files of every DUT in after that are not in
+// before.  If either snapshot of a DUT failed, no core files are returned for
+// the DUT and the failure is kept in its status.
+func testDelta(before, after map[string]dutCoreFiles) map[string]dutCoreFiles {
+	delta := map[string]dutCoreFiles{}
+	for id, a := range after {
+		d := dutCoreFiles{DUT: a.DUT, Files: coreFiles{}, Status: a.Status}
+		b, ok := before[id]
+		switch {
+		case !ok:
+			d.Status = fmt.Sprintf("DUT %q was not checked before the test", a.DUT)
+		case b.Status != "OK":
+			d.Status = b.Status
+		case a.Status == "OK":
+			d.Files = newCores(b.Files, a.Files)
+		}
+		delta[id] = d
+	}
+	return delta
+}
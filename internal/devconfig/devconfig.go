@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package devconfig gets the CLI config of a device and compares OpenConfig
+// configs.  It is shared by the checkpoints of the binding and the config
+// snapshots of fptest, so that both restore the config of a device the same
+// way.
+package devconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openconfig/featureprofiles/internal/confirm"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// cliPath is the root of the CLI-origin config.
+var cliPath = &gpb.Path{Origin: "cli", Elem: []*gpb.PathElem{}}
+
+// GetCLI returns the CLI-origin config of the device.
+func GetCLI(ctx context.Context, c gpb.GNMIClient) (string, error) {
+	resp, err := c.Get(ctx, &gpb.GetRequest{
+		Path:     []*gpb.Path{cliPath},
+		Type:     gpb.GetRequest_CONFIG,
+		Encoding: gpb.Encoding_ASCII,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, n := range resp.GetNotification() {
+		for _, u := range n.GetUpdate() {
+			if v, ok := u.GetVal().GetValue().(*gpb.TypedValue_AsciiVal); ok {
+				return v.AsciiVal, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no ASCII value in the response: %v", resp)
+}
+
+// CLIUpdate returns the update that replaces the CLI-origin config with the
+// config.
+func CLIUpdate(config string) *gpb.Update {
+	return &gpb.Update{
+		Path: cliPath,
+		Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: config}},
+	}
+}
+
+// ReplaceCLI replaces the CLI-origin config of the device with the config.
+func ReplaceCLI(ctx context.Context, c gpb.GNMIClient, config string) error {
+	_, err := c.Set(ctx, &gpb.SetRequest{Replace: []*gpb.Update{CLIUpdate(config)}})
+	return err
+}
+
+// Changes returns the values of want that are missing or different in got.
+// Values that are only in got are ignored, because devices add state and
+// default values to the config they return.
+func Changes(want, got *oc.Root) ([]*confirm.Change, error) {
+	diff, err := ygot.Diff(want, got, &ygot.IgnoreAdditions{})
+	if err != nil {
+		return nil, err
+	}
+	return confirm.ExtractChanges(diff, want, got)
+}
+
+// FormatChange returns a line describing the change.
+func FormatChange(c *confirm.Change) string {
+	if c.Missing {
+		return fmt.Sprintf("%s: missing, want %s", confirm.PathLabel(c.Path), confirm.Readable(c.Want))
+	}
+	return fmt.Sprintf("%s: got %s, want %s", confirm.PathLabel(c.Path), confirm.Readable(c.Got), confirm.Readable(c.Want))
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestChanges(t *testing.T) {
+	want := &oc.Root{}
+	want.GetOrCreateInterface("eth0").Description = ygot.String("uplink")
+	want.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(9000)
+	want.GetOrCreateInterface("eth1").Enabled = ygot.Bool(true)
+
+	got := &oc.Root{}
+	got.GetOrCreateInterface("eth0").Description = ygot.String("changed")
+	got.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(9000)
+	// Additions are ignored.
+	got.GetOrCreateInterface("eth0").Enabled = ygot.Bool(false)
+	got.GetOrCreateInterface("eth2").Enabled = ygot.Bool(true)
+
+	changes, err := Changes(want, got)
+	if err != nil {
+		t.Fatalf("Changes() got unexpected error: %v", err)
+	}
+	var gotChanges []string
+	for _, c := range changes {
+		gotChanges = append(gotChanges, FormatChange(c))
+	}
+	wantChanges := []string{
+		"/interfaces/interface[name=eth1]/state/enabled: missing, want &true",
+		"/interfaces/interface[name=eth1]/state/name: missing, want &eth1",
+		"/interfaces/interface[name=eth1]/name: missing, want &eth1",
+		"/interfaces/interface[name=eth0]/state/description: got &changed, want &uplink",
+	}
+	if diff := cmp.Diff(wantChanges, gotChanges, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Changes() -want,+got:\n%s", diff)
+	}
+}
+
+type fakeGNMIClient struct {
+	gpb.GNMIClient
+	getResponse *gpb.GetResponse
+	setRequest  *gpb.SetRequest
+}
+
+func (c *fakeGNMIClient) Get(context.Context, *gpb.GetRequest, ...grpc.CallOption) (*gpb.GetResponse, error) {
+	return c.getResponse, nil
+}
+
+func (c *fakeGNMIClient) Set(_ context.Context, req *gpb.SetRequest, _ ...grpc.CallOption) (*gpb.SetResponse, error) {
+	c.setRequest = req
+	return &gpb.SetResponse{}, nil
+}
+
+func TestCLI(t *testing.T) {
+	tests := []struct {
+		desc    string
+		resp    *gpb.GetResponse
+		want    string
+		wantErr string
+	}{{
+		desc: "ascii",
+		resp: &gpb.GetResponse{
+			Notification: []*gpb.Notification{{
+				Update: []*gpb.Update{{
+					Path: cliPath,
+					Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "hostname dut\n"}},
+				}},
+			}},
+		},
+		want: "hostname dut\n",
+	}, {
+		desc:    "empty",
+		resp:    &gpb.GetResponse{},
+		wantErr: "no ASCII value",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &fakeGNMIClient{getResponse: tt.resp}
+			got, err := GetCLI(context.Background(), c)
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("GetCLI() unexpected error: %s", s)
+			}
+			if got != tt.want {
+				t.Errorf("GetCLI() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	c := &fakeGNMIClient{}
+	if err := ReplaceCLI(context.Background(), c, "hostname dut\n"); err != nil {
+		t.Fatalf("ReplaceCLI() got unexpected error: %v", err)
+	}
+	want := &gpb.SetRequest{
+		Replace: []*gpb.Update{{
+			Path: &gpb.Path{Origin: "cli"},
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "hostname dut\n"}},
+		}},
+	}
+	if diff := cmp.Diff(want, c.setRequest, protocmp.Transform()); diff != "" {
+		t.Errorf("ReplaceCLI() SetRequest -want,+got:\n%s", diff)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"flag"
 
 	"github.com/openconfig/ondatra"
+
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
 )
 
 // Vendor deviation flags.
@@ -36,7 +38,13 @@ var (
 	ateIPv6FlowLabelUnsupported              = flag.Bool("deviation_ate_ipv6_flow_label_unsupported", false, "Set to true for ATEs that do not support IPv6 flow labels")
 )
 
+// isFlagSet returns true if the deviation flag is set on the command line and
+// takes precedence over the metadata.  Deviation flags are ignored under
+// -strict_compliance.
 func isFlagSet(name string) bool {
+	if *strictCompliance {
+		return false
+	}
 	visited := false
 	flag.Visit(func(f *flag.Flag) {
 		if f.Name == name {
@@ -52,7 +60,7 @@ func CPUMissingAncestor(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_cpu_missing_ancestor") {
 		return *cpuMissingAncestor
 	}
-	return lookupDUTDeviations(dut).GetCpuMissingAncestor()
+	return dutDeviation(dut, "CPUMissingAncestor", (*mpb.Metadata_Deviations).GetCpuMissingAncestor)
 }
 
 // InterfaceRefConfigUnsupported deviation set to true for devices that do not support
@@ -61,7 +69,7 @@ func InterfaceRefConfigUnsupported(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_interface_ref_config_unsupported") {
 		return *interfaceRefConfigUnsupported
 	}
-	return lookupDUTDeviations(dut).GetInterfaceRefConfigUnsupported()
+	return dutDeviation(dut, "InterfaceRefConfigUnsupported", (*mpb.Metadata_Deviations).GetInterfaceRefConfigUnsupported)
 }
 
 // RequireRoutedSubinterface0 returns true if device needs to configure subinterface 0
@@ -70,7 +78,7 @@ func RequireRoutedSubinterface0(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_require_routed_subinterface_0") {
 		return *requireRoutedSubinterface0
 	}
-	return lookupDUTDeviations(dut).GetRequireRoutedSubinterface_0()
+	return dutDeviation(dut, "RequireRoutedSubinterface0", (*mpb.Metadata_Deviations).GetRequireRoutedSubinterface_0)
 }
 
 // GNOISwitchoverReasonMissingUserInitiated returns true for devices that don't
@@ -79,7 +87,7 @@ func GNOISwitchoverReasonMissingUserInitiated(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_gnoi_switchover_reason_missing_user_initiated") {
 		return *gnoiSwitchoverReasonMissingUserInitiated
 	}
-	return lookupDUTDeviations(dut).GetGnoiSwitchoverReasonMissingUserInitiated()
+	return dutDeviation(dut, "GNOISwitchoverReasonMissingUserInitiated", (*mpb.Metadata_Deviations).GetGnoiSwitchoverReasonMissingUserInitiated)
 }
 
 // P4rtUnsetElectionIDPrimaryAllowed returns whether the device does not support unset election ID.
@@ -87,7 +95,7 @@ func P4rtUnsetElectionIDPrimaryAllowed(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_p4rt_unsetelectionid_primary_allowed") {
 		return *p4rtUnsetElectionIDPrimaryAllowed
 	}
-	return lookupDUTDeviations(dut).GetP4RtUnsetelectionidPrimaryAllowed()
+	return dutDeviation(dut, "P4rtUnsetElectionIDPrimaryAllowed", (*mpb.Metadata_Deviations).GetP4RtUnsetelectionidPrimaryAllowed)
 }
 
 // P4rtBackupArbitrationResponseCode returns whether the device does not support unset election ID.
@@ -95,7 +103,7 @@ func P4rtBackupArbitrationResponseCode(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_bkup_arbitration_resp_code") {
 		return *p4rtBackupArbitrationResponseCode
 	}
-	return lookupDUTDeviations(dut).GetBkupArbitrationRespCode()
+	return dutDeviation(dut, "P4rtBackupArbitrationResponseCode", (*mpb.Metadata_Deviations).GetBkupArbitrationRespCode)
 }
 
 // BackupNHGRequiresVrfWithDecap returns true for devices that require
@@ -104,7 +112,7 @@ func BackupNHGRequiresVrfWithDecap(dut *ondatra.DUTDevice) bool {
 	if isFlagSet("deviation_backup_nhg_requires_vrf_with_decap") {
 		return *backupNHGRequiresVrfWithDecap
 	}
-	return lookupDUTDeviations(dut).GetBackupNhgRequiresVrfWithDecap()
+	return dutDeviation(dut, "BackupNHGRequiresVrfWithDecap", (*mpb.Metadata_Deviations).GetBackupNhgRequiresVrfWithDecap)
 }
 
 // ATEPortLinkStateOperationsUnsupported returns true for traffic generators that do not support
@@ -113,7 +121,7 @@ func ATEPortLinkStateOperationsUnsupported(ate *ondatra.ATEDevice) bool {
 	if isFlagSet("deviation_ate_port_link_state_operations_unsupported") {
 		return *atePortLinkStateOperationsUnsupported
 	}
-	return lookupATEDeviations(ate).GetAtePortLinkStateOperationsUnsupported()
+	return ateDeviation(ate, "ATEPortLinkStateOperationsUnsupported", (*mpb.Metadata_Deviations).GetAtePortLinkStateOperationsUnsupported)
 }
 
 // ATEIPv6FlowLabelUnsupported returns true for traffic generators that do not support
@@ -122,5 +130,5 @@ func ATEIPv6FlowLabelUnsupported(ate *ondatra.ATEDevice) bool {
 	if isFlagSet("deviation_ate_ipv6_flow_label_unsupported") {
 		return *ateIPv6FlowLabelUnsupported
 	}
-	return lookupATEDeviations(ate).GetAteIpv6FlowLabelUnsupported()
+	return ateDeviation(ate, "ATEIPv6FlowLabelUnsupported", (*mpb.Metadata_Deviations).GetAteIpv6FlowLabelUnsupported)
 }
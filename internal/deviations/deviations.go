@@ -26,7 +26,8 @@
 //
 // Deviations typically work by reducing testing requirements or by changing the way the
 // configuration is done.  However, the targeted compliance tier is always without
-// deviation.
+// deviation.  Run a test with -strict_compliance to force every deviation to its
+// OpenConfig compliant default and check for Tier 1 compliance.
 //
 // Requirements for deviations:
 //
@@ -45,49 +46,27 @@ package deviations
 
 import (
 	"fmt"
-	"regexp"
 
 	log "github.com/golang/glog"
 	"github.com/openconfig/featureprofiles/internal/metadata"
 
 	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
 	"github.com/openconfig/ondatra"
+	opb "github.com/openconfig/ondatra/proto"
 )
 
 func lookupDeviations(dvc *ondatra.Device) (*mpb.Metadata_PlatformExceptions, error) {
 	var matchedPlatformException *mpb.Metadata_PlatformExceptions
 
 	for _, platformExceptions := range metadata.Get().GetPlatformExceptions() {
-		if platformExceptions.GetPlatform().GetVendor().String() == "" {
-			return nil, fmt.Errorf("vendor should be specified in textproto %v", platformExceptions)
+		matched, err := matchPlatform(platformExceptions.GetPlatform(), opb.Device_Vendor(dvc.Vendor()), dvc.Model(), dvc.Version())
+		if err != nil {
+			return nil, err
 		}
-
-		if dvc.Vendor().String() != platformExceptions.GetPlatform().GetVendor().String() {
+		if !matched {
 			continue
 		}
 
-		// If hardware_model_regex is set and does not match, continue
-		if hardwareModelRegex := platformExceptions.GetPlatform().GetHardwareModelRegex(); hardwareModelRegex != "" {
-			matchHw, errHw := regexp.MatchString(hardwareModelRegex, dvc.Model())
-			if errHw != nil {
-				return nil, fmt.Errorf("error with regex match %v", errHw)
-			}
-			if !matchHw {
-				continue
-			}
-		}
-
-		// If software_version_regex is set and does not match, continue
-		if softwareVersionRegex := platformExceptions.GetPlatform().GetSoftwareVersionRegex(); softwareVersionRegex != "" {
-			matchSw, errSw := regexp.MatchString(softwareVersionRegex, dvc.Version())
-			if errSw != nil {
-				return nil, fmt.Errorf("error with regex match %v", errSw)
-			}
-			if !matchSw {
-				continue
-			}
-		}
-
 		if matchedPlatformException != nil {
 			return nil, fmt.Errorf("cannot have more than one match within platform_exceptions fields %v and %v", matchedPlatformException, platformExceptions)
 		}
@@ -108,1076 +87,1086 @@ func mustLookupDeviations(dvc *ondatra.Device) *mpb.Metadata_Deviations {
 	return platformExceptions.GetDeviations()
 }
 
-func lookupDUTDeviations(dut *ondatra.DUTDevice) *mpb.Metadata_Deviations {
-	return mustLookupDeviations(dut.Device)
+// dutDeviation returns the deviation read by get from the deviations of the
+// DUT, on behalf of the accessor.
+func dutDeviation[T comparable](dut *ondatra.DUTDevice, accessor string, get func(*mpb.Metadata_Deviations) T) T {
+	return exerciseDeviation(dut.Name(), mustLookupDeviations(dut.Device), accessor, get)
 }
 
-func lookupATEDeviations(ate *ondatra.ATEDevice) *mpb.Metadata_Deviations {
-	return mustLookupDeviations(ate.Device)
+// ateDeviation returns the deviation read by get from the deviations of the
+// ATE, on behalf of the accessor.
+func ateDeviation[T comparable](ate *ondatra.ATEDevice, accessor string, get func(*mpb.Metadata_Deviations) T) T {
+	return exerciseDeviation(ate.Name(), mustLookupDeviations(ate.Device), accessor, get)
 }
 
 // BannerDelimiter returns if device requires the banner to have a delimiter character.
 // Full OpenConfig compliant devices should work without delimiter.
 func BannerDelimiter(dut *ondatra.DUTDevice) string {
-	return lookupDUTDeviations(dut).GetBannerDelimiter()
+	return dutDeviation(dut, "BannerDelimiter", (*mpb.Metadata_Deviations).GetBannerDelimiter)
 }
 
 // OmitL2MTU returns if device does not support setting the L2 MTU.
 func OmitL2MTU(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOmitL2Mtu()
+	return dutDeviation(dut, "OmitL2MTU", (*mpb.Metadata_Deviations).GetOmitL2Mtu)
 }
 
 // GRIBIMACOverrideStaticARPStaticRoute returns whether the device needs to configure Static ARP + Static Route to override setting MAC address in Next Hop.
 func GRIBIMACOverrideStaticARPStaticRoute(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGribiMacOverrideStaticArpStaticRoute()
+	return dutDeviation(dut, "GRIBIMACOverrideStaticARPStaticRoute", (*mpb.Metadata_Deviations).GetGribiMacOverrideStaticArpStaticRoute)
 }
 
 // AggregateAtomicUpdate returns if device requires that aggregate Port-Channel and its members be defined in a single gNMI Update transaction at /interfaces,
 // Otherwise lag-type will be dropped, and no member can be added to the aggregate.
 // Full OpenConfig compliant devices should pass both with and without this deviation.
 func AggregateAtomicUpdate(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAggregateAtomicUpdate()
+	return dutDeviation(dut, "AggregateAtomicUpdate", (*mpb.Metadata_Deviations).GetAggregateAtomicUpdate)
 }
 
 // DefaultNetworkInstance returns the name used for the default network instance for VRF.
 func DefaultNetworkInstance(dut *ondatra.DUTDevice) string {
-	if dni := lookupDUTDeviations(dut).GetDefaultNetworkInstance(); dni != "" {
-		return dni
-	}
-	return "DEFAULT"
+	return dutDeviation(dut, "DefaultNetworkInstance", func(devs *mpb.Metadata_Deviations) string {
+		if dni := devs.GetDefaultNetworkInstance(); dni != "" {
+			return dni
+		}
+		return "DEFAULT"
+	})
 }
 
 // ISISRestartSuppressUnsupported returns whether the device should skip isis restart-suppress check.
 func ISISRestartSuppressUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisRestartSuppressUnsupported()
+	return dutDeviation(dut, "ISISRestartSuppressUnsupported", (*mpb.Metadata_Deviations).GetIsisRestartSuppressUnsupported)
 }
 
 // BgpGrHelperDisableUnsupported returns whether the device does not support to disable BGP GR Helper.
 func BgpGrHelperDisableUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpGrHelperDisableUnsupported()
+	return dutDeviation(dut, "BgpGrHelperDisableUnsupported", (*mpb.Metadata_Deviations).GetBgpGrHelperDisableUnsupported)
 }
 
 // BgpGracefulRestartUnderAfiSafiUnsupported returns whether the device does not support bgp GR-RESTART under AFI/SAFI.
 func BgpGracefulRestartUnderAfiSafiUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpGracefulRestartUnderAfiSafiUnsupported()
+	return dutDeviation(dut, "BgpGracefulRestartUnderAfiSafiUnsupported", (*mpb.Metadata_Deviations).GetBgpGracefulRestartUnderAfiSafiUnsupported)
 }
 
 // MissingBgpLastNotificationErrorCode returns whether the last-notification-error-code leaf is missing in bgp.
 func MissingBgpLastNotificationErrorCode(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingBgpLastNotificationErrorCode()
+	return dutDeviation(dut, "MissingBgpLastNotificationErrorCode", (*mpb.Metadata_Deviations).GetMissingBgpLastNotificationErrorCode)
 }
 
 // GRIBIMACOverrideWithStaticARP returns whether for a gRIBI IPv4 route the device does not support a mac-address only next-hop-entry.
 func GRIBIMACOverrideWithStaticARP(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGribiMacOverrideWithStaticArp()
+	return dutDeviation(dut, "GRIBIMACOverrideWithStaticARP", (*mpb.Metadata_Deviations).GetGribiMacOverrideWithStaticArp)
 }
 
 // CLITakesPrecedenceOverOC returns whether config pushed through origin CLI takes precedence over config pushed through origin OC.
 func CLITakesPrecedenceOverOC(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetCliTakesPrecedenceOverOc()
+	return dutDeviation(dut, "CLITakesPrecedenceOverOC", (*mpb.Metadata_Deviations).GetCliTakesPrecedenceOverOc)
 }
 
 // BGPTrafficTolerance returns the allowed tolerance for BGP traffic flow while comparing for pass or fail conditions.
 func BGPTrafficTolerance(dut *ondatra.DUTDevice) int32 {
-	return lookupDUTDeviations(dut).GetBgpToleranceValue()
+	return dutDeviation(dut, "BGPTrafficTolerance", (*mpb.Metadata_Deviations).GetBgpToleranceValue)
 }
 
 // StaticProtocolName returns the name used for the static routing protocol.
 func StaticProtocolName(dut *ondatra.DUTDevice) string {
-	if spn := lookupDUTDeviations(dut).GetStaticProtocolName(); spn != "" {
-		return spn
-	}
-	return "DEFAULT"
+	return dutDeviation(dut, "StaticProtocolName", func(devs *mpb.Metadata_Deviations) string {
+		if spn := devs.GetStaticProtocolName(); spn != "" {
+			return spn
+		}
+		return "DEFAULT"
+	})
 }
 
 // SwitchChipIDUnsupported returns whether the device supports id leaf for SwitchChip components.
 func SwitchChipIDUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSwitchChipIdUnsupported()
+	return dutDeviation(dut, "SwitchChipIDUnsupported", (*mpb.Metadata_Deviations).GetSwitchChipIdUnsupported)
 }
 
 // BackplaneFacingCapacityUnsupported returns whether the device supports backplane-facing-capacity leaves for some components.
 func BackplaneFacingCapacityUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBackplaneFacingCapacityUnsupported()
+	return dutDeviation(dut, "BackplaneFacingCapacityUnsupported", (*mpb.Metadata_Deviations).GetBackplaneFacingCapacityUnsupported)
 }
 
 // SchedulerInputWeightLimit returns whether the device does not support weight above 100.
 func SchedulerInputWeightLimit(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSchedulerInputWeightLimit()
+	return dutDeviation(dut, "SchedulerInputWeightLimit", (*mpb.Metadata_Deviations).GetSchedulerInputWeightLimit)
 }
 
 // ECNProfileRequiredDefinition returns whether the device requires additional config for ECN.
 func ECNProfileRequiredDefinition(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEcnProfileRequiredDefinition()
+	return dutDeviation(dut, "ECNProfileRequiredDefinition", (*mpb.Metadata_Deviations).GetEcnProfileRequiredDefinition)
 }
 
 // ISISGlobalAuthenticationNotRequired returns true if ISIS Global authentication not required.
 func ISISGlobalAuthenticationNotRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisGlobalAuthenticationNotRequired()
+	return dutDeviation(dut, "ISISGlobalAuthenticationNotRequired", (*mpb.Metadata_Deviations).GetIsisGlobalAuthenticationNotRequired)
 }
 
 // ISISExplicitLevelAuthenticationConfig returns true if ISIS Explicit Level Authentication configuration is required
 func ISISExplicitLevelAuthenticationConfig(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisExplicitLevelAuthenticationConfig()
+	return dutDeviation(dut, "ISISExplicitLevelAuthenticationConfig", (*mpb.Metadata_Deviations).GetIsisExplicitLevelAuthenticationConfig)
 }
 
 // ISISSingleTopologyRequired sets isis af ipv6 single topology on the device if value is true.
 func ISISSingleTopologyRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisSingleTopologyRequired()
+	return dutDeviation(dut, "ISISSingleTopologyRequired", (*mpb.Metadata_Deviations).GetIsisSingleTopologyRequired)
 }
 
 // ISISMultiTopologyUnsupported returns if device skips isis multi-topology check.
 func ISISMultiTopologyUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisMultiTopologyUnsupported()
+	return dutDeviation(dut, "ISISMultiTopologyUnsupported", (*mpb.Metadata_Deviations).GetIsisMultiTopologyUnsupported)
 }
 
 // ISISInterfaceLevel1DisableRequired returns if device should disable isis level1 under interface mode.
 func ISISInterfaceLevel1DisableRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisInterfaceLevel1DisableRequired()
+	return dutDeviation(dut, "ISISInterfaceLevel1DisableRequired", (*mpb.Metadata_Deviations).GetIsisInterfaceLevel1DisableRequired)
 }
 
 // MissingIsisInterfaceAfiSafiEnable returns if device should set and validate isis interface address family enable.
 // Default is validate isis address family enable at global mode.
 func MissingIsisInterfaceAfiSafiEnable(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingIsisInterfaceAfiSafiEnable()
+	return dutDeviation(dut, "MissingIsisInterfaceAfiSafiEnable", (*mpb.Metadata_Deviations).GetMissingIsisInterfaceAfiSafiEnable)
 }
 
 // Ipv6DiscardedPktsUnsupported returns whether the device supports interface ipv6 discarded packet stats.
 func Ipv6DiscardedPktsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv6DiscardedPktsUnsupported()
+	return dutDeviation(dut, "Ipv6DiscardedPktsUnsupported", (*mpb.Metadata_Deviations).GetIpv6DiscardedPktsUnsupported)
 }
 
 // LinkQualWaitAfterDeleteRequired returns whether the device requires additional time to complete post delete link qualification cleanup.
 func LinkQualWaitAfterDeleteRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLinkQualWaitAfterDeleteRequired()
+	return dutDeviation(dut, "LinkQualWaitAfterDeleteRequired", (*mpb.Metadata_Deviations).GetLinkQualWaitAfterDeleteRequired)
 }
 
 // StatePathsUnsupported returns whether the device supports following state paths
 func StatePathsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStatePathUnsupported()
+	return dutDeviation(dut, "StatePathsUnsupported", (*mpb.Metadata_Deviations).GetStatePathUnsupported)
 }
 
 // DropWeightLeavesUnsupported returns whether the device supports drop and weight leaves under queue management profile.
 func DropWeightLeavesUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDropWeightLeavesUnsupported()
+	return dutDeviation(dut, "DropWeightLeavesUnsupported", (*mpb.Metadata_Deviations).GetDropWeightLeavesUnsupported)
 }
 
 // SwVersionUnsupported returns true if the device does not support reporting software version according to the requirements in gNMI-1.10.
 func SwVersionUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSwVersionUnsupported()
+	return dutDeviation(dut, "SwVersionUnsupported", (*mpb.Metadata_Deviations).GetSwVersionUnsupported)
 }
 
 // HierarchicalWeightResolutionTolerance returns the allowed tolerance for BGP traffic flow while comparing for pass or fail conditions.
 // Default minimum value is 0.2. Anything less than 0.2 will be set to 0.2.
 func HierarchicalWeightResolutionTolerance(dut *ondatra.DUTDevice) float64 {
-	hwrt := lookupDUTDeviations(dut).GetHierarchicalWeightResolutionTolerance()
-	if minHWRT := 0.2; hwrt < minHWRT {
-		return minHWRT
-	}
-	return hwrt
+	return dutDeviation(dut, "HierarchicalWeightResolutionTolerance", func(devs *mpb.Metadata_Deviations) float64 {
+		hwrt := devs.GetHierarchicalWeightResolutionTolerance()
+		if minHWRT := 0.2; hwrt < minHWRT {
+			return minHWRT
+		}
+		return hwrt
+	})
 }
 
 // InterfaceEnabled returns if device requires interface enabled leaf booleans to be explicitly set to true.
 func InterfaceEnabled(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceEnabled()
+	return dutDeviation(dut, "InterfaceEnabled", (*mpb.Metadata_Deviations).GetInterfaceEnabled)
 }
 
 // InterfaceCountersFromContainer returns if the device only supports querying counters from the state container, not from individual counter leaves.
 func InterfaceCountersFromContainer(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceCountersFromContainer()
+	return dutDeviation(dut, "InterfaceCountersFromContainer", (*mpb.Metadata_Deviations).GetInterfaceCountersFromContainer)
 }
 
 // IPv4MissingEnabled returns if device does not support interface/ipv4/enabled.
 func IPv4MissingEnabled(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv4MissingEnabled()
+	return dutDeviation(dut, "IPv4MissingEnabled", (*mpb.Metadata_Deviations).GetIpv4MissingEnabled)
 }
 
 // IPNeighborMissing returns true if the device does not support interface/ipv4(6)/neighbor,
 // so test can suppress the related check for interface/ipv4(6)/neighbor.
 func IPNeighborMissing(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpNeighborMissing()
+	return dutDeviation(dut, "IPNeighborMissing", (*mpb.Metadata_Deviations).GetIpNeighborMissing)
 }
 
 // GRIBIRIBAckOnly returns if device only supports RIB ack, so tests that normally expect FIB_ACK will allow just RIB_ACK.
 // Full gRIBI compliant devices should pass both with and without this deviation.
 func GRIBIRIBAckOnly(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGribiRibackOnly()
+	return dutDeviation(dut, "GRIBIRIBAckOnly", (*mpb.Metadata_Deviations).GetGribiRibackOnly)
 }
 
 // MissingValueForDefaults returns if device returns no value for some OpenConfig paths if the operational value equals the default.
 func MissingValueForDefaults(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingValueForDefaults()
+	return dutDeviation(dut, "MissingValueForDefaults", (*mpb.Metadata_Deviations).GetMissingValueForDefaults)
 }
 
 // TraceRouteL4ProtocolUDP returns if device only support UDP as l4 protocol for traceroute.
 // Default value is false.
 func TraceRouteL4ProtocolUDP(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTracerouteL4ProtocolUdp()
+	return dutDeviation(dut, "TraceRouteL4ProtocolUDP", (*mpb.Metadata_Deviations).GetTracerouteL4ProtocolUdp)
 }
 
 // LLDPInterfaceConfigOverrideGlobal returns if LLDP interface config should override the global config,
 // expect neighbours are seen when lldp is disabled globally but enabled on interface
 func LLDPInterfaceConfigOverrideGlobal(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLldpInterfaceConfigOverrideGlobal()
+	return dutDeviation(dut, "LLDPInterfaceConfigOverrideGlobal", (*mpb.Metadata_Deviations).GetLldpInterfaceConfigOverrideGlobal)
 }
 
 // SubinterfacePacketCountersMissing returns if device is missing subinterface packet counters for IPv4/IPv6,
 // so the test will skip checking them.
 // Full OpenConfig compliant devices should pass both with and without this deviation.
 func SubinterfacePacketCountersMissing(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSubinterfacePacketCountersMissing()
+	return dutDeviation(dut, "SubinterfacePacketCountersMissing", (*mpb.Metadata_Deviations).GetSubinterfacePacketCountersMissing)
 }
 
 // MissingPrePolicyReceivedRoutes returns if device does not support bgp/neighbors/neighbor/afi-safis/afi-safi/state/prefixes/received-pre-policy.
 // Fully-compliant devices should pass with and without this deviation.
 func MissingPrePolicyReceivedRoutes(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPrepolicyReceivedRoutes()
+	return dutDeviation(dut, "MissingPrePolicyReceivedRoutes", (*mpb.Metadata_Deviations).GetPrepolicyReceivedRoutes)
 }
 
 // DeprecatedVlanID returns if device requires using the deprecated openconfig-vlan:vlan/config/vlan-id or openconfig-vlan:vlan/state/vlan-id leaves.
 func DeprecatedVlanID(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDeprecatedVlanId()
+	return dutDeviation(dut, "DeprecatedVlanID", (*mpb.Metadata_Deviations).GetDeprecatedVlanId)
 }
 
 // OSActivateNoReboot returns if device requires separate reboot to activate OS.
 func OSActivateNoReboot(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOsactivateNoreboot()
+	return dutDeviation(dut, "OSActivateNoReboot", (*mpb.Metadata_Deviations).GetOsactivateNoreboot)
 }
 
 // ConnectRetry returns if /bgp/neighbors/neighbor/timers/config/connect-retry is not supported.
 func ConnectRetry(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetConnectRetry()
+	return dutDeviation(dut, "ConnectRetry", (*mpb.Metadata_Deviations).GetConnectRetry)
 }
 
 // InstallOSForStandbyRP returns if device requires OS installation on standby RP as well as active RP.
 func InstallOSForStandbyRP(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOsinstallForStandbyRp()
+	return dutDeviation(dut, "InstallOSForStandbyRP", (*mpb.Metadata_Deviations).GetOsinstallForStandbyRp)
 }
 
 // GNOIStatusWithEmptySubcomponent returns if the response of gNOI reboot status is a single value (not a list),
 // the device requires explicit component path to account for a situation when there is more than one active reboot requests.
 func GNOIStatusWithEmptySubcomponent(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGnoiStatusEmptySubcomponent()
+	return dutDeviation(dut, "GNOIStatusWithEmptySubcomponent", (*mpb.Metadata_Deviations).GetGnoiStatusEmptySubcomponent)
 }
 
 // NetworkInstanceTableDeletionRequired returns if device requires explicit deletion of network-instance table.
 func NetworkInstanceTableDeletionRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNetworkInstanceTableDeletionRequired()
+	return dutDeviation(dut, "NetworkInstanceTableDeletionRequired", (*mpb.Metadata_Deviations).GetNetworkInstanceTableDeletionRequired)
 }
 
 // ExplicitPortSpeed returns if device requires port-speed to be set because its default value may not be usable.
 // Fully compliant devices selects the highest speed available based on negotiation.
 func ExplicitPortSpeed(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetExplicitPortSpeed()
+	return dutDeviation(dut, "ExplicitPortSpeed", (*mpb.Metadata_Deviations).GetExplicitPortSpeed)
 }
 
 // ExplicitInterfaceInDefaultVRF returns if device requires explicit attachment of an interface or subinterface to the default network instance.
 // OpenConfig expects an unattached interface or subinterface to be implicitly part of the default network instance.
 // Fully-compliant devices should pass with and without this deviation.
 func ExplicitInterfaceInDefaultVRF(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetExplicitInterfaceInDefaultVrf()
+	return dutDeviation(dut, "ExplicitInterfaceInDefaultVRF", (*mpb.Metadata_Deviations).GetExplicitInterfaceInDefaultVrf)
 }
 
 // RibWecmp returns if device requires CLI knob to enable wecmp feature.
 func RibWecmp(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetRibWecmp()
+	return dutDeviation(dut, "RibWecmp", (*mpb.Metadata_Deviations).GetRibWecmp)
 }
 
 // InterfaceConfigVRFBeforeAddress returns if vrf should be configured before IP address when configuring interface.
 func InterfaceConfigVRFBeforeAddress(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceConfigVrfBeforeAddress()
+	return dutDeviation(dut, "InterfaceConfigVRFBeforeAddress", (*mpb.Metadata_Deviations).GetInterfaceConfigVrfBeforeAddress)
 }
 
 // BGPMD5RequiresReset returns if device requires a BGP session reset to utilize a new MD5 key.
 func BGPMD5RequiresReset(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpMd5RequiresReset()
+	return dutDeviation(dut, "BGPMD5RequiresReset", (*mpb.Metadata_Deviations).GetBgpMd5RequiresReset)
 }
 
 // ExplicitIPv6EnableForGRIBI returns if device requires Ipv6 to be enabled on interface for gRIBI NH programmed with destination mac address.
 func ExplicitIPv6EnableForGRIBI(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv6EnableForGribiNhDmac()
+	return dutDeviation(dut, "ExplicitIPv6EnableForGRIBI", (*mpb.Metadata_Deviations).GetIpv6EnableForGribiNhDmac)
 }
 
 // ISISInstanceEnabledRequired returns if isis instance name string should be set on the device.
 func ISISInstanceEnabledRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisInstanceEnabledRequired()
+	return dutDeviation(dut, "ISISInstanceEnabledRequired", (*mpb.Metadata_Deviations).GetIsisInstanceEnabledRequired)
 }
 
 // GNOISubcomponentPath returns if device currently uses component name instead of a full openconfig path.
 func GNOISubcomponentPath(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGnoiSubcomponentPath()
+	return dutDeviation(dut, "GNOISubcomponentPath", (*mpb.Metadata_Deviations).GetGnoiSubcomponentPath)
 }
 
 // NoMixOfTaggedAndUntaggedSubinterfaces returns if device does not support a mix of tagged and untagged subinterfaces
 func NoMixOfTaggedAndUntaggedSubinterfaces(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNoMixOfTaggedAndUntaggedSubinterfaces()
+	return dutDeviation(dut, "NoMixOfTaggedAndUntaggedSubinterfaces", (*mpb.Metadata_Deviations).GetNoMixOfTaggedAndUntaggedSubinterfaces)
 }
 
 // DequeueDeleteNotCountedAsDrops returns if device dequeues and deletes the pkts after a while and those are not counted
 // as drops
 func DequeueDeleteNotCountedAsDrops(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDequeueDeleteNotCountedAsDrops()
+	return dutDeviation(dut, "DequeueDeleteNotCountedAsDrops", (*mpb.Metadata_Deviations).GetDequeueDeleteNotCountedAsDrops)
 }
 
 // RoutePolicyUnderAFIUnsupported returns if Route-Policy under the AFI/SAFI is not supported
 func RoutePolicyUnderAFIUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetRoutePolicyUnderAfiUnsupported()
+	return dutDeviation(dut, "RoutePolicyUnderAFIUnsupported", (*mpb.Metadata_Deviations).GetRoutePolicyUnderAfiUnsupported)
 }
 
 // StorageComponentUnsupported returns if telemetry path /components/component/storage is not supported.
 func StorageComponentUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStorageComponentUnsupported()
+	return dutDeviation(dut, "StorageComponentUnsupported", (*mpb.Metadata_Deviations).GetStorageComponentUnsupported)
 }
 
 // GNOIFabricComponentRebootUnsupported returns if device does not support use using gNOI to reboot the Fabric Component.
 func GNOIFabricComponentRebootUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGnoiFabricComponentRebootUnsupported()
+	return dutDeviation(dut, "GNOIFabricComponentRebootUnsupported", (*mpb.Metadata_Deviations).GetGnoiFabricComponentRebootUnsupported)
 }
 
 // NtpNonDefaultVrfUnsupported returns true if the device does not support ntp non-default vrf.
 // Default value is false.
 func NtpNonDefaultVrfUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNtpNonDefaultVrfUnsupported()
+	return dutDeviation(dut, "NtpNonDefaultVrfUnsupported", (*mpb.Metadata_Deviations).GetNtpNonDefaultVrfUnsupported)
 }
 
 // SkipControllerCardPowerAdmin returns if power-admin-state config on controller card should be skipped.
 // Default value is false.
 func SkipControllerCardPowerAdmin(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipControllerCardPowerAdmin()
+	return dutDeviation(dut, "SkipControllerCardPowerAdmin", (*mpb.Metadata_Deviations).GetSkipControllerCardPowerAdmin)
 }
 
 // QOSOctets returns if device should skip checking QOS octet stats for interface.
 func QOSOctets(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosOctets()
+	return dutDeviation(dut, "QOSOctets", (*mpb.Metadata_Deviations).GetQosOctets)
 }
 
 // ISISInterfaceAfiUnsupported returns true for devices that don't support configuring
 // ISIS /afi-safi/af/config container.
 func ISISInterfaceAfiUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisInterfaceAfiUnsupported()
+	return dutDeviation(dut, "ISISInterfaceAfiUnsupported", (*mpb.Metadata_Deviations).GetIsisInterfaceAfiUnsupported)
 }
 
 // P4RTModifyTableEntryUnsupported returns true for devices that don't support
 // modify table entry operation in P4 Runtime.
 func P4RTModifyTableEntryUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetP4RtModifyTableEntryUnsupported()
+	return dutDeviation(dut, "P4RTModifyTableEntryUnsupported", (*mpb.Metadata_Deviations).GetP4RtModifyTableEntryUnsupported)
 }
 
 // OSComponentParentIsSupervisorOrLinecard returns true if parent of OS component is
 // of type SUPERVISOR or LINECARD.
 func OSComponentParentIsSupervisorOrLinecard(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOsComponentParentIsSupervisorOrLinecard()
+	return dutDeviation(dut, "OSComponentParentIsSupervisorOrLinecard", (*mpb.Metadata_Deviations).GetOsComponentParentIsSupervisorOrLinecard)
 }
 
 // OSComponentParentIsChassis returns true if parent of OS component is of type CHASSIS.
 func OSComponentParentIsChassis(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOsComponentParentIsChassis()
+	return dutDeviation(dut, "OSComponentParentIsChassis", (*mpb.Metadata_Deviations).GetOsComponentParentIsChassis)
 }
 
 // ISISRequireSameL1MetricWithL2Metric returns true for devices that require configuring
 // the same ISIS Metrics for Level 1 when configuring Level 2 Metrics.
 func ISISRequireSameL1MetricWithL2Metric(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisRequireSameL1MetricWithL2Metric()
+	return dutDeviation(dut, "ISISRequireSameL1MetricWithL2Metric", (*mpb.Metadata_Deviations).GetIsisRequireSameL1MetricWithL2Metric)
 }
 
 // BGPSetMedRequiresEqualOspfSetMetric returns true for devices that require configuring
 // the same OSPF setMetric when BGP SetMED is configured.
 func BGPSetMedRequiresEqualOspfSetMetric(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpSetMedRequiresEqualOspfSetMetric()
+	return dutDeviation(dut, "BGPSetMedRequiresEqualOspfSetMetric", (*mpb.Metadata_Deviations).GetBgpSetMedRequiresEqualOspfSetMetric)
 }
 
 // SetNativeUser creates a user and assigns role/rbac to that user via native model.
 func SetNativeUser(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSetNativeUser()
+	return dutDeviation(dut, "SetNativeUser", (*mpb.Metadata_Deviations).GetSetNativeUser)
 }
 
 // P4RTGdpRequiresDot1QSubinterface returns true for devices that require configuring
 // subinterface with tagged vlan for P4RT packet in.
 func P4RTGdpRequiresDot1QSubinterface(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetP4RtGdpRequiresDot1QSubinterface()
+	return dutDeviation(dut, "P4RTGdpRequiresDot1QSubinterface", (*mpb.Metadata_Deviations).GetP4RtGdpRequiresDot1QSubinterface)
 }
 
 // LinecardCPUUtilizationUnsupported returns if the device does not support telemetry path
 // /components/component/cpu/utilization/state/avg for linecards' CPU card.
 // Default value is false.
 func LinecardCPUUtilizationUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLinecardCpuUtilizationUnsupported()
+	return dutDeviation(dut, "LinecardCPUUtilizationUnsupported", (*mpb.Metadata_Deviations).GetLinecardCpuUtilizationUnsupported)
 }
 
 // ConsistentComponentNamesUnsupported returns if the device does not support consistent component names for GNOI and GNMI.
 // Default value is false.
 func ConsistentComponentNamesUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetConsistentComponentNamesUnsupported()
+	return dutDeviation(dut, "ConsistentComponentNamesUnsupported", (*mpb.Metadata_Deviations).GetConsistentComponentNamesUnsupported)
 }
 
 // ControllerCardCPUUtilizationUnsupported returns if the device does not support telemetry path
 // /components/component/cpu/utilization/state/avg for controller cards' CPU card.
 // Default value is false.
 func ControllerCardCPUUtilizationUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetControllerCardCpuUtilizationUnsupported()
+	return dutDeviation(dut, "ControllerCardCPUUtilizationUnsupported", (*mpb.Metadata_Deviations).GetControllerCardCpuUtilizationUnsupported)
 }
 
 // FabricDropCounterUnsupported returns if the device does not support counter for fabric block lost packets.
 // Default value is false.
 func FabricDropCounterUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetFabricDropCounterUnsupported()
+	return dutDeviation(dut, "FabricDropCounterUnsupported", (*mpb.Metadata_Deviations).GetFabricDropCounterUnsupported)
 }
 
 // LinecardMemoryUtilizationUnsupported returns if the device does not support memory utilization related leaves for linecard components.
 // Default value is false.
 func LinecardMemoryUtilizationUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLinecardMemoryUtilizationUnsupported()
+	return dutDeviation(dut, "LinecardMemoryUtilizationUnsupported", (*mpb.Metadata_Deviations).GetLinecardMemoryUtilizationUnsupported)
 }
 
 // QOSVoqDropCounterUnsupported returns if the device does not support telemetry path
 // /qos/interfaces/interface/input/virtual-output-queues/voq-interface/queues/queue/state/dropped-pkts.
 // Default value is false.
 func QOSVoqDropCounterUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosVoqDropCounterUnsupported()
+	return dutDeviation(dut, "QOSVoqDropCounterUnsupported", (*mpb.Metadata_Deviations).GetQosVoqDropCounterUnsupported)
 }
 
 // ISISTimersCsnpIntervalUnsupported returns true for devices that do not support
 // configuring csnp-interval timer for ISIS.
 func ISISTimersCsnpIntervalUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisTimersCsnpIntervalUnsupported()
+	return dutDeviation(dut, "ISISTimersCsnpIntervalUnsupported", (*mpb.Metadata_Deviations).GetIsisTimersCsnpIntervalUnsupported)
 }
 
 // ISISCounterManualAddressDropFromAreasUnsupported returns true for devices that do not
 // support telemetry for isis system-level-counter manual-address-drop-from-areas.
 func ISISCounterManualAddressDropFromAreasUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisCounterManualAddressDropFromAreasUnsupported()
+	return dutDeviation(dut, "ISISCounterManualAddressDropFromAreasUnsupported", (*mpb.Metadata_Deviations).GetIsisCounterManualAddressDropFromAreasUnsupported)
 }
 
 // ISISCounterPartChangesUnsupported returns true for devices that do not
 // support telemetry for isis system-level-counter part-changes.
 func ISISCounterPartChangesUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisCounterPartChangesUnsupported()
+	return dutDeviation(dut, "ISISCounterPartChangesUnsupported", (*mpb.Metadata_Deviations).GetIsisCounterPartChangesUnsupported)
 }
 
 // SkipTCPNegotiatedMSSCheck returns true for devices that do not
 // support telemetry to check negotiated tcp mss value.
 func SkipTCPNegotiatedMSSCheck(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipTcpNegotiatedMssCheck()
+	return dutDeviation(dut, "SkipTCPNegotiatedMSSCheck", (*mpb.Metadata_Deviations).GetSkipTcpNegotiatedMssCheck)
 }
 
 // TransceiverThresholdsUnsupported returns true if the device does not support threshold container under /components/component/transceiver.
 // Default value is false.
 func TransceiverThresholdsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTransceiverThresholdsUnsupported()
+	return dutDeviation(dut, "TransceiverThresholdsUnsupported", (*mpb.Metadata_Deviations).GetTransceiverThresholdsUnsupported)
 }
 
 // InterfaceLoopbackModeRawGnmi returns true if interface loopback mode needs to be updated using raw gnmi API due to server version.
 // Default value is false.
 func InterfaceLoopbackModeRawGnmi(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceLoopbackModeRawGnmi()
+	return dutDeviation(dut, "InterfaceLoopbackModeRawGnmi", (*mpb.Metadata_Deviations).GetInterfaceLoopbackModeRawGnmi)
 }
 
 // ISISLspMetadataLeafsUnsupported returns true for devices that don't support ISIS-Lsp
 // metadata paths: checksum, sequence-number, remaining-lifetime.
 func ISISLspMetadataLeafsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisLspMetadataLeafsUnsupported()
+	return dutDeviation(dut, "ISISLspMetadataLeafsUnsupported", (*mpb.Metadata_Deviations).GetIsisLspMetadataLeafsUnsupported)
 }
 
 // QOSQueueRequiresID returns if device should configure QOS queue along with queue-id
 func QOSQueueRequiresID(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosQueueRequiresId()
+	return dutDeviation(dut, "QOSQueueRequiresID", (*mpb.Metadata_Deviations).GetQosQueueRequiresId)
 }
 
 // BgpLlgrOcUndefined returns true if device does not support OC path to disable BGP LLGR.
 func BgpLlgrOcUndefined(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpLlgrOcUndefined()
+	return dutDeviation(dut, "BgpLlgrOcUndefined", (*mpb.Metadata_Deviations).GetBgpLlgrOcUndefined)
 }
 
 // QOSBufferAllocationConfigRequired returns if device should configure QOS buffer-allocation-profile
 func QOSBufferAllocationConfigRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosBufferAllocationConfigRequired()
+	return dutDeviation(dut, "QOSBufferAllocationConfigRequired", (*mpb.Metadata_Deviations).GetQosBufferAllocationConfigRequired)
 }
 
 // BGPGlobalExtendedNextHopEncodingUnsupported returns true for devices that do not support configuring
 // BGP ExtendedNextHopEncoding at the global level.
 func BGPGlobalExtendedNextHopEncodingUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpGlobalExtendedNextHopEncodingUnsupported()
+	return dutDeviation(dut, "BGPGlobalExtendedNextHopEncodingUnsupported", (*mpb.Metadata_Deviations).GetBgpGlobalExtendedNextHopEncodingUnsupported)
 }
 
 // TunnelStatePathUnsupported returns true for devices that require configuring
 // /interfaces/interface/state/counters/in-pkts, in-octets,out-pkts, out-octetsis not supported.
 func TunnelStatePathUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTunnelStatePathUnsupported()
+	return dutDeviation(dut, "TunnelStatePathUnsupported", (*mpb.Metadata_Deviations).GetTunnelStatePathUnsupported)
 }
 
 // TunnelConfigPathUnsupported returns true for devices that require configuring
 // Tunnel source-address destination-address, encapsulation type are not supported in OC
 func TunnelConfigPathUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTunnelConfigPathUnsupported()
+	return dutDeviation(dut, "TunnelConfigPathUnsupported", (*mpb.Metadata_Deviations).GetTunnelConfigPathUnsupported)
 }
 
 // EcnSameMinMaxThresholdUnsupported returns true for devices that don't support the same minimum and maximum threshold values
 // CISCO: minimum and maximum threshold values are not the same, the difference between minimum and maximum threshold value should be 6144.
 func EcnSameMinMaxThresholdUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEcnSameMinMaxThresholdUnsupported()
+	return dutDeviation(dut, "EcnSameMinMaxThresholdUnsupported", (*mpb.Metadata_Deviations).GetEcnSameMinMaxThresholdUnsupported)
 }
 
 // QosSchedulerConfigRequired returns if device should configure QOS buffer-allocation-profile
 func QosSchedulerConfigRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosSchedulerConfigRequired()
+	return dutDeviation(dut, "QosSchedulerConfigRequired", (*mpb.Metadata_Deviations).GetQosSchedulerConfigRequired)
 }
 
 // QosSetWeightConfigUnsupported returns whether the device does not support set weight leaves under qos ecn.
 func QosSetWeightConfigUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosSetWeightConfigUnsupported()
+	return dutDeviation(dut, "QosSetWeightConfigUnsupported", (*mpb.Metadata_Deviations).GetQosSetWeightConfigUnsupported)
 }
 
 // QosGetStatePathUnsupported returns whether the device does not support get state leaves under qos.
 func QosGetStatePathUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosGetStatePathUnsupported()
+	return dutDeviation(dut, "QosGetStatePathUnsupported", (*mpb.Metadata_Deviations).GetQosGetStatePathUnsupported)
 }
 
 // InterfaceRefInterfaceIDFormat returns if device is required to use interface-id format of interface name + .subinterface index with Interface-ref container
 func InterfaceRefInterfaceIDFormat(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceRefInterfaceIdFormat()
+	return dutDeviation(dut, "InterfaceRefInterfaceIDFormat", (*mpb.Metadata_Deviations).GetInterfaceRefInterfaceIdFormat)
 }
 
 // ISISLevelEnabled returns if device should enable isis under level.
 func ISISLevelEnabled(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisLevelEnabled()
+	return dutDeviation(dut, "ISISLevelEnabled", (*mpb.Metadata_Deviations).GetIsisLevelEnabled)
 }
 
 // MemberLinkLoopbackUnsupported returns true for devices that require configuring
 // loopback on aggregated links instead of member links.
 func MemberLinkLoopbackUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMemberLinkLoopbackUnsupported()
+	return dutDeviation(dut, "MemberLinkLoopbackUnsupported", (*mpb.Metadata_Deviations).GetMemberLinkLoopbackUnsupported)
 }
 
 // SkipPlqInterfaceOperStatusCheck returns true for devices that do not support
 // PLQ operational status check for interfaces
 func SkipPlqInterfaceOperStatusCheck(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipPlqInterfaceOperStatusCheck()
+	return dutDeviation(dut, "SkipPlqInterfaceOperStatusCheck", (*mpb.Metadata_Deviations).GetSkipPlqInterfaceOperStatusCheck)
 }
 
 // BGPExplicitPrefixLimitReceived returns if device must specify the received prefix limits explicitly
 // under the "prefix-limit-received" field rather than simply "prefix-limit".
 func BGPExplicitPrefixLimitReceived(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpExplicitPrefixLimitReceived()
+	return dutDeviation(dut, "BGPExplicitPrefixLimitReceived", (*mpb.Metadata_Deviations).GetBgpExplicitPrefixLimitReceived)
 }
 
 // BGPMissingOCMaxPrefixesConfiguration returns true for devices that does not configure BGP
 // maximum routes correctly when max-prefixes OC leaf is configured.
 func BGPMissingOCMaxPrefixesConfiguration(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpMissingOcMaxPrefixesConfiguration()
+	return dutDeviation(dut, "BGPMissingOCMaxPrefixesConfiguration", (*mpb.Metadata_Deviations).GetBgpMissingOcMaxPrefixesConfiguration)
 }
 
 // SkipBgpSessionCheckWithoutAfisafi returns if device needs to skip checking AFI-SAFI disable.
 func SkipBgpSessionCheckWithoutAfisafi(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipBgpSessionCheckWithoutAfisafi()
+	return dutDeviation(dut, "SkipBgpSessionCheckWithoutAfisafi", (*mpb.Metadata_Deviations).GetSkipBgpSessionCheckWithoutAfisafi)
 }
 
 // MismatchedHardwareResourceNameInComponent returns true for devices that have separate
 // naming conventions for hardware resource name in /system/ tree and /components/ tree.
 func MismatchedHardwareResourceNameInComponent(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMismatchedHardwareResourceNameInComponent()
+	return dutDeviation(dut, "MismatchedHardwareResourceNameInComponent", (*mpb.Metadata_Deviations).GetMismatchedHardwareResourceNameInComponent)
 }
 
 // GNOISubcomponentRebootStatusUnsupported returns true for devices that do not support subcomponent reboot status check.
 func GNOISubcomponentRebootStatusUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGnoiSubcomponentRebootStatusUnsupported()
+	return dutDeviation(dut, "GNOISubcomponentRebootStatusUnsupported", (*mpb.Metadata_Deviations).GetGnoiSubcomponentRebootStatusUnsupported)
 }
 
 // SkipNonBgpRouteExportCheck returns true for devices that exports routes from all
 // protocols to BGP if the export-policy is ACCEPT.
 func SkipNonBgpRouteExportCheck(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipNonBgpRouteExportCheck()
+	return dutDeviation(dut, "SkipNonBgpRouteExportCheck", (*mpb.Metadata_Deviations).GetSkipNonBgpRouteExportCheck)
 }
 
 // ISISMetricStyleTelemetryUnsupported returns true for devices that do not support state path
 // /network-instances/network-instance/protocols/protocol/isis/levels/level/state/metric-style
 func ISISMetricStyleTelemetryUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisMetricStyleTelemetryUnsupported()
+	return dutDeviation(dut, "ISISMetricStyleTelemetryUnsupported", (*mpb.Metadata_Deviations).GetIsisMetricStyleTelemetryUnsupported)
 }
 
 // StaticRouteNextHopInterfaceRefUnsupported returns if device does not support Interface-ref under static-route next-hop
 func StaticRouteNextHopInterfaceRefUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticRouteNextHopInterfaceRefUnsupported()
+	return dutDeviation(dut, "StaticRouteNextHopInterfaceRefUnsupported", (*mpb.Metadata_Deviations).GetStaticRouteNextHopInterfaceRefUnsupported)
 }
 
 // SkipStaticNexthopCheck returns if device needs index starting from non-zero
 func SkipStaticNexthopCheck(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipStaticNexthopCheck()
+	return dutDeviation(dut, "SkipStaticNexthopCheck", (*mpb.Metadata_Deviations).GetSkipStaticNexthopCheck)
 }
 
 // Ipv6RouterAdvertisementConfigUnsupported returns true for devices which don't support Ipv6 RouterAdvertisement configuration
 func Ipv6RouterAdvertisementConfigUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv6RouterAdvertisementConfigUnsupported()
+	return dutDeviation(dut, "Ipv6RouterAdvertisementConfigUnsupported", (*mpb.Metadata_Deviations).GetIpv6RouterAdvertisementConfigUnsupported)
 }
 
 // PrefixLimitExceededTelemetryUnsupported is to skip checking prefix limit telemetry flag.
 func PrefixLimitExceededTelemetryUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPrefixLimitExceededTelemetryUnsupported()
+	return dutDeviation(dut, "PrefixLimitExceededTelemetryUnsupported", (*mpb.Metadata_Deviations).GetPrefixLimitExceededTelemetryUnsupported)
 }
 
 // SkipSettingAllowMultipleAS return true if device needs to skip setting allow-multiple-as while configuring eBGP
 func SkipSettingAllowMultipleAS(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipSettingAllowMultipleAs()
+	return dutDeviation(dut, "SkipSettingAllowMultipleAS", (*mpb.Metadata_Deviations).GetSkipSettingAllowMultipleAs)
 }
 
 // GribiDecapMixedPlenUnsupported returns true if devices does not support
 // programming with mixed prefix length.
 func GribiDecapMixedPlenUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGribiDecapMixedPlenUnsupported()
+	return dutDeviation(dut, "GribiDecapMixedPlenUnsupported", (*mpb.Metadata_Deviations).GetGribiDecapMixedPlenUnsupported)
 }
 
 // SkipIsisSetLevel return true if device needs to skip setting isis-actions set-level while configuring routing-policy statement action
 func SkipIsisSetLevel(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipIsisSetLevel()
+	return dutDeviation(dut, "SkipIsisSetLevel", (*mpb.Metadata_Deviations).GetSkipIsisSetLevel)
 }
 
 // SkipIsisSetMetricStyleType return true if device needs to skip setting isis-actions set-metric-style-type while configuring routing-policy statement action
 func SkipIsisSetMetricStyleType(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipIsisSetMetricStyleType()
+	return dutDeviation(dut, "SkipIsisSetMetricStyleType", (*mpb.Metadata_Deviations).GetSkipIsisSetMetricStyleType)
 }
 
 // SkipSettingDisableMetricPropagation return true if device needs to skip setting disable-metric-propagation while configuring table-connection
 func SkipSettingDisableMetricPropagation(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipSettingDisableMetricPropagation()
+	return dutDeviation(dut, "SkipSettingDisableMetricPropagation", (*mpb.Metadata_Deviations).GetSkipSettingDisableMetricPropagation)
 }
 
 // BGPConditionsMatchCommunitySetUnsupported returns true if device doesn't support bgp-conditions/match-community-set leaf
 func BGPConditionsMatchCommunitySetUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpConditionsMatchCommunitySetUnsupported()
+	return dutDeviation(dut, "BGPConditionsMatchCommunitySetUnsupported", (*mpb.Metadata_Deviations).GetBgpConditionsMatchCommunitySetUnsupported)
 }
 
 // PfRequireMatchDefaultRule returns true for device which requires match condition for ether type v4 and v6 for default rule with network-instance default-vrf in policy-forwarding.
 func PfRequireMatchDefaultRule(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPfRequireMatchDefaultRule()
+	return dutDeviation(dut, "PfRequireMatchDefaultRule", (*mpb.Metadata_Deviations).GetPfRequireMatchDefaultRule)
 }
 
 // MissingPortToOpticalChannelMapping returns true for devices missing component tree mapping from hardware port to optical channel.
 func MissingPortToOpticalChannelMapping(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingPortToOpticalChannelComponentMapping()
+	return dutDeviation(dut, "MissingPortToOpticalChannelMapping", (*mpb.Metadata_Deviations).GetMissingPortToOpticalChannelComponentMapping)
 }
 
 // SkipContainerOp returns true if gNMI container OP needs to be skipped.
 // Cisco: https://partnerissuetracker.corp.google.com/issues/322291556
 func SkipContainerOp(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipContainerOp()
+	return dutDeviation(dut, "SkipContainerOp", (*mpb.Metadata_Deviations).GetSkipContainerOp)
 }
 
 // ReorderCallsForVendorCompatibilty returns true if call needs to be updated/added/deleted.
 // Cisco: https://partnerissuetracker.corp.google.com/issues/322291556
 func ReorderCallsForVendorCompatibilty(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetReorderCallsForVendorCompatibilty()
+	return dutDeviation(dut, "ReorderCallsForVendorCompatibilty", (*mpb.Metadata_Deviations).GetReorderCallsForVendorCompatibilty)
 }
 
 // AddMissingBaseConfigViaCli returns true if missing base config needs to be added using CLI.
 // Cisco: https://partnerissuetracker.corp.google.com/issues/322291556
 func AddMissingBaseConfigViaCli(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAddMissingBaseConfigViaCli()
+	return dutDeviation(dut, "AddMissingBaseConfigViaCli", (*mpb.Metadata_Deviations).GetAddMissingBaseConfigViaCli)
 }
 
 // SkipMacaddressCheck returns true if mac address for an interface via gNMI needs to be skipped.
 // Cisco: https://partnerissuetracker.corp.google.com/issues/322291556
 func SkipMacaddressCheck(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipMacaddressCheck()
+	return dutDeviation(dut, "SkipMacaddressCheck", (*mpb.Metadata_Deviations).GetSkipMacaddressCheck)
 }
 
 // BGPRibOcPathUnsupported returns true if BGP RIB OC telemetry path is not supported.
 func BGPRibOcPathUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpRibOcPathUnsupported()
+	return dutDeviation(dut, "BGPRibOcPathUnsupported", (*mpb.Metadata_Deviations).GetBgpRibOcPathUnsupported)
 }
 
 // SkipPrefixSetMode return true if device needs to skip setting prefix-set mode while configuring prefix-set routing-policy
 func SkipPrefixSetMode(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipPrefixSetMode()
+	return dutDeviation(dut, "SkipPrefixSetMode", (*mpb.Metadata_Deviations).GetSkipPrefixSetMode)
 }
 
 // SetMetricAsPreference returns true for devices which set metric as
 // preference for static next-hop
 func SetMetricAsPreference(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSetMetricAsPreference()
+	return dutDeviation(dut, "SetMetricAsPreference", (*mpb.Metadata_Deviations).GetSetMetricAsPreference)
 }
 
 // IPv6StaticRouteWithIPv4NextHopRequiresStaticARP returns true if devices don't support having an
 // IPv6 static Route with an IPv4 address as next hop and requires configuring a static ARP entry.
 // Arista: https://partnerissuetracker.corp.google.com/issues/316593298
 func IPv6StaticRouteWithIPv4NextHopRequiresStaticARP(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv6StaticRouteWithIpv4NextHopRequiresStaticArp()
+	return dutDeviation(dut, "IPv6StaticRouteWithIPv4NextHopRequiresStaticARP", (*mpb.Metadata_Deviations).GetIpv6StaticRouteWithIpv4NextHopRequiresStaticArp)
 }
 
 // PfRequireSequentialOrderPbrRules returns true for device requires policy-forwarding rules to be in sequential order in the gNMI set-request.
 func PfRequireSequentialOrderPbrRules(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPfRequireSequentialOrderPbrRules()
+	return dutDeviation(dut, "PfRequireSequentialOrderPbrRules", (*mpb.Metadata_Deviations).GetPfRequireSequentialOrderPbrRules)
 }
 
 // MissingStaticRouteNextHopMetricTelemetry returns true for devices missing
 // static route next-hop metric telemetry.
 // Arista: https://partnerissuetracker.corp.google.com/issues/321010782
 func MissingStaticRouteNextHopMetricTelemetry(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingStaticRouteNextHopMetricTelemetry()
+	return dutDeviation(dut, "MissingStaticRouteNextHopMetricTelemetry", (*mpb.Metadata_Deviations).GetMissingStaticRouteNextHopMetricTelemetry)
 }
 
 // UnsupportedStaticRouteNextHopRecurse returns true for devices that don't support recursive
 // resolution of static route next hop.
 // Arista: https://partnerissuetracker.corp.google.com/issues/314449182
 func UnsupportedStaticRouteNextHopRecurse(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetUnsupportedStaticRouteNextHopRecurse()
+	return dutDeviation(dut, "UnsupportedStaticRouteNextHopRecurse", (*mpb.Metadata_Deviations).GetUnsupportedStaticRouteNextHopRecurse)
 }
 
 // MissingStaticRouteDropNextHopTelemetry returns true for devices missing
 // static route telemetry with DROP next hop.
 // Arista: https://partnerissuetracker.corp.google.com/issues/330619816
 func MissingStaticRouteDropNextHopTelemetry(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingStaticRouteDropNextHopTelemetry()
+	return dutDeviation(dut, "MissingStaticRouteDropNextHopTelemetry", (*mpb.Metadata_Deviations).GetMissingStaticRouteDropNextHopTelemetry)
 }
 
 // MissingZROpticalChannelTunableParametersTelemetry returns true for devices missing 400ZR
 // optical-channel tunable parameters telemetry: min/max/avg.
 // Arista: https://partnerissuetracker.corp.google.com/issues/319314781
 func MissingZROpticalChannelTunableParametersTelemetry(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingZrOpticalChannelTunableParametersTelemetry()
+	return dutDeviation(dut, "MissingZROpticalChannelTunableParametersTelemetry", (*mpb.Metadata_Deviations).GetMissingZrOpticalChannelTunableParametersTelemetry)
 }
 
 // PLQReflectorStatsUnsupported returns true for devices that does not support packet link qualification(PLQ) reflector packet sent/received stats.
 func PLQReflectorStatsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPlqReflectorStatsUnsupported()
+	return dutDeviation(dut, "PLQReflectorStatsUnsupported", (*mpb.Metadata_Deviations).GetPlqReflectorStatsUnsupported)
 }
 
 // PLQGeneratorCapabilitiesMaxMTU returns supported max_mtu for devices that does not support packet link qualification(PLQ) Generator max_mtu to be at least >= 8184.
 func PLQGeneratorCapabilitiesMaxMTU(dut *ondatra.DUTDevice) uint32 {
-	return lookupDUTDeviations(dut).GetPlqGeneratorCapabilitiesMaxMtu()
+	return dutDeviation(dut, "PLQGeneratorCapabilitiesMaxMTU", (*mpb.Metadata_Deviations).GetPlqGeneratorCapabilitiesMaxMtu)
 }
 
 // PLQGeneratorCapabilitiesMaxPPS returns supported max_pps for devices that does not support packet link qualification(PLQ) Generator max_pps to be at least >= 100000000.
 func PLQGeneratorCapabilitiesMaxPPS(dut *ondatra.DUTDevice) uint64 {
-	return lookupDUTDeviations(dut).GetPlqGeneratorCapabilitiesMaxPps()
+	return dutDeviation(dut, "PLQGeneratorCapabilitiesMaxPPS", (*mpb.Metadata_Deviations).GetPlqGeneratorCapabilitiesMaxPps)
 }
 
 // BgpExtendedCommunityIndexUnsupported return true if BGP extended community index is not supported.
 func BgpExtendedCommunityIndexUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpExtendedCommunityIndexUnsupported()
+	return dutDeviation(dut, "BgpExtendedCommunityIndexUnsupported", (*mpb.Metadata_Deviations).GetBgpExtendedCommunityIndexUnsupported)
 }
 
 // BgpCommunitySetRefsUnsupported return true if BGP community set refs is not supported.
 func BgpCommunitySetRefsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpCommunitySetRefsUnsupported()
+	return dutDeviation(dut, "BgpCommunitySetRefsUnsupported", (*mpb.Metadata_Deviations).GetBgpCommunitySetRefsUnsupported)
 }
 
 // TableConnectionsUnsupported returns true if Table Connections are unsupported.
 func TableConnectionsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTableConnectionsUnsupported()
+	return dutDeviation(dut, "TableConnectionsUnsupported", (*mpb.Metadata_Deviations).GetTableConnectionsUnsupported)
 }
 
 // UseVendorNativeTagSetConfig returns whether a device requires native model to configure tag-set
 func UseVendorNativeTagSetConfig(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetUseVendorNativeTagSetConfig()
+	return dutDeviation(dut, "UseVendorNativeTagSetConfig", (*mpb.Metadata_Deviations).GetUseVendorNativeTagSetConfig)
 }
 
 // SkipBgpSendCommunityType return true if device needs to skip setting BGP send-community-type
 func SkipBgpSendCommunityType(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipBgpSendCommunityType()
+	return dutDeviation(dut, "SkipBgpSendCommunityType", (*mpb.Metadata_Deviations).GetSkipBgpSendCommunityType)
 }
 
 // BgpActionsSetCommunityMethodUnsupported return true if BGP actions set-community method is unsupported
 func BgpActionsSetCommunityMethodUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpActionsSetCommunityMethodUnsupported()
+	return dutDeviation(dut, "BgpActionsSetCommunityMethodUnsupported", (*mpb.Metadata_Deviations).GetBgpActionsSetCommunityMethodUnsupported)
 
 }
 
 // SetNoPeerGroup Ensure that no BGP configurations exists under PeerGroups.
 func SetNoPeerGroup(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSetNoPeerGroup()
+	return dutDeviation(dut, "SetNoPeerGroup", (*mpb.Metadata_Deviations).GetSetNoPeerGroup)
 }
 
 // BgpCommunityMemberIsAString returns true if device community member is not a list
 func BgpCommunityMemberIsAString(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpCommunityMemberIsAString()
+	return dutDeviation(dut, "BgpCommunityMemberIsAString", (*mpb.Metadata_Deviations).GetBgpCommunityMemberIsAString)
 }
 
 // IPv4StaticRouteWithIPv6NextHopUnsupported unsupported ipv4 with ipv6 nexthop
 func IPv4StaticRouteWithIPv6NextHopUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv4StaticRouteWithIpv6NhUnsupported()
+	return dutDeviation(dut, "IPv4StaticRouteWithIPv6NextHopUnsupported", (*mpb.Metadata_Deviations).GetIpv4StaticRouteWithIpv6NhUnsupported)
 }
 
 // IPv6StaticRouteWithIPv4NextHopUnsupported unsupported ipv6 with ipv4 nexthop
 func IPv6StaticRouteWithIPv4NextHopUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv6StaticRouteWithIpv4NhUnsupported()
+	return dutDeviation(dut, "IPv6StaticRouteWithIPv4NextHopUnsupported", (*mpb.Metadata_Deviations).GetIpv6StaticRouteWithIpv4NhUnsupported)
 }
 
 // StaticRouteWithDropNhUnsupported unsupported drop nexthop
 func StaticRouteWithDropNhUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticRouteWithDropNh()
+	return dutDeviation(dut, "StaticRouteWithDropNhUnsupported", (*mpb.Metadata_Deviations).GetStaticRouteWithDropNh)
 }
 
 // StaticRouteWithExplicitMetric set explicit metric
 func StaticRouteWithExplicitMetric(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticRouteWithExplicitMetric()
+	return dutDeviation(dut, "StaticRouteWithExplicitMetric", (*mpb.Metadata_Deviations).GetStaticRouteWithExplicitMetric)
 }
 
 // BgpDefaultPolicyUnsupported return true if BGP default-import/export-policy is not supported.
 func BgpDefaultPolicyUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpDefaultPolicyUnsupported()
+	return dutDeviation(dut, "BgpDefaultPolicyUnsupported", (*mpb.Metadata_Deviations).GetBgpDefaultPolicyUnsupported)
 }
 
 // ExplicitEnableBGPOnDefaultVRF return true if BGP needs to be explicitly enabled on default VRF
 func ExplicitEnableBGPOnDefaultVRF(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetExplicitEnableBgpOnDefaultVrf()
+	return dutDeviation(dut, "ExplicitEnableBGPOnDefaultVRF", (*mpb.Metadata_Deviations).GetExplicitEnableBgpOnDefaultVrf)
 }
 
 // RoutingPolicyTagSetEmbedded returns true if the implementation does not support tag-set(s) as a
 // separate entity, but embeds it in the policy statement
 func RoutingPolicyTagSetEmbedded(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetRoutingPolicyTagSetEmbedded()
+	return dutDeviation(dut, "RoutingPolicyTagSetEmbedded", (*mpb.Metadata_Deviations).GetRoutingPolicyTagSetEmbedded)
 }
 
 // SkipAfiSafiPathForBgpMultipleAs return true if device do not support afi/safi path to enable allow multiple-as for eBGP
 func SkipAfiSafiPathForBgpMultipleAs(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipAfiSafiPathForBgpMultipleAs()
+	return dutDeviation(dut, "SkipAfiSafiPathForBgpMultipleAs", (*mpb.Metadata_Deviations).GetSkipAfiSafiPathForBgpMultipleAs)
 }
 
 // CommunityMemberRegexUnsupported return true if device do not support community member regex
 func CommunityMemberRegexUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetCommunityMemberRegexUnsupported()
+	return dutDeviation(dut, "CommunityMemberRegexUnsupported", (*mpb.Metadata_Deviations).GetCommunityMemberRegexUnsupported)
 }
 
 // SamePolicyAttachedToAllAfis returns true if same import policy has to be applied for all AFIs
 func SamePolicyAttachedToAllAfis(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSamePolicyAttachedToAllAfis()
+	return dutDeviation(dut, "SamePolicyAttachedToAllAfis", (*mpb.Metadata_Deviations).GetSamePolicyAttachedToAllAfis)
 }
 
 // SkipSettingStatementForPolicy return true if device do not support afi/safi path to enable allow multiple-as for eBGP
 func SkipSettingStatementForPolicy(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipSettingStatementForPolicy()
+	return dutDeviation(dut, "SkipSettingStatementForPolicy", (*mpb.Metadata_Deviations).GetSkipSettingStatementForPolicy)
 }
 
 // SkipCheckingAttributeIndex return true if device do not return bgp attribute for the bgp session specifying the index
 func SkipCheckingAttributeIndex(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipCheckingAttributeIndex()
+	return dutDeviation(dut, "SkipCheckingAttributeIndex", (*mpb.Metadata_Deviations).GetSkipCheckingAttributeIndex)
 }
 
 // FlattenPolicyWithMultipleStatements return true if devices does not support policy-chaining
 func FlattenPolicyWithMultipleStatements(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetFlattenPolicyWithMultipleStatements()
+	return dutDeviation(dut, "FlattenPolicyWithMultipleStatements", (*mpb.Metadata_Deviations).GetFlattenPolicyWithMultipleStatements)
 }
 
 // SlaacPrefixLength128 for Slaac generated IPv6 link local address
 func SlaacPrefixLength128(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSlaacPrefixLength128()
+	return dutDeviation(dut, "SlaacPrefixLength128", (*mpb.Metadata_Deviations).GetSlaacPrefixLength128)
 }
 
 // DefaultRoutePolicyUnsupported returns true if default route policy is not supported
 func DefaultRoutePolicyUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDefaultRoutePolicyUnsupported()
+	return dutDeviation(dut, "DefaultRoutePolicyUnsupported", (*mpb.Metadata_Deviations).GetDefaultRoutePolicyUnsupported)
 }
 
 // CommunityMatchWithRedistributionUnsupported is set to true for devices that do not support matching community at the redistribution attach point.
 func CommunityMatchWithRedistributionUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetCommunityMatchWithRedistributionUnsupported()
+	return dutDeviation(dut, "CommunityMatchWithRedistributionUnsupported", (*mpb.Metadata_Deviations).GetCommunityMatchWithRedistributionUnsupported)
 }
 
 // BgpMaxMultipathPathsUnsupported returns true if the device does not support
 // bgp max multipaths.
 func BgpMaxMultipathPathsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpMaxMultipathPathsUnsupported()
+	return dutDeviation(dut, "BgpMaxMultipathPathsUnsupported", (*mpb.Metadata_Deviations).GetBgpMaxMultipathPathsUnsupported)
 }
 
 // MultipathUnsupportedNeighborOrAfisafi returns true if the device does not
 // support multipath under neighbor or afisafi.
 func MultipathUnsupportedNeighborOrAfisafi(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMultipathUnsupportedNeighborOrAfisafi()
+	return dutDeviation(dut, "MultipathUnsupportedNeighborOrAfisafi", (*mpb.Metadata_Deviations).GetMultipathUnsupportedNeighborOrAfisafi)
 }
 
 // ModelNameUnsupported returns true if /components/components/state/model-name
 // is not supported for any component type.
 func ModelNameUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetModelNameUnsupported()
+	return dutDeviation(dut, "ModelNameUnsupported", (*mpb.Metadata_Deviations).GetModelNameUnsupported)
 }
 
 // InstallPositionAndInstallComponentUnsupported returns true if install
 // position and install component are not supported.
 func InstallPositionAndInstallComponentUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInstallPositionAndInstallComponentUnsupported()
+	return dutDeviation(dut, "InstallPositionAndInstallComponentUnsupported", (*mpb.Metadata_Deviations).GetInstallPositionAndInstallComponentUnsupported)
 }
 
 // EncapTunnelShutBackupNhgZeroTraffic returns true when encap tunnel is shut then zero traffic flows to back-up NHG
 func EncapTunnelShutBackupNhgZeroTraffic(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEncapTunnelShutBackupNhgZeroTraffic()
+	return dutDeviation(dut, "EncapTunnelShutBackupNhgZeroTraffic", (*mpb.Metadata_Deviations).GetEncapTunnelShutBackupNhgZeroTraffic)
 }
 
 // MaxEcmpPaths supported for isis max ecmp path
 func MaxEcmpPaths(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMaxEcmpPaths()
+	return dutDeviation(dut, "MaxEcmpPaths", (*mpb.Metadata_Deviations).GetMaxEcmpPaths)
 }
 
 // WecmpAutoUnsupported returns true if wecmp auto is not supported
 func WecmpAutoUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetWecmpAutoUnsupported()
+	return dutDeviation(dut, "WecmpAutoUnsupported", (*mpb.Metadata_Deviations).GetWecmpAutoUnsupported)
 }
 
 // RoutingPolicyChainingUnsupported returns true if policy chaining is unsupported
 func RoutingPolicyChainingUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetRoutingPolicyChainingUnsupported()
+	return dutDeviation(dut, "RoutingPolicyChainingUnsupported", (*mpb.Metadata_Deviations).GetRoutingPolicyChainingUnsupported)
 }
 
 // ISISLoopbackRequired returns true if isis loopback is required.
 func ISISLoopbackRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisLoopbackRequired()
+	return dutDeviation(dut, "ISISLoopbackRequired", (*mpb.Metadata_Deviations).GetIsisLoopbackRequired)
 }
 
 // WeightedEcmpFixedPacketVerification returns true if fixed packet is used in traffic flow
 func WeightedEcmpFixedPacketVerification(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetWeightedEcmpFixedPacketVerification()
+	return dutDeviation(dut, "WeightedEcmpFixedPacketVerification", (*mpb.Metadata_Deviations).GetWeightedEcmpFixedPacketVerification)
 }
 
 // OverrideDefaultNhScale returns true if default NextHop scale needs to be modified
 // else returns false
 func OverrideDefaultNhScale(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOverrideDefaultNhScale()
+	return dutDeviation(dut, "OverrideDefaultNhScale", (*mpb.Metadata_Deviations).GetOverrideDefaultNhScale)
 }
 
 // BgpExtendedCommunitySetUnsupported returns true if set bgp extended community is unsupported
 func BgpExtendedCommunitySetUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpExtendedCommunitySetUnsupported()
+	return dutDeviation(dut, "BgpExtendedCommunitySetUnsupported", (*mpb.Metadata_Deviations).GetBgpExtendedCommunitySetUnsupported)
 }
 
 // BgpSetExtCommunitySetRefsUnsupported returns true if bgp set ext community refs is unsupported
 func BgpSetExtCommunitySetRefsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpSetExtCommunitySetRefsUnsupported()
+	return dutDeviation(dut, "BgpSetExtCommunitySetRefsUnsupported", (*mpb.Metadata_Deviations).GetBgpSetExtCommunitySetRefsUnsupported)
 }
 
 // BgpDeleteLinkBandwidthUnsupported returns true if bgp delete link bandwidth is unsupported
 func BgpDeleteLinkBandwidthUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpDeleteLinkBandwidthUnsupported()
+	return dutDeviation(dut, "BgpDeleteLinkBandwidthUnsupported", (*mpb.Metadata_Deviations).GetBgpDeleteLinkBandwidthUnsupported)
 }
 
 // QOSInQueueDropCounterUnsupported returns true if /qos/interfaces/interface/input/queues/queue/state/dropped-pkts
 // is not supported for any component type.
 func QOSInQueueDropCounterUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosInqueueDropCounterUnsupported()
+	return dutDeviation(dut, "QOSInQueueDropCounterUnsupported", (*mpb.Metadata_Deviations).GetQosInqueueDropCounterUnsupported)
 }
 
 // BgpExplicitExtendedCommunityEnable returns true if explicit extended community enable is needed
 func BgpExplicitExtendedCommunityEnable(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpExplicitExtendedCommunityEnable()
+	return dutDeviation(dut, "BgpExplicitExtendedCommunityEnable", (*mpb.Metadata_Deviations).GetBgpExplicitExtendedCommunityEnable)
 }
 
 // MatchTagSetConditionUnsupported returns true if match tag set condition is not supported
 func MatchTagSetConditionUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMatchTagSetConditionUnsupported()
+	return dutDeviation(dut, "MatchTagSetConditionUnsupported", (*mpb.Metadata_Deviations).GetMatchTagSetConditionUnsupported)
 }
 
 // PeerGroupDefEbgpVrfUnsupported returns true if peer group definition under ebgp vrf is unsupported
 func PeerGroupDefEbgpVrfUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPeerGroupDefEbgpVrfUnsupported()
+	return dutDeviation(dut, "PeerGroupDefEbgpVrfUnsupported", (*mpb.Metadata_Deviations).GetPeerGroupDefEbgpVrfUnsupported)
 }
 
 // RedisConnectedUnderEbgpVrfUnsupported returns true if redistribution of routes under ebgp vrf is unsupported
 func RedisConnectedUnderEbgpVrfUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetRedisConnectedUnderEbgpVrfUnsupported()
+	return dutDeviation(dut, "RedisConnectedUnderEbgpVrfUnsupported", (*mpb.Metadata_Deviations).GetRedisConnectedUnderEbgpVrfUnsupported)
 }
 
 // BgpAfiSafiInDefaultNiBeforeOtherNi returns true if certain AFI SAFIs are configured in default network instance before other network instances
 func BgpAfiSafiInDefaultNiBeforeOtherNi(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpAfiSafiInDefaultNiBeforeOtherNi()
+	return dutDeviation(dut, "BgpAfiSafiInDefaultNiBeforeOtherNi", (*mpb.Metadata_Deviations).GetBgpAfiSafiInDefaultNiBeforeOtherNi)
 }
 
 // DefaultImportExportPolicyUnsupported returns true when device
 // does not support default import export policy.
 func DefaultImportExportPolicyUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDefaultImportExportPolicyUnsupported()
+	return dutDeviation(dut, "DefaultImportExportPolicyUnsupported", (*mpb.Metadata_Deviations).GetDefaultImportExportPolicyUnsupported)
 }
 
 // CommunityInvertAnyUnsupported returns true when device
 // does not support community invert any.
 func CommunityInvertAnyUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetCommunityInvertAnyUnsupported()
+	return dutDeviation(dut, "CommunityInvertAnyUnsupported", (*mpb.Metadata_Deviations).GetCommunityInvertAnyUnsupported)
 }
 
 // Ipv6RouterAdvertisementIntervalUnsupported returns true for devices which don't support Ipv6 RouterAdvertisement interval configuration
 func Ipv6RouterAdvertisementIntervalUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIpv6RouterAdvertisementIntervalUnsupported()
+	return dutDeviation(dut, "Ipv6RouterAdvertisementIntervalUnsupported", (*mpb.Metadata_Deviations).GetIpv6RouterAdvertisementIntervalUnsupported)
 }
 
 // DecapNHWithNextHopNIUnsupported returns true if Decap NH with NextHopNetworkInstance is unsupported
 func DecapNHWithNextHopNIUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDecapNhWithNexthopNiUnsupported()
+	return dutDeviation(dut, "DecapNHWithNextHopNIUnsupported", (*mpb.Metadata_Deviations).GetDecapNhWithNexthopNiUnsupported)
 }
 
 // SflowSourceAddressUpdateUnsupported returns true if sflow source address update is unsupported
 func SflowSourceAddressUpdateUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSflowSourceAddressUpdateUnsupported()
+	return dutDeviation(dut, "SflowSourceAddressUpdateUnsupported", (*mpb.Metadata_Deviations).GetSflowSourceAddressUpdateUnsupported)
 }
 
 // LinkLocalMaskLen returns true if linklocal mask length is not 64
 func LinkLocalMaskLen(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLinkLocalMaskLen()
+	return dutDeviation(dut, "LinkLocalMaskLen", (*mpb.Metadata_Deviations).GetLinkLocalMaskLen)
 }
 
 // UseParentComponentForTemperatureTelemetry returns true if parent component supports temperature telemetry
 func UseParentComponentForTemperatureTelemetry(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetUseParentComponentForTemperatureTelemetry()
+	return dutDeviation(dut, "UseParentComponentForTemperatureTelemetry", (*mpb.Metadata_Deviations).GetUseParentComponentForTemperatureTelemetry)
 }
 
 // ComponentMfgDateUnsupported returns true if component's mfg-date leaf is unsupported
 func ComponentMfgDateUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetComponentMfgDateUnsupported()
+	return dutDeviation(dut, "ComponentMfgDateUnsupported", (*mpb.Metadata_Deviations).GetComponentMfgDateUnsupported)
 }
 
 // InterfaceCountersUpdateDelayed returns true if telemetry for interface counters
 // does not return the latest counter values.
 func InterfaceCountersUpdateDelayed(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceCountersUpdateDelayed()
+	return dutDeviation(dut, "InterfaceCountersUpdateDelayed", (*mpb.Metadata_Deviations).GetInterfaceCountersUpdateDelayed)
 }
 
 // OTNChannelTribUnsupported returns true if TRIB parameter is unsupported under OTN channel configuration
 func OTNChannelTribUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOtnChannelTribUnsupported()
+	return dutDeviation(dut, "OTNChannelTribUnsupported", (*mpb.Metadata_Deviations).GetOtnChannelTribUnsupported)
 }
 
 // EthChannelIngressParametersUnsupported returns true if ingress parameters are unsupported under ETH channel configuration
 func EthChannelIngressParametersUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEthChannelIngressParametersUnsupported()
+	return dutDeviation(dut, "EthChannelIngressParametersUnsupported", (*mpb.Metadata_Deviations).GetEthChannelIngressParametersUnsupported)
 }
 
 // EthChannelAssignmentCiscoNumbering returns true if eth channel assignment index starts from 1 instead of 0
 func EthChannelAssignmentCiscoNumbering(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEthChannelAssignmentCiscoNumbering()
+	return dutDeviation(dut, "EthChannelAssignmentCiscoNumbering", (*mpb.Metadata_Deviations).GetEthChannelAssignmentCiscoNumbering)
 }
 
 // ChassisGetRPCUnsupported returns true if a Healthz Get RPC against the Chassis component is unsupported
 func ChassisGetRPCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetChassisGetRpcUnsupported()
+	return dutDeviation(dut, "ChassisGetRPCUnsupported", (*mpb.Metadata_Deviations).GetChassisGetRpcUnsupported)
 }
 
 // PowerDisableEnableLeafRefValidation returns true if definition of leaf-ref is not supported.
 func PowerDisableEnableLeafRefValidation(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPowerDisableEnableLeafRefValidation()
+	return dutDeviation(dut, "PowerDisableEnableLeafRefValidation", (*mpb.Metadata_Deviations).GetPowerDisableEnableLeafRefValidation)
 }
 
 // SSHServerCountersUnsupported is to skip checking ssh server counters.
 func SSHServerCountersUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSshServerCountersUnsupported()
+	return dutDeviation(dut, "SSHServerCountersUnsupported", (*mpb.Metadata_Deviations).GetSshServerCountersUnsupported)
 }
 
 // OperationalModeUnsupported returns true if operational-mode leaf is unsupported
 func OperationalModeUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOperationalModeUnsupported()
+	return dutDeviation(dut, "OperationalModeUnsupported", (*mpb.Metadata_Deviations).GetOperationalModeUnsupported)
 }
 
 // BgpSessionStateIdleInPassiveMode returns true if BGP session state idle is not supported instead of active in passive mode.
 func BgpSessionStateIdleInPassiveMode(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpSessionStateIdleInPassiveMode()
+	return dutDeviation(dut, "BgpSessionStateIdleInPassiveMode", (*mpb.Metadata_Deviations).GetBgpSessionStateIdleInPassiveMode)
 }
 
 // EnableMultipathUnderAfiSafi returns true for devices that do not support multipath under /global path and instead support under global/afi/safi path.
 func EnableMultipathUnderAfiSafi(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEnableMultipathUnderAfiSafi()
+	return dutDeviation(dut, "EnableMultipathUnderAfiSafi", (*mpb.Metadata_Deviations).GetEnableMultipathUnderAfiSafi)
 }
 
 // OTNChannelAssignmentCiscoNumbering returns true if OTN channel assignment index starts from 1 instead of 0
 func OTNChannelAssignmentCiscoNumbering(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOtnChannelAssignmentCiscoNumbering()
+	return dutDeviation(dut, "OTNChannelAssignmentCiscoNumbering", (*mpb.Metadata_Deviations).GetOtnChannelAssignmentCiscoNumbering)
 }
 
 // CiscoPreFECBERInactiveValue returns true if a non-zero pre-fec-ber value is to be used for Cisco
 func CiscoPreFECBERInactiveValue(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetCiscoPreFecBerInactiveValue()
+	return dutDeviation(dut, "CiscoPreFECBERInactiveValue", (*mpb.Metadata_Deviations).GetCiscoPreFecBerInactiveValue)
 }
 
 // BgpAfiSafiWildcardNotSupported return true if bgp afi/safi wildcard query is not supported.
@@ -1186,585 +1175,587 @@ func CiscoPreFECBERInactiveValue(dut *ondatra.DUTDevice) bool {
 // Use of this deviation is permitted if a query using an explicit key is supported (such as
 // `oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST`).
 func BgpAfiSafiWildcardNotSupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpAfiSafiWildcardNotSupported()
+	return dutDeviation(dut, "BgpAfiSafiWildcardNotSupported", (*mpb.Metadata_Deviations).GetBgpAfiSafiWildcardNotSupported)
 }
 
 // NoZeroSuppression returns true if device wants to remove zero suppression
 func NoZeroSuppression(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNoZeroSuppression()
+	return dutDeviation(dut, "NoZeroSuppression", (*mpb.Metadata_Deviations).GetNoZeroSuppression)
 }
 
 // IsisInterfaceLevelPassiveUnsupported returns true for devices that do not support passive leaf
 func IsisInterfaceLevelPassiveUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisInterfaceLevelPassiveUnsupported()
+	return dutDeviation(dut, "IsisInterfaceLevelPassiveUnsupported", (*mpb.Metadata_Deviations).GetIsisInterfaceLevelPassiveUnsupported)
 }
 
 // IsisDisSysidUnsupported returns true for devices that do not support dis-system-id leaf
 func IsisDisSysidUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisDisSysidUnsupported()
+	return dutDeviation(dut, "IsisDisSysidUnsupported", (*mpb.Metadata_Deviations).GetIsisDisSysidUnsupported)
 }
 
 // IsisDatabaseOverloadsUnsupported returns true for devices that do not support database-overloads leaf
 func IsisDatabaseOverloadsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisDatabaseOverloadsUnsupported()
+	return dutDeviation(dut, "IsisDatabaseOverloadsUnsupported", (*mpb.Metadata_Deviations).GetIsisDatabaseOverloadsUnsupported)
 }
 
 // BgpSetMedV7Unsupported returns true if devices which are not
 // supporting bgp set med union type in OC.
 func BgpSetMedV7Unsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpSetMedV7Unsupported()
+	return dutDeviation(dut, "BgpSetMedV7Unsupported", (*mpb.Metadata_Deviations).GetBgpSetMedV7Unsupported)
 }
 
 // EnableTableConnections returns true if admin state of tableconnections needs to be enabled in SRL native model
 func EnableTableConnections(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEnableTableConnections()
+	return dutDeviation(dut, "EnableTableConnections", (*mpb.Metadata_Deviations).GetEnableTableConnections)
 }
 
 // TcDefaultImportPolicyUnsupported returns true if default import policy for table connection is unsupported
 func TcDefaultImportPolicyUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTcDefaultImportPolicyUnsupported()
+	return dutDeviation(dut, "TcDefaultImportPolicyUnsupported", (*mpb.Metadata_Deviations).GetTcDefaultImportPolicyUnsupported)
 }
 
 // TcMetricPropagationUnsupported returns true if metric propagation for table connection is unsupported
 func TcMetricPropagationUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTcMetricPropagationUnsupported()
+	return dutDeviation(dut, "TcMetricPropagationUnsupported", (*mpb.Metadata_Deviations).GetTcMetricPropagationUnsupported)
 }
 
 // TcAttributePropagationUnsupported returns true if attribute propagation for table connection is unsupported
 func TcAttributePropagationUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTcAttributePropagationUnsupported()
+	return dutDeviation(dut, "TcAttributePropagationUnsupported", (*mpb.Metadata_Deviations).GetTcAttributePropagationUnsupported)
 }
 
 // TcSubscriptionUnsupported returns true if subscription for table connection is unsupported
 func TcSubscriptionUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTcSubscriptionUnsupported()
+	return dutDeviation(dut, "TcSubscriptionUnsupported", (*mpb.Metadata_Deviations).GetTcSubscriptionUnsupported)
 }
 
 // DefaultBgpInstanceName returns bgp instance name as set in deviation to override default value "DEFAULT"
 func DefaultBgpInstanceName(dut *ondatra.DUTDevice) string {
-	if dbin := lookupDUTDeviations(dut).GetDefaultBgpInstanceName(); dbin != "" {
-		return dbin
-	}
-	return "DEFAULT"
+	return dutDeviation(dut, "DefaultBgpInstanceName", func(devs *mpb.Metadata_Deviations) string {
+		if dbin := devs.GetDefaultBgpInstanceName(); dbin != "" {
+			return dbin
+		}
+		return "DEFAULT"
+	})
 }
 
 // ChannelRateClassParametersUnsupported returns true if channel rate class parameters are unsupported
 func ChannelRateClassParametersUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetChannelAssignmentRateClassParametersUnsupported()
+	return dutDeviation(dut, "ChannelRateClassParametersUnsupported", (*mpb.Metadata_Deviations).GetChannelAssignmentRateClassParametersUnsupported)
 }
 
 // QosSchedulerIngressPolicer returns true if qos ingress policing is unsupported
 func QosSchedulerIngressPolicer(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosSchedulerIngressPolicerUnsupported()
+	return dutDeviation(dut, "QosSchedulerIngressPolicer", (*mpb.Metadata_Deviations).GetQosSchedulerIngressPolicerUnsupported)
 }
 
 // GribiEncapHeaderUnsupported returns true if gribi encap header is unsupported
 func GribiEncapHeaderUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGribiEncapHeaderUnsupported()
+	return dutDeviation(dut, "GribiEncapHeaderUnsupported", (*mpb.Metadata_Deviations).GetGribiEncapHeaderUnsupported)
 }
 
 // P4RTCapabilitiesUnsupported returns true for devices that don't support P4RT Capabilities rpc.
 func P4RTCapabilitiesUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetP4RtCapabilitiesUnsupported()
+	return dutDeviation(dut, "P4RTCapabilitiesUnsupported", (*mpb.Metadata_Deviations).GetP4RtCapabilitiesUnsupported)
 }
 
 // GNMIGetOnRootUnsupported returns true if the device does not support gNMI get on root.
 func GNMIGetOnRootUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGnmiGetOnRootUnsupported()
+	return dutDeviation(dut, "GNMIGetOnRootUnsupported", (*mpb.Metadata_Deviations).GetGnmiGetOnRootUnsupported)
 }
 
 // PacketProcessingAggregateDropsUnsupported returns true if the device does not support packet processing aggregate drops.
 func PacketProcessingAggregateDropsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPacketProcessingAggregateDropsUnsupported()
+	return dutDeviation(dut, "PacketProcessingAggregateDropsUnsupported", (*mpb.Metadata_Deviations).GetPacketProcessingAggregateDropsUnsupported)
 }
 
 // FragmentTotalDropsUnsupported returns true if the device does not support fragment total drops.
 func FragmentTotalDropsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetFragmentTotalDropsUnsupported()
+	return dutDeviation(dut, "FragmentTotalDropsUnsupported", (*mpb.Metadata_Deviations).GetFragmentTotalDropsUnsupported)
 }
 
 // BgpPrefixsetReqRoutepolRef returns true if devices needs route policy reference to stream prefix set info.
 func BgpPrefixsetReqRoutepolRef(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpPrefixsetReqRoutepolRef()
+	return dutDeviation(dut, "BgpPrefixsetReqRoutepolRef", (*mpb.Metadata_Deviations).GetBgpPrefixsetReqRoutepolRef)
 }
 
 // OperStatusForIcUnsupported return true if oper-status leaf is unsupported for Integration Circuit
 func OperStatusForIcUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOperStatusForIcUnsupported()
+	return dutDeviation(dut, "OperStatusForIcUnsupported", (*mpb.Metadata_Deviations).GetOperStatusForIcUnsupported)
 }
 
 // BgpAspathsetUnsupported returns true if as-path-set for bgp-defined-sets is unsupported
 func BgpAspathsetUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpAspathsetUnsupported()
+	return dutDeviation(dut, "BgpAspathsetUnsupported", (*mpb.Metadata_Deviations).GetBgpAspathsetUnsupported)
 }
 
 // ExplicitDcoConfig returns true if a user-configured value is required in module-functional-type for the transceiver
 func ExplicitDcoConfig(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetExplicitDcoConfig()
+	return dutDeviation(dut, "ExplicitDcoConfig", (*mpb.Metadata_Deviations).GetExplicitDcoConfig)
 }
 
 // VerifyExpectedBreakoutSupportedConfig is to skip checking for breakout config mode.
 func VerifyExpectedBreakoutSupportedConfig(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetVerifyExpectedBreakoutSupportedConfig()
+	return dutDeviation(dut, "VerifyExpectedBreakoutSupportedConfig", (*mpb.Metadata_Deviations).GetVerifyExpectedBreakoutSupportedConfig)
 }
 
 // SrIgpConfigUnsupported return true if SR IGP config is not supported
 func SrIgpConfigUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSrIgpConfigUnsupported()
+	return dutDeviation(dut, "SrIgpConfigUnsupported", (*mpb.Metadata_Deviations).GetSrIgpConfigUnsupported)
 }
 
 // SetISISAuthWithInterfaceAuthenticationContainer returns true if Isis Authentication is blocked for one level specific config for P2P links, and the corresponding hello-authentication leafs can be set with ISIS Interface/Authentication container.
 func SetISISAuthWithInterfaceAuthenticationContainer(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSetIsisAuthWithInterfaceAuthenticationContainer()
+	return dutDeviation(dut, "SetISISAuthWithInterfaceAuthenticationContainer", (*mpb.Metadata_Deviations).GetSetIsisAuthWithInterfaceAuthenticationContainer)
 }
 
 // GreGueTunnelInterfaceOcUnsupported returns true if GRE/GUE tunnel interface oc is unsupported
 func GreGueTunnelInterfaceOcUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGreGueTunnelInterfaceOcUnsupported()
+	return dutDeviation(dut, "GreGueTunnelInterfaceOcUnsupported", (*mpb.Metadata_Deviations).GetGreGueTunnelInterfaceOcUnsupported)
 }
 
 // LoadIntervalNotSupported returns true if load interval is not supported on vendors
 func LoadIntervalNotSupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLoadIntervalNotSupported()
+	return dutDeviation(dut, "LoadIntervalNotSupported", (*mpb.Metadata_Deviations).GetLoadIntervalNotSupported)
 }
 
 // SkipOpticalChannelOutputPowerInterval returns true if devices do not support opticalchannel output-power interval leaf
 func SkipOpticalChannelOutputPowerInterval(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipOpticalChannelOutputPowerInterval()
+	return dutDeviation(dut, "SkipOpticalChannelOutputPowerInterval", (*mpb.Metadata_Deviations).GetSkipOpticalChannelOutputPowerInterval)
 }
 
 // SkipTransceiverDescription returns true if devices do not support transceiver description leaf
 func SkipTransceiverDescription(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipTransceiverDescription()
+	return dutDeviation(dut, "SkipTransceiverDescription", (*mpb.Metadata_Deviations).GetSkipTransceiverDescription)
 }
 
 // ContainerzOCUnsupported returns true if devices cannot configure containerz via OpenConfig
 func ContainerzOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetContainerzOcUnsupported()
+	return dutDeviation(dut, "ContainerzOCUnsupported", (*mpb.Metadata_Deviations).GetContainerzOcUnsupported)
 }
 
 // NextHopGroupOCUnsupported returns true if devices do not support next-hop-group config
 func NextHopGroupOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNextHopGroupConfigUnsupported()
+	return dutDeviation(dut, "NextHopGroupOCUnsupported", (*mpb.Metadata_Deviations).GetNextHopGroupConfigUnsupported)
 }
 
 // QosShaperOCUnsupported returns true if qos shaper config is unsupported
 func QosShaperOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosShaperConfigUnsupported()
+	return dutDeviation(dut, "QosShaperOCUnsupported", (*mpb.Metadata_Deviations).GetQosShaperConfigUnsupported)
 }
 
 // EthernetOverMPLSogreOCUnsupported returns true if ethernet over mplsogre is unsupported
 func EthernetOverMPLSogreOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetEthernetOverMplsogreUnsupported()
+	return dutDeviation(dut, "EthernetOverMPLSogreOCUnsupported", (*mpb.Metadata_Deviations).GetEthernetOverMplsogreUnsupported)
 }
 
 // SflowOCUnsupported returns true if sflow is unsupported
 func SflowOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSflowUnsupported()
+	return dutDeviation(dut, "SflowOCUnsupported", (*mpb.Metadata_Deviations).GetSflowUnsupported)
 }
 
 // MplsOCUnsupported returns true if mpls is unsupported
 func MplsOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMplsUnsupported()
+	return dutDeviation(dut, "MplsOCUnsupported", (*mpb.Metadata_Deviations).GetMplsUnsupported)
 }
 
 // MacsecOCUnsupported returns true if macsec is unsupported
 func MacsecOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMacsecUnsupported()
+	return dutDeviation(dut, "MacsecOCUnsupported", (*mpb.Metadata_Deviations).GetMacsecUnsupported)
 }
 
 // GueGreDecapOCUnsupported returns true if gue gre decap is unsupported
 func GueGreDecapOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGueGreDecapUnsupported()
+	return dutDeviation(dut, "GueGreDecapOCUnsupported", (*mpb.Metadata_Deviations).GetGueGreDecapUnsupported)
 }
 
 // MplsLabelClassificationOCUnsupported returns true if mpls label classification is unsupported
 func MplsLabelClassificationOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMplsLabelClassificationUnsupported()
+	return dutDeviation(dut, "MplsLabelClassificationOCUnsupported", (*mpb.Metadata_Deviations).GetMplsLabelClassificationUnsupported)
 }
 
 // LocalProxyOCUnsupported returns true if local proxy is unsupported
 func LocalProxyOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLocalProxyUnsupported()
+	return dutDeviation(dut, "LocalProxyOCUnsupported", (*mpb.Metadata_Deviations).GetLocalProxyUnsupported)
 }
 
 // StaticMplsOCUnsupported returns true if static mpls is unsupported
 func StaticMplsOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticMplsUnsupported()
+	return dutDeviation(dut, "StaticMplsOCUnsupported", (*mpb.Metadata_Deviations).GetStaticMplsUnsupported)
 }
 
 // QosClassificationOCUnsupported returns true if qos classification is unsupported
 func QosClassificationOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosClassificationUnsupported()
+	return dutDeviation(dut, "QosClassificationOCUnsupported", (*mpb.Metadata_Deviations).GetQosClassificationUnsupported)
 }
 
 // PolicyForwardingOCUnsupported returns true if policy forwarding is unsupported
 func PolicyForwardingOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPolicyForwardingUnsupported()
+	return dutDeviation(dut, "PolicyForwardingOCUnsupported", (*mpb.Metadata_Deviations).GetPolicyForwardingUnsupported)
 }
 
 // InterfacePolicyForwardingOCUnsupported returns true if interface policy forwarding is unsupported
 func InterfacePolicyForwardingOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfacePolicyForwardingUnsupported()
+	return dutDeviation(dut, "InterfacePolicyForwardingOCUnsupported", (*mpb.Metadata_Deviations).GetInterfacePolicyForwardingUnsupported)
 }
 
 // GueGreDecapUnsupported returns true if gue or gre decap is unsupported
 func GueGreDecapUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGueGreDecapUnsupported()
+	return dutDeviation(dut, "GueGreDecapUnsupported", (*mpb.Metadata_Deviations).GetGueGreDecapUnsupported)
 }
 
 // StaticMplsUnsupported returns true if static mpls is unsupported
 func StaticMplsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticMplsUnsupported()
+	return dutDeviation(dut, "StaticMplsUnsupported", (*mpb.Metadata_Deviations).GetStaticMplsUnsupported)
 }
 
 // QosShaperStateOCUnsupported returns true if qos shaper state is unsupported
 func QosShaperStateOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosShaperStateUnsupported()
+	return dutDeviation(dut, "QosShaperStateOCUnsupported", (*mpb.Metadata_Deviations).GetQosShaperStateUnsupported)
 }
 
 // CfmOCUnsupported returns true if CFM is unsupported
 func CfmOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetCfmUnsupported()
+	return dutDeviation(dut, "CfmOCUnsupported", (*mpb.Metadata_Deviations).GetCfmUnsupported)
 }
 
 // LabelRangeOCUnsupported returns true if label range is unsupported
 func LabelRangeOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLabelRangeUnsupported()
+	return dutDeviation(dut, "LabelRangeOCUnsupported", (*mpb.Metadata_Deviations).GetLabelRangeUnsupported)
 }
 
 // StaticArpOCUnsupported returns true if static arp is unsupported
 func StaticArpOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticArpUnsupported()
+	return dutDeviation(dut, "StaticArpOCUnsupported", (*mpb.Metadata_Deviations).GetStaticArpUnsupported)
 }
 
 // BgpDistanceOcPathUnsupported returns true if BGP Distance OC telemetry path is not supported.
 func BgpDistanceOcPathUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpDistanceOcPathUnsupported()
+	return dutDeviation(dut, "BgpDistanceOcPathUnsupported", (*mpb.Metadata_Deviations).GetBgpDistanceOcPathUnsupported)
 }
 
 // IsisMplsUnsupported returns true if there's no OC support for MPLS under ISIS
 func IsisMplsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisMplsUnsupported()
+	return dutDeviation(dut, "IsisMplsUnsupported", (*mpb.Metadata_Deviations).GetIsisMplsUnsupported)
 }
 
 // AutoNegotiateUnsupported returns true if there's no OC support for auto-negotiate
 func AutoNegotiateUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAutoNegotiateUnsupported()
+	return dutDeviation(dut, "AutoNegotiateUnsupported", (*mpb.Metadata_Deviations).GetAutoNegotiateUnsupported)
 }
 
 // DuplexModeUnsupported returns true if there's no OC support for duplex-mode
 func DuplexModeUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDuplexModeUnsupported()
+	return dutDeviation(dut, "DuplexModeUnsupported", (*mpb.Metadata_Deviations).GetDuplexModeUnsupported)
 }
 
 // PortSpeedUnsupported returns true if there's no OC support for port-speed
 func PortSpeedUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPortSpeedUnsupported()
+	return dutDeviation(dut, "PortSpeedUnsupported", (*mpb.Metadata_Deviations).GetPortSpeedUnsupported)
 }
 
 // PolicyForwardingToNextHopOcUnsupported returns true if policy forwarding to next hop is not supported on vendors
 func PolicyForwardingToNextHopOcUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPolicyForwardingToNextHopOcUnsupported()
+	return dutDeviation(dut, "PolicyForwardingToNextHopOcUnsupported", (*mpb.Metadata_Deviations).GetPolicyForwardingToNextHopOcUnsupported)
 }
 
 // BGPSetMedActionUnsupported returns true if there's no OC support for BGP set med action
 func BGPSetMedActionUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBgpSetMedActionUnsupported()
+	return dutDeviation(dut, "BGPSetMedActionUnsupported", (*mpb.Metadata_Deviations).GetBgpSetMedActionUnsupported)
 }
 
 // NumPhysyicalChannelsUnsupported returns true if there's no OC support for num-physical-channels
 func NumPhysyicalChannelsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNumPhysicalChannelsUnsupported()
+	return dutDeviation(dut, "NumPhysyicalChannelsUnsupported", (*mpb.Metadata_Deviations).GetNumPhysicalChannelsUnsupported)
 }
 
 // UseOldOCPathStaticLspNh returns true if the old OC path for static lsp next-hop is used
 func UseOldOCPathStaticLspNh(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetUseOldOcPathStaticLspNh()
+	return dutDeviation(dut, "UseOldOCPathStaticLspNh", (*mpb.Metadata_Deviations).GetUseOldOcPathStaticLspNh)
 }
 
 // ConfigLeafCreateRequired returns true if leaf creation is required
 func ConfigLeafCreateRequired(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetConfigLeafCreateRequired()
+	return dutDeviation(dut, "ConfigLeafCreateRequired", (*mpb.Metadata_Deviations).GetConfigLeafCreateRequired)
 }
 
 // FrBreakoutFix returns true if the fix is needed
 func FrBreakoutFix(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetFrBreakoutFix()
+	return dutDeviation(dut, "FrBreakoutFix", (*mpb.Metadata_Deviations).GetFrBreakoutFix)
 }
 
 // SkipInterfaceNameCheck returns if device requires skipping the interface name check.
 func SkipInterfaceNameCheck(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipInterfaceNameCheck()
+	return dutDeviation(dut, "SkipInterfaceNameCheck", (*mpb.Metadata_Deviations).GetSkipInterfaceNameCheck)
 }
 
 // UnsupportedQoSOutputServicePolicy returns true if devices do not support qos output service-policy
 func UnsupportedQoSOutputServicePolicy(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetUnsupportedQosOutputServicePolicy()
+	return dutDeviation(dut, "UnsupportedQoSOutputServicePolicy", (*mpb.Metadata_Deviations).GetUnsupportedQosOutputServicePolicy)
 }
 
 // InterfaceOutputQueueNonStandardName returns true if devices have non-standard output queue names
 func InterfaceOutputQueueNonStandardName(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfaceOutputQueueNonStandardName()
+	return dutDeviation(dut, "InterfaceOutputQueueNonStandardName", (*mpb.Metadata_Deviations).GetInterfaceOutputQueueNonStandardName)
 }
 
 // MplsExpIngressClassifierOcUnsupported returns true if devices do not support classifying ingress packets based on the MPLS exp field
 func MplsExpIngressClassifierOcUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMplsExpIngressClassifierOcUnsupported()
+	return dutDeviation(dut, "MplsExpIngressClassifierOcUnsupported", (*mpb.Metadata_Deviations).GetMplsExpIngressClassifierOcUnsupported)
 }
 
 // Devices that do not propagate IGP metric through redistribution
 func DefaultNoIgpMetricPropagation(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDefaultNoIgpMetricPropagation()
+	return dutDeviation(dut, "DefaultNoIgpMetricPropagation", (*mpb.Metadata_Deviations).GetDefaultNoIgpMetricPropagation)
 }
 
 // SkipBgpPeerGroupSendCommunityType return true if device needs to skip setting BGP send-community-type for peer group
 func SkipBgpPeerGroupSendCommunityType(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipBgpPeerGroupSendCommunityType()
+	return dutDeviation(dut, "SkipBgpPeerGroupSendCommunityType", (*mpb.Metadata_Deviations).GetSkipBgpPeerGroupSendCommunityType)
 }
 
 // ExplicitSwapSrcDstMacNeededForLoopbackMode returns true if device needs to explicitly set swap-src-dst-mac for loopback mode
 func ExplicitSwapSrcDstMacNeededForLoopbackMode(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetExplicitSwapSrcDstMacNeededForLoopbackMode()
+	return dutDeviation(dut, "ExplicitSwapSrcDstMacNeededForLoopbackMode", (*mpb.Metadata_Deviations).GetExplicitSwapSrcDstMacNeededForLoopbackMode)
 }
 
 // LinkLocalInsteadOfNh returns true if device requires link-local instead of NH.
 func LinkLocalInsteadOfNh(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLinkLocalInsteadOfNh()
+	return dutDeviation(dut, "LinkLocalInsteadOfNh", (*mpb.Metadata_Deviations).GetLinkLocalInsteadOfNh)
 }
 
 // LowScaleAft returns if device requires link-local instead of NH.
 func LowScaleAft(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLowScaleAft()
+	return dutDeviation(dut, "LowScaleAft", (*mpb.Metadata_Deviations).GetLowScaleAft)
 }
 
 // MissingSystemDescriptionConfigPath returns true if device does not support config lldp system-description leaf
 func MissingSystemDescriptionConfigPath(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMissingSystemDescriptionConfigPath()
+	return dutDeviation(dut, "MissingSystemDescriptionConfigPath", (*mpb.Metadata_Deviations).GetMissingSystemDescriptionConfigPath)
 }
 
 // FEC uncorrectable errors accumulate over time and are not cleared unless the component is reset on target
 func NonIntervalFecErrorCounter(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNonIntervalFecErrorCounter()
+	return dutDeviation(dut, "NonIntervalFecErrorCounter", (*mpb.Metadata_Deviations).GetNonIntervalFecErrorCounter)
 }
 
 // NtpSourceAddressUnsupported returns true if NTP source address is not supported
 func NtpSourceAddressUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNtpSourceAddressUnsupported()
+	return dutDeviation(dut, "NtpSourceAddressUnsupported", (*mpb.Metadata_Deviations).GetNtpSourceAddressUnsupported)
 }
 
 // StaticMplsLspUnsupported returns true if static mpls lsp parameters are unsupported
 func StaticMplsLspOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticMplsLspOcUnsupported()
+	return dutDeviation(dut, "StaticMplsLspOCUnsupported", (*mpb.Metadata_Deviations).GetStaticMplsLspOcUnsupported)
 }
 
 // GreDecapsulationUnsupported returns true if decapsulation is not supported
 func GreDecapsulationOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGreDecapsulationOcUnsupported()
+	return dutDeviation(dut, "GreDecapsulationOCUnsupported", (*mpb.Metadata_Deviations).GetGreDecapsulationOcUnsupported)
 }
 
 // SRLB and SRGB configuration does not effective with OC config
 func IsisSrgbSrlbUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisSrgbSrlbUnsupported()
+	return dutDeviation(dut, "IsisSrgbSrlbUnsupported", (*mpb.Metadata_Deviations).GetIsisSrgbSrlbUnsupported)
 }
 
 // Isis Prefix Segment config does not supported
 func IsisSrPrefixSegmentConfigUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisSrPrefixSegmentConfigUnsupported()
+	return dutDeviation(dut, "IsisSrPrefixSegmentConfigUnsupported", (*mpb.Metadata_Deviations).GetIsisSrPrefixSegmentConfigUnsupported)
 }
 
 // Isis Node Segment Configuration do not supported
 func IsisSrNodeSegmentConfigUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisSrNodeSegmentConfigUnsupported()
+	return dutDeviation(dut, "IsisSrNodeSegmentConfigUnsupported", (*mpb.Metadata_Deviations).GetIsisSrNodeSegmentConfigUnsupported)
 }
 
 // SflowIngressMinSamplingRate returns the minimum sampling rate supported for sflow ingress on the device.
 func SflowIngressMinSamplingRate(dut *ondatra.DUTDevice) uint32 {
-	return lookupDUTDeviations(dut).GetSflowIngressMinSamplingRate()
+	return dutDeviation(dut, "SflowIngressMinSamplingRate", (*mpb.Metadata_Deviations).GetSflowIngressMinSamplingRate)
 }
 
 // QosRemarkOCUnsupported returns true if Qos remark parameters are unsupported
 func QosRemarkOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosRemarkOcUnsupported()
+	return dutDeviation(dut, "QosRemarkOCUnsupported", (*mpb.Metadata_Deviations).GetQosRemarkOcUnsupported)
 }
 
 // PolicyForwardingGREEncapsulationOCUnsupported returns true if policy forwarding GRE encapsulation is not supported on vendors
 func PolicyForwardingGreEncapsulationOcUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPolicyForwardingGreEncapsulationOcUnsupported()
+	return dutDeviation(dut, "PolicyForwardingGreEncapsulationOcUnsupported", (*mpb.Metadata_Deviations).GetPolicyForwardingGreEncapsulationOcUnsupported)
 }
 
 // PolicyRuleCountersOCUnsupported returns true if policy forwarding Rule Counters is not supported on vendors
 func PolicyRuleCountersOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPolicyRuleCountersOcUnsupported()
+	return dutDeviation(dut, "PolicyRuleCountersOCUnsupported", (*mpb.Metadata_Deviations).GetPolicyRuleCountersOcUnsupported)
 }
 
 // OTNToETHAssignment returns true if the device must have the OTN to ETH assignment.
 func OTNToETHAssignment(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetOtnToEthAssignment()
+	return dutDeviation(dut, "OTNToETHAssignment", (*mpb.Metadata_Deviations).GetOtnToEthAssignment)
 }
 
 // NetworkInstanceImportExportPolicyOCUnsupported returns true if network instance import/export policy is not supported.
 func NetworkInstanceImportExportPolicyOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetNetworkInstanceImportExportPolicyOcUnsuppored()
+	return dutDeviation(dut, "NetworkInstanceImportExportPolicyOCUnsupported", (*mpb.Metadata_Deviations).GetNetworkInstanceImportExportPolicyOcUnsuppored)
 }
 
 // SkipOrigin returns true if the device does not support the 'origin' field in gNMI/gNOI RPC paths.
 func SkipOrigin(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSkipOrigin()
+	return dutDeviation(dut, "SkipOrigin", (*mpb.Metadata_Deviations).GetSkipOrigin)
 }
 
 // PredefinedMaxEcmpPaths returns true if max ecmp paths are predefined.
 func PredefinedMaxEcmpPaths(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPredefinedMaxEcmpPaths()
+	return dutDeviation(dut, "PredefinedMaxEcmpPaths", (*mpb.Metadata_Deviations).GetPredefinedMaxEcmpPaths)
 }
 
 // DecapGroupOCUnsupported returns true if decapsulation group is not supported
 func DecapsulateGueOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDecapsulateGueOcUnsupported()
+	return dutDeviation(dut, "DecapsulateGueOCUnsupported", (*mpb.Metadata_Deviations).GetDecapsulateGueOcUnsupported)
 }
 
 // LinePortUnsupported returns whether the DUT does not support line-port configuration on optical channel components.
 func LinePortUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLinePortUnsupported()
+	return dutDeviation(dut, "LinePortUnsupported", (*mpb.Metadata_Deviations).GetLinePortUnsupported)
 }
 
 // UseBgpSetCommunityOptionTypeReplace returns true if BGP community set REPLACE
 // option is required
 func UseBgpSetCommunityOptionTypeReplace(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetUseBgpSetCommunityOptionTypeReplace()
+	return dutDeviation(dut, "UseBgpSetCommunityOptionTypeReplace", (*mpb.Metadata_Deviations).GetUseBgpSetCommunityOptionTypeReplace)
 }
 
 // MaxEcmpPaths path on global level is unsupported
 func GlobalMaxEcmpPathsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGlobalMaxEcmpPathsUnsupported()
+	return dutDeviation(dut, "GlobalMaxEcmpPathsUnsupported", (*mpb.Metadata_Deviations).GetGlobalMaxEcmpPathsUnsupported)
 }
 
 // QosTwoRateThreeColorPolicerOCUnsupported returns true if the device does not support QoS two-rate-three-color policer.
 // Arista: https://partnerissuetracker.corp.google.com/issues/442749011
 func QosTwoRateThreeColorPolicerOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetQosTwoRateThreeColorPolicerOcUnsupported()
+	return dutDeviation(dut, "QosTwoRateThreeColorPolicerOCUnsupported", (*mpb.Metadata_Deviations).GetQosTwoRateThreeColorPolicerOcUnsupported)
 }
 
 // LoadBalancePolicyOCUnsupported returns true if load-balancing policy configuration is not supported through OpenConfig.
 func LoadBalancePolicyOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLoadBalancePolicyOcUnsupported()
+	return dutDeviation(dut, "LoadBalancePolicyOCUnsupported", (*mpb.Metadata_Deviations).GetLoadBalancePolicyOcUnsupported)
 }
 
 // Gribi Records Unsupported returns true if Gribi records creation is not supported through OpenConfig.
 func GribiRecordsUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGribiRecordsUnsupported()
+	return dutDeviation(dut, "GribiRecordsUnsupported", (*mpb.Metadata_Deviations).GetGribiRecordsUnsupported)
 }
 
 // CiscoxrLaserFt returns the functional translator to be used for translating
 // transceiver threshold leaves.
 func CiscoxrLaserFt(dut *ondatra.DUTDevice) string {
-	return lookupDUTDeviations(dut).GetCiscoxrLaserFt()
+	return dutDeviation(dut, "CiscoxrLaserFt", (*mpb.Metadata_Deviations).GetCiscoxrLaserFt)
 }
 
 // BreakoutModeUnsupportedForEightHundredGb returns true if the device does not support breakout mode for 800G ports.
 func BreakoutModeUnsupportedForEightHundredGb(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetBreakoutModeUnsupportedForEightHundredGb()
+	return dutDeviation(dut, "BreakoutModeUnsupportedForEightHundredGb", (*mpb.Metadata_Deviations).GetBreakoutModeUnsupportedForEightHundredGb)
 }
 
 // PortSpeedDuplexModeUnsupportedForInterfaceConfig returns true if the device does not support port speed and duplex mode for interface config.
 func PortSpeedDuplexModeUnsupportedForInterfaceConfig(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetPortSpeedDuplexModeUnsupportedForInterfaceConfig()
+	return dutDeviation(dut, "PortSpeedDuplexModeUnsupportedForInterfaceConfig", (*mpb.Metadata_Deviations).GetPortSpeedDuplexModeUnsupportedForInterfaceConfig)
 }
 
 // ExplicitBreakoutInterfaceConfig returns true if the device needs explicit breakout interface config.
 func ExplicitBreakoutInterfaceConfig(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetExplicitBreakoutInterfaceConfig()
+	return dutDeviation(dut, "ExplicitBreakoutInterfaceConfig", (*mpb.Metadata_Deviations).GetExplicitBreakoutInterfaceConfig)
 }
 
 // OC state path for the lower priority next hop not supported
 func TelemetryNotSupportedForLowPriorityNh(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTelemetryNotSupportedForLowPriorityNh()
+	return dutDeviation(dut, "TelemetryNotSupportedForLowPriorityNh", (*mpb.Metadata_Deviations).GetTelemetryNotSupportedForLowPriorityNh)
 }
 
 // MatchAsPathSetUnsupported returns true if match-as-path-set policy configuration is not supported
 func MatchAsPathSetUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetMatchAsPathSetUnsupported()
+	return dutDeviation(dut, "MatchAsPathSetUnsupported", (*mpb.Metadata_Deviations).GetMatchAsPathSetUnsupported)
 }
 
 // Same apply-policy under peer-group and peer-group/afi-safi
 func SameAfiSafiAndPeergroupPoliciesUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSameAfiSafiAndPeergroupPoliciesUnsupported()
+	return dutDeviation(dut, "SameAfiSafiAndPeergroupPoliciesUnsupported", (*mpb.Metadata_Deviations).GetSameAfiSafiAndPeergroupPoliciesUnsupported)
 }
 
 // SyslogOCUnsupported returns true if the device does not support syslog OC configuration for below OC paths.
 // '/system/logging/remote-servers/remote-server/config/network-instance'
 func SyslogOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSyslogOcUnsupported()
+	return dutDeviation(dut, "SyslogOCUnsupported", (*mpb.Metadata_Deviations).GetSyslogOcUnsupported)
 }
 
 // SIDPerInterfaceCounterUnsupported return true if device does not supprt mpls/signaling-protocols/segment-routing/interfaces/interface/sid-counters/sid-counter/
 func SIDPerInterfaceCounterUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSidPerInterfaceCounterUnsupported()
+	return dutDeviation(dut, "SIDPerInterfaceCounterUnsupported", (*mpb.Metadata_Deviations).GetSidPerInterfaceCounterUnsupported)
 }
 
 // TransceiverConfigEnableUnsupported returns true if devices cannot set transceiver config enable
 func TransceiverConfigEnableUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetTransceiverConfigEnableUnsupported()
+	return dutDeviation(dut, "TransceiverConfigEnableUnsupported", (*mpb.Metadata_Deviations).GetTransceiverConfigEnableUnsupported)
 }
 
 // AFTSummaryOCUnsupported returns true "/network-instances/network-instance/afts/aft-summaries" OC path is not supported.
 func AFTSummaryOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAftSummaryOcUnsupported()
+	return dutDeviation(dut, "AFTSummaryOCUnsupported", (*mpb.Metadata_Deviations).GetAftSummaryOcUnsupported)
 }
 
 // ISISLSPTlvsOCUnsupported returns true if "/network-instances/network-instance/protocols/protocol/isis/levels/level/link-state-database/lsp/tlvs" OC path is not supported.
 func ISISLSPTlvsOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisLspTlvsOcUnsupported()
+	return dutDeviation(dut, "ISISLSPTlvsOCUnsupported", (*mpb.Metadata_Deviations).GetIsisLspTlvsOcUnsupported)
 }
 
 // ISISAdjacencyStreamUnsupported returns if "/network-instances/network-instance/protocols/protocol/isis/interfaces/interface/levels/level/adjacencies" OC path
 // is not supported or malfunctioning when STREAM subscription is used .
 func ISISAdjacencyStreamUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetIsisAdjacencyStreamUnsupported()
+	return dutDeviation(dut, "ISISAdjacencyStreamUnsupported", (*mpb.Metadata_Deviations).GetIsisAdjacencyStreamUnsupported)
 }
 
 // localhost_for_containerz returns if the device uses an IPv6 address instead of localhost.
 func LocalhostForContainerz(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetLocalhostForContainerz()
+	return dutDeviation(dut, "LocalhostForContainerz", (*mpb.Metadata_Deviations).GetLocalhostForContainerz)
 }
 
 // AggregateBandwidthPolicyActionUnsupported returns true if device does not support aggregate bandwidth policy action.
 func AggregateBandwidthPolicyActionUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAggregateBandwidthPolicyActionUnsupported()
+	return dutDeviation(dut, "AggregateBandwidthPolicyActionUnsupported", (*mpb.Metadata_Deviations).GetAggregateBandwidthPolicyActionUnsupported)
 }
 
 // AutoLinkBandwidthUnsupported returns true if device does not support auto link bandwidth.
 func AutoLinkBandwidthUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAutoLinkBandwidthUnsupported()
+	return dutDeviation(dut, "AutoLinkBandwidthUnsupported", (*mpb.Metadata_Deviations).GetAutoLinkBandwidthUnsupported)
 }
 
 // AdvertisedCumulativeLBwOCUnsupported returns true if device does not support oc state path for advertised cumulative link bandwidth.
 func AdvertisedCumulativeLBwOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetAdvertisedCumulativeLbwOcUnsupported()
+	return dutDeviation(dut, "AdvertisedCumulativeLBwOCUnsupported", (*mpb.Metadata_Deviations).GetAdvertisedCumulativeLbwOcUnsupported)
 }
 
 // DisableHardwareNexthopProxy returns true if the device requires disabling hardware nexthop proxying
 func DisableHardwareNexthopProxy(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetDisableHardwareNexthopProxy()
+	return dutDeviation(dut, "DisableHardwareNexthopProxy", (*mpb.Metadata_Deviations).GetDisableHardwareNexthopProxy)
 }
 
 // URPFConfigOCUnsupported returns true if OC does not support configuring uRPF.
 func URPFConfigOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetInterfacePolicyForwardingUnsupported()
+	return dutDeviation(dut, "URPFConfigOCUnsupported", (*mpb.Metadata_Deviations).GetInterfacePolicyForwardingUnsupported)
 }
 
 // StaticRouteNextNetworkInstanceOCUnsupported returns true for devices that don't support NextNetworkInstance of static route next hop.
 func StaticRouteNextNetworkInstanceOCUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetStaticRouteNextNetworkInstanceOcUnsupported()
+	return dutDeviation(dut, "StaticRouteNextNetworkInstanceOCUnsupported", (*mpb.Metadata_Deviations).GetStaticRouteNextNetworkInstanceOcUnsupported)
 }
 
 // GnpsiOcUnsupported returns true if there's no OC support for configuring gNPSI
 func GnpsiOcUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetGnpsiOcUnsupported()
+	return dutDeviation(dut, "GnpsiOcUnsupported", (*mpb.Metadata_Deviations).GetGnpsiOcUnsupported)
 }
 
 // SyslogNonDefaultVrfUnsupported returns true if device does not support adding remote-syslog config under
 // non-default VRF
 func SyslogNonDefaultVrfUnsupported(dut *ondatra.DUTDevice) bool {
-	return lookupDUTDeviations(dut).GetSyslogNonDefaultVrfUnsupported()
+	return dutDeviation(dut, "SyslogNonDefaultVrfUnsupported", (*mpb.Metadata_Deviations).GetSyslogNonDefaultVrfUnsupported)
 }
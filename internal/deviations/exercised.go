@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/eventlis"
+
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+)
+
+var (
+	exercisedMu sync.Mutex
+	// exercised maps a device name and an accessor name to the deviation
+	// the accessor returned for the device.
+	exercised = map[string]map[string]exercise{}
+)
+
+// exercise is one deviation returned by an accessor.
+type exercise struct {
+	// value is the value returned by the accessor.
+	value string
+	// applied is true if the deviation differs from its OpenConfig compliant
+	// default, i.e. the deviation changed the behavior of the test, or would
+	// have under -strict_compliance.
+	applied bool
+}
+
+// exerciseDeviation returns the deviation read by get from the deviations of
+// a device, or the OpenConfig compliant default read by get from empty
+// deviations under -strict_compliance, and records the value returned for the
+// accessor.
+func exerciseDeviation[T comparable](dvc string, devs *mpb.Metadata_Deviations, accessor string, get func(*mpb.Metadata_Deviations) T) T {
+	v, compliant := get(devs), get(&mpb.Metadata_Deviations{})
+	e := exercise{applied: v != compliant}
+	if *strictCompliance {
+		v = compliant
+	}
+	e.value = fmt.Sprint(v)
+
+	exercisedMu.Lock()
+	defer exercisedMu.Unlock()
+	if exercised[dvc] == nil {
+		exercised[dvc] = make(map[string]exercise)
+	}
+	exercised[dvc][accessor] = e
+	if e.applied {
+		markTracked(accessor)
+	}
+	return v
+}
+
+// ExercisedProperties returns the test properties describing the deviations
+// looked up by the test so far:
+//
+//   - deviations.exercised.<device> - a comma separated list of the accessors
+//     called for the device with the value they returned, e.g.
+//     "DefaultNetworkInstance=DEFAULT,OmitL2MTU=false".
+//   - deviations.applied - a comma separated list of the accessors that
+//     returned a deviation for any device.
+//   - deviations.clean - true if no accessor returned a deviation, i.e. the
+//     test ran without deviations.
+//   - deviations.suppressed - under -strict_compliance, the accessors that
+//     would have returned a deviation, in which case
+//     deviations.applied is empty.
+func ExercisedProperties() map[string]string {
+	exercisedMu.Lock()
+	defer exercisedMu.Unlock()
+
+	m := make(map[string]string)
+	applied := map[string]bool{}
+	for dvc, accessors := range exercised {
+		var parts []string
+		for accessor, e := range accessors {
+			parts = append(parts, fmt.Sprintf("%s=%s", accessor, e.value))
+			if e.applied {
+				applied[accessor] = true
+			}
+		}
+		sort.Strings(parts)
+		m["deviations.exercised."+dvc] = strings.Join(parts, ",")
+	}
+
+	var names []string
+	for accessor := range applied {
+		names = append(names, accessor)
+	}
+	sort.Strings(names)
+	if *strictCompliance {
+		m["deviations.suppressed"] = strings.Join(names, ",")
+		names = nil
+	}
+	m["deviations.applied"] = strings.Join(names, ",")
+	m["deviations.clean"] = fmt.Sprint(len(names) == 0)
+	return m
+}
+
+// RegisterExercised registers a callback that adds ExercisedProperties and
+// ComplianceProperties to the test suite properties after the tests finish,
+// so that results can be told apart by the deviations they ran with.
+func RegisterExercised() {
+	ondatra.EventListener().AddAfterTestsCallback(func(e *eventlis.AfterTestsEvent) error {
+		exitCode := 0
+		if e.ExitCode != nil {
+			exitCode = *e.ExitCode
+		}
+		for k, v := range ExercisedProperties() {
+			ondatra.Report().AddSuiteProperty(k, v)
+		}
+		for k, v := range ComplianceProperties(exitCode) {
+			ondatra.Report().AddSuiteProperty(k, v)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+)
+
+// The functions below stand in for the accessors calling dutDeviation.
+
+func omitL2Mtu(dvc string, devs *mpb.Metadata_Deviations) bool {
+	return exerciseDeviation(dvc, devs, "omitL2Mtu", (*mpb.Metadata_Deviations).GetOmitL2Mtu)
+}
+
+func defaultNetworkInstance(dvc string, devs *mpb.Metadata_Deviations) string {
+	return exerciseDeviation(dvc, devs, "defaultNetworkInstance", func(devs *mpb.Metadata_Deviations) string {
+		if dni := devs.GetDefaultNetworkInstance(); dni != "" {
+			return dni
+		}
+		return "DEFAULT"
+	})
+}
+
+func hierarchicalWeightResolutionTolerance(dvc string, devs *mpb.Metadata_Deviations) float64 {
+	return exerciseDeviation(dvc, devs, "hierarchicalWeightResolutionTolerance", func(devs *mpb.Metadata_Deviations) float64 {
+		return max(devs.GetHierarchicalWeightResolutionTolerance(), 0.2)
+	})
+}
+
+func TestExercisedProperties(t *testing.T) {
+	exercised = map[string]map[string]exercise{}
+	defer func() { exercised = map[string]map[string]exercise{} }()
+
+	if diff := cmp.Diff(map[string]string{
+		"deviations.applied": "",
+		"deviations.clean":   "true",
+	}, ExercisedProperties()); diff != "" {
+		t.Errorf("ExercisedProperties() before lookups -want,+got:\n%s", diff)
+	}
+
+	dut1 := &mpb.Metadata_Deviations{OmitL2Mtu: true, HierarchicalWeightResolutionTolerance: 0.1}
+	dut2 := &mpb.Metadata_Deviations{DefaultNetworkInstance: "VRF", HierarchicalWeightResolutionTolerance: 0.5}
+	omitL2Mtu("dut1", dut1)
+	defaultNetworkInstance("dut1", dut1)
+	hierarchicalWeightResolutionTolerance("dut1", dut1)
+	omitL2Mtu("dut2", dut2)
+	defaultNetworkInstance("dut2", dut2)
+	hierarchicalWeightResolutionTolerance("dut2", dut2)
+
+	want := map[string]string{
+		"deviations.exercised.dut1": "defaultNetworkInstance=DEFAULT,hierarchicalWeightResolutionTolerance=0.2,omitL2Mtu=true",
+		"deviations.exercised.dut2": "defaultNetworkInstance=VRF,hierarchicalWeightResolutionTolerance=0.5,omitL2Mtu=false",
+		"deviations.applied":        "defaultNetworkInstance,hierarchicalWeightResolutionTolerance,omitL2Mtu",
+		"deviations.clean":          "false",
+	}
+	if diff := cmp.Diff(want, ExercisedProperties()); diff != "" {
+		t.Errorf("ExercisedProperties() -want,+got:\n%s", diff)
+	}
+}
+
+// TestAccessorNames checks that every accessor records the deviation it
+// returns under its own name.
+func TestAccessorNames(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, file := range []string{"deviations.go", "byexceptions.go"} {
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+			var names []string
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if name, ok := accessorName(n); ok {
+					names = append(names, name)
+				}
+				return true
+			})
+			if len(names) != 1 || names[0] != fn.Name.Name {
+				t.Errorf("%s: accessor %s records deviations as %q, want [%q]", fset.Position(fn.Pos()), fn.Name.Name, names, fn.Name.Name)
+			}
+		}
+	}
+}
+
+// accessorName returns the accessor name passed to a call of dutDeviation or
+// ateDeviation.
+func accessorName(n ast.Node) (string, bool) {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) != 3 {
+		return "", false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || (ident.Name != "dutDeviation" && ident.Name != "ateDeviation") {
+		return "", false
+	}
+	lit, ok := call.Args[1].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	name, err := strconv.Unquote(lit.Value)
+	return name, err == nil
+}
@@ -0,0 +1,402 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/featureprofiles/internal/pathutil"
+	"github.com/openconfig/ondatra"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	dpb "github.com/openconfig/featureprofiles/proto/deviations_go_proto"
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// registryFile is the location of the deviation registry relative to the
+// root of the featureprofiles repository.
+const registryFile = "internal/deviations/deviations.textproto"
+
+var (
+	registryOnce sync.Once
+	registryPB   *dpb.DeviationRegistry
+	registryErr  error
+
+	registriesMu sync.Mutex
+	registries   = map[string]*Registry{}
+
+	// Stub out for unit tests.
+	registryPathFn = defaultRegistryPath
+)
+
+func defaultRegistryPath() (string, error) {
+	rootPath, err := pathutil.RootPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(rootPath, registryFile), nil
+}
+
+// loadRegistry reads and validates the deviation registry.  The registry is
+// only read once per test binary.
+func loadRegistry() (*dpb.DeviationRegistry, error) {
+	registryOnce.Do(func() {
+		path, err := registryPathFn()
+		if err != nil {
+			registryErr = err
+			return
+		}
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			registryErr = err
+			return
+		}
+		registryPB, registryErr = parseRegistry(bytes)
+	})
+	return registryPB, registryErr
+}
+
+// parseRegistry unmarshals a DeviationRegistry textproto and checks that it
+// is well-formed.  A deviation name may appear more than once, e.g. when the
+// impacted paths differ between vendors, but always with the same type.
+func parseRegistry(bytes []byte) (*dpb.DeviationRegistry, error) {
+	reg := new(dpb.DeviationRegistry)
+	if err := prototext.Unmarshal(bytes, reg); err != nil {
+		return nil, fmt.Errorf("unable to parse deviation registry: %w", err)
+	}
+	types := map[string]dpb.DeviationType{}
+	for _, d := range reg.GetDeviations() {
+		name := d.GetName()
+		if name == "" {
+			return nil, fmt.Errorf("deviation name should be specified in registry %v", d)
+		}
+		if t, ok := types[name]; ok && t != d.GetType() {
+			return nil, fmt.Errorf("deviation %q is defined with conflicting types %v and %v", name, t, d.GetType())
+		}
+		types[name] = d.GetType()
+		for _, pd := range d.GetPlatforms() {
+			if pd.GetPlatform().GetVendor() == opb.Device_VENDOR_UNSPECIFIED {
+				return nil, fmt.Errorf("deviation %q: vendor should be specified in platform %v", name, pd.GetPlatform())
+			}
+			for _, re := range []string{pd.GetPlatform().GetHardwareModelRegex(), pd.GetPlatform().GetSoftwareVersionRegex()} {
+				if _, err := regexp.Compile(re); err != nil {
+					return nil, fmt.Errorf("deviation %q: %w", name, err)
+				}
+			}
+			for _, cmd := range pd.GetClis().GetCommands() {
+				if _, err := regexp.Compile(cmd); err != nil {
+					return nil, fmt.Errorf("deviation %q: invalid CLI regex: %w", name, err)
+				}
+			}
+		}
+	}
+	return reg, nil
+}
+
+// matchPlatform reports whether the device with the given vendor, hardware
+// model and software version matches the platform.  An empty regex matches
+// any hardware model or software version.
+func matchPlatform(p *mpb.Metadata_Platform, vendor opb.Device_Vendor, model, version string) (bool, error) {
+	if p.GetVendor().String() == "" {
+		return false, fmt.Errorf("vendor should be specified in textproto %v", p)
+	}
+	if vendor.String() != p.GetVendor().String() {
+		return false, nil
+	}
+	// If hardware_model_regex is set and does not match, continue
+	if hardwareModelRegex := p.GetHardwareModelRegex(); hardwareModelRegex != "" {
+		matchHw, errHw := regexp.MatchString(hardwareModelRegex, model)
+		if errHw != nil {
+			return false, fmt.Errorf("error with regex match %v", errHw)
+		}
+		if !matchHw {
+			return false, nil
+		}
+	}
+	// If software_version_regex is set and does not match, continue
+	if softwareVersionRegex := p.GetSoftwareVersionRegex(); softwareVersionRegex != "" {
+		matchSw, errSw := regexp.MatchString(softwareVersionRegex, version)
+		if errSw != nil {
+			return false, fmt.Errorf("error with regex match %v", errSw)
+		}
+		if !matchSw {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Entry is a deviation from the registry as it applies to one platform.
+type Entry struct {
+	// Name is the name of the deviation, e.g. "default_network_instance".
+	Name string
+	// Type is the type of the deviation.
+	Type dpb.DeviationType
+	// IssueURL tracks the deviation for the matched platform.
+	IssueURL string
+	// ImpactedPaths are the OpenConfig schema paths affected by the deviation.
+	ImpactedPaths []string
+	// AdditionalPaths are the paths used instead of the impacted paths
+	// for DEVIATION_TYPE_PATH deviations.
+	AdditionalPaths []string
+	// CLIs are the CLI command formats used instead of the impacted paths
+	// for DEVIATION_TYPE_CLI deviations.
+	CLIs []*regexp.Regexp
+	// OCValue and VendorValue are the OpenConfig standard value and the
+	// vendor specific value for DEVIATION_TYPE_VALUE deviations.
+	OCValue     *gpb.TypedValue
+	VendorValue *gpb.TypedValue
+}
+
+// MatchesCLI reports whether the CLI text matches any of the CLI command
+// formats of the deviation.
+func (e *Entry) MatchesCLI(cli string) bool {
+	for _, re := range e.CLIs {
+		if re.MatchString(cli) {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry is the set of registry deviations that apply to a device.
+// A nil *Registry is valid and has no deviations.
+type Registry struct {
+	entries map[string]*Entry
+	// byPath maps from an impacted schema path to the deviations affecting it.
+	byPath map[string][]*Entry
+}
+
+// newRegistry selects the deviations in reg whose platforms match the
+// device with the given vendor, hardware model and software version.
+// Matching deviations that share a name are merged into one Entry.
+func newRegistry(reg *dpb.DeviationRegistry, vendor opb.Device_Vendor, model, version string) (*Registry, error) {
+	r := &Registry{
+		entries: map[string]*Entry{},
+		byPath:  map[string][]*Entry{},
+	}
+	for _, d := range reg.GetDeviations() {
+		var matched *dpb.PlatformData
+		for _, pd := range d.GetPlatforms() {
+			ok, err := matchPlatform(pd.GetPlatform(), vendor, model, version)
+			if err != nil {
+				return nil, fmt.Errorf("deviation %q: %w", d.GetName(), err)
+			}
+			if !ok {
+				continue
+			}
+			if matched != nil {
+				return nil, fmt.Errorf("deviation %q: cannot have more than one match within platforms fields %v and %v", d.GetName(), matched, pd)
+			}
+			matched = pd
+		}
+		if matched == nil {
+			continue
+		}
+		e, ok := r.entries[d.GetName()]
+		if !ok {
+			e = &Entry{
+				Name:     d.GetName(),
+				Type:     d.GetType(),
+				IssueURL: matched.GetIssueUrl(),
+			}
+			r.entries[e.Name] = e
+		}
+		if e.IssueURL == "" {
+			e.IssueURL = matched.GetIssueUrl()
+		}
+		if e.OCValue == nil && e.VendorValue == nil {
+			e.OCValue = matched.GetDeviationValues().GetOcStandardValue()
+			e.VendorValue = matched.GetDeviationValues().GetVendorSpecificValue()
+		}
+		for _, p := range d.GetImpactedPaths().GetOcpaths() {
+			if !slices.Contains(e.ImpactedPaths, p.GetName()) {
+				e.ImpactedPaths = append(e.ImpactedPaths, p.GetName())
+				sp := schemaPath(p.GetName())
+				r.byPath[sp] = append(r.byPath[sp], e)
+			}
+		}
+		for _, p := range matched.GetAdditionalPaths().GetOcpaths() {
+			if !slices.Contains(e.AdditionalPaths, p.GetName()) {
+				e.AdditionalPaths = append(e.AdditionalPaths, p.GetName())
+			}
+		}
+		for _, cmd := range matched.GetClis().GetCommands() {
+			re, err := regexp.Compile(cmd)
+			if err != nil {
+				return nil, fmt.Errorf("deviation %q: invalid CLI regex: %w", d.GetName(), err)
+			}
+			e.CLIs = append(e.CLIs, re)
+		}
+	}
+	return r, nil
+}
+
+var keyRE = regexp.MustCompile(`\[[^\]]*\]`)
+
+// schemaPath strips list keys and any trailing slash from a path, so that
+// "/interfaces/interface[name=Ethernet1]/config/mtu" is looked up as
+// "/interfaces/interface/config/mtu".
+func schemaPath(path string) string {
+	path = keyRE.ReplaceAllString(path, "")
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// Names returns the sorted names of all deviations in the registry.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the named deviation, or false if it does not apply.
+func (r *Registry) Lookup(name string) (*Entry, bool) {
+	if r == nil {
+		return nil, false
+	}
+	e, ok := r.entries[name]
+	return e, ok
+}
+
+// PathDeviations returns the deviations which impact the OpenConfig path.
+// List keys in the path are ignored.
+func (r *Registry) PathDeviations(path string) []*Entry {
+	if r == nil {
+		return nil
+	}
+	return r.byPath[schemaPath(path)]
+}
+
+// IsPathDeviated reports whether any deviation impacts the OpenConfig path.
+func (r *Registry) IsPathDeviated(path string) bool {
+	return len(r.PathDeviations(path)) > 0
+}
+
+// ValueDeviations returns the DEVIATION_TYPE_VALUE deviations in the registry,
+// ordered by name.
+func (r *Registry) ValueDeviations() []*Entry {
+	var entries []*Entry
+	for _, name := range r.Names() {
+		if e := r.entries[name]; e.Type == dpb.DeviationType_DEVIATION_TYPE_VALUE && e.OCValue != nil && e.VendorValue != nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// VendorValue returns the vendor specific value that replaces the OpenConfig
+// standard value ocVal at the given path.  It returns false if no value
+// deviation applies to that path and value.
+func (r *Registry) VendorValue(path string, ocVal *gpb.TypedValue) (*gpb.TypedValue, bool) {
+	return r.translate(schemaPath(path), ocVal, true)
+}
+
+// OCValue returns the OpenConfig standard value for the vendor specific value
+// vendorVal at the given path.  It returns false if no value deviation
+// applies to that path and value.
+func (r *Registry) OCValue(path string, vendorVal *gpb.TypedValue) (*gpb.TypedValue, bool) {
+	return r.translate(schemaPath(path), vendorVal, false)
+}
+
+// translate maps the value at the schema path from the OpenConfig standard
+// value to the vendor specific value if toVendor, or the other way around.
+func (r *Registry) translate(schemaPath string, val *gpb.TypedValue, toVendor bool) (*gpb.TypedValue, bool) {
+	if r == nil {
+		return nil, false
+	}
+	for _, e := range r.byPath[schemaPath] {
+		if e.Type != dpb.DeviationType_DEVIATION_TYPE_VALUE {
+			continue
+		}
+		from, to := e.OCValue, e.VendorValue
+		if !toVendor {
+			from, to = to, from
+		}
+		if to != nil && typedValueEqual(from, val) {
+			return to, true
+		}
+	}
+	return nil, false
+}
+
+func typedValueEqual(a, b *gpb.TypedValue) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return proto.Equal(a, b)
+}
+
+// RegistryFor returns the deviations from the deviation registry that apply
+// to a device with the given vendor, hardware model and software version.
+// It is meant for callers such as bindings that have no *ondatra.Device.
+func RegistryFor(vendor opb.Device_Vendor, model, version string) (*Registry, error) {
+	key := fmt.Sprintf("%s|%s|%s", vendor, model, version)
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+	if r, ok := registries[key]; ok {
+		return r, nil
+	}
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	r, err := newRegistry(reg, vendor, model, version)
+	if err != nil {
+		return nil, err
+	}
+	registries[key] = r
+	return r, nil
+}
+
+func mustLookupRegistry(dvc *ondatra.Device) *Registry {
+	r, err := RegistryFor(opb.Device_Vendor(dvc.Vendor()), dvc.Model(), dvc.Version())
+	if err != nil {
+		log.Exitf("Error looking up deviation registry: %v", err)
+	}
+	return r
+}
+
+// DUTRegistry returns the deviations from the deviation registry that apply
+// to the DUT, so that tests can ask whether and how an OpenConfig path is
+// deviated on the DUT.
+func DUTRegistry(dut *ondatra.DUTDevice) *Registry {
+	return mustLookupRegistry(dut.Device)
+}
+
+// ATERegistry returns the deviations from the deviation registry that apply
+// to the ATE.
+func ATERegistry(ate *ondatra.ATEDevice) *Registry {
+	return mustLookupRegistry(ate.Device)
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	dpb "github.com/openconfig/featureprofiles/proto/deviations_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+const testRegistry = `
+deviations: {
+  name: "default_network_instance"
+  type: DEVIATION_TYPE_VALUE
+  impacted_paths: {
+    ocpaths: {
+      name: "/network-instances/network-instance/config/name"
+    }
+  }
+  platforms: {
+    issue_url: "https://example.com/1"
+    platform: {
+      vendor: ARISTA
+      software_version_regex: ".*"
+    }
+    deviation_values: {
+      oc_standard_value: {
+        string_val: "default"
+      }
+      vendor_specific_value: {
+        string_val: "DEFAULT"
+      }
+    }
+  }
+}
+deviations: {
+  name: "route_policy_under_afi_unsupported"
+  type: DEVIATION_TYPE_PATH
+  impacted_paths: {
+    ocpaths: {
+      name: "/network-instances/network-instance/protocols/protocol/bgp/peer-groups/peer-group/afi-safis/afi-safi/apply-policy/config/export-policy"
+    }
+  }
+  platforms: {
+    platform: {
+      vendor: ARISTA
+      hardware_model_regex: "^7280"
+    }
+    additional_paths: {
+      ocpaths: {
+        name: "/network-instances/network-instance/protocols/protocol/bgp/peer-groups/peer-group/apply-policy/config/export-policy"
+      }
+    }
+  }
+  platforms: {
+    platform: {
+      vendor: CISCO
+    }
+    additional_paths: {
+      ocpaths: {
+        name: "/network-instances/network-instance/protocols/protocol/bgp/peer-groups/peer-group/apply-policy/config/export-policy"
+      }
+    }
+  }
+}
+deviations: {
+  name: "bgp_missing_oc_max_prefixes_configuration"
+  type: DEVIATION_TYPE_CLI
+  impacted_paths: {
+    ocpaths: {
+      name: "/network-instances/network-instance/protocols/protocol/bgp/peer-groups/peer-group/afi-safis/afi-safi/use-multiple-paths/ebgp/config/maximum-paths"
+    }
+  }
+  platforms: {
+    platform: {
+      vendor: ARISTA
+    }
+    clis: {
+      commands: "router.bgp.[0-9]+\n +neighbor.*maximum-routes.[0-9]+"
+    }
+  }
+}
+`
+
+func mustParseRegistry(t *testing.T, s string) *dpb.DeviationRegistry {
+	t.Helper()
+	reg, err := parseRegistry([]byte(s))
+	if err != nil {
+		t.Fatalf("parseRegistry() got unexpected error: %v", err)
+	}
+	return reg
+}
+
+func TestParseRegistry(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		wantErr string
+	}{{
+		desc: "valid",
+		in:   testRegistry,
+	}, {
+		desc:    "missing name",
+		in:      `deviations: { type: DEVIATION_TYPE_PATH }`,
+		wantErr: "name should be specified",
+	}, {
+		desc: "repeated name",
+		in:   `deviations: { name: "a" type: DEVIATION_TYPE_PATH } deviations: { name: "a" type: DEVIATION_TYPE_PATH }`,
+	}, {
+		desc:    "conflicting types",
+		in:      `deviations: { name: "a" type: DEVIATION_TYPE_PATH } deviations: { name: "a" type: DEVIATION_TYPE_CLI }`,
+		wantErr: "conflicting types",
+	}, {
+		desc:    "missing vendor",
+		in:      `deviations: { name: "a" platforms: { platform: { software_version_regex: ".*" } } }`,
+		wantErr: "vendor should be specified",
+	}, {
+		desc:    "invalid version regex",
+		in:      `deviations: { name: "a" platforms: { platform: { vendor: NOKIA software_version_regex: "*.*" } } }`,
+		wantErr: "missing argument to repetition operator",
+	}, {
+		desc:    "invalid cli regex",
+		in:      `deviations: { name: "a" platforms: { platform: { vendor: NOKIA } clis: { commands: "(" } } }`,
+		wantErr: "invalid CLI regex",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := parseRegistry([]byte(tc.in))
+			if diff := errdiff.Substring(err, tc.wantErr); diff != "" {
+				t.Errorf("parseRegistry() got unexpected error diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	reg := mustParseRegistry(t, testRegistry)
+	tests := []struct {
+		desc   string
+		vendor opb.Device_Vendor
+		model  string
+		want   []string
+	}{{
+		desc:   "arista 7280",
+		vendor: opb.Device_ARISTA,
+		model:  "7280R3",
+		want:   []string{"bgp_missing_oc_max_prefixes_configuration", "default_network_instance", "route_policy_under_afi_unsupported"},
+	}, {
+		desc:   "arista 7800",
+		vendor: opb.Device_ARISTA,
+		model:  "7800R3",
+		want:   []string{"bgp_missing_oc_max_prefixes_configuration", "default_network_instance"},
+	}, {
+		desc:   "cisco",
+		vendor: opb.Device_CISCO,
+		model:  "8808",
+		want:   []string{"route_policy_under_afi_unsupported"},
+	}, {
+		desc:   "juniper",
+		vendor: opb.Device_JUNIPER,
+		model:  "PTX10008",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := newRegistry(reg, tc.vendor, tc.model, "1.0")
+			if err != nil {
+				t.Fatalf("newRegistry() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, r.Names()); diff != "" {
+				t.Errorf("Names() got unexpected diff (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewRegistryMultipleMatches(t *testing.T) {
+	reg := mustParseRegistry(t, `deviations: {
+  name: "a"
+  platforms: { platform: { vendor: NOKIA } }
+  platforms: { platform: { vendor: NOKIA hardware_model_regex: "7250" } }
+}`)
+	_, err := newRegistry(reg, opb.Device_NOKIA, "7250 IXR-10e", "24.3")
+	if diff := errdiff.Substring(err, "more than one match"); diff != "" {
+		t.Errorf("newRegistry() got unexpected error diff: %s", diff)
+	}
+}
+
+func TestNewRegistryMerge(t *testing.T) {
+	reg := mustParseRegistry(t, `deviations: {
+  name: "a"
+  type: DEVIATION_TYPE_PATH
+  impacted_paths: { ocpaths: { name: "/x/config/y" } }
+  platforms: { platform: { vendor: NOKIA } }
+}
+deviations: {
+  name: "a"
+  type: DEVIATION_TYPE_PATH
+  impacted_paths: { ocpaths: { name: "/x/config/y" } ocpaths: { name: "/x/state/y" } }
+  platforms: { platform: { vendor: NOKIA } }
+}`)
+	r, err := newRegistry(reg, opb.Device_NOKIA, "7250 IXR-10e", "24.3")
+	if err != nil {
+		t.Fatalf("newRegistry() got unexpected error: %v", err)
+	}
+	e, ok := r.Lookup("a")
+	if !ok {
+		t.Fatalf("Lookup() got false, want true")
+	}
+	if diff := cmp.Diff([]string{"/x/config/y", "/x/state/y"}, e.ImpactedPaths); diff != "" {
+		t.Errorf("ImpactedPaths got unexpected diff (-want, +got): %s", diff)
+	}
+	if got := len(r.PathDeviations("/x/config/y")); got != 1 {
+		t.Errorf("PathDeviations() got %d deviations, want 1", got)
+	}
+}
+
+func TestRegistryLookups(t *testing.T) {
+	r, err := newRegistry(mustParseRegistry(t, testRegistry), opb.Device_ARISTA, "7280R3", "4.33")
+	if err != nil {
+		t.Fatalf("newRegistry() got unexpected error: %v", err)
+	}
+
+	t.Run("path", func(t *testing.T) {
+		const path = "/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=BGP]/bgp/peer-groups/peer-group[peer-group-name=pg]/afi-safis/afi-safi[afi-safi-name=IPV4_UNICAST]/apply-policy/config/export-policy"
+		got := r.PathDeviations(path)
+		if len(got) != 1 || got[0].Name != "route_policy_under_afi_unsupported" {
+			t.Fatalf("PathDeviations(%q) got %v, want route_policy_under_afi_unsupported", path, got)
+		}
+		want := []string{"/network-instances/network-instance/protocols/protocol/bgp/peer-groups/peer-group/apply-policy/config/export-policy"}
+		if diff := cmp.Diff(want, got[0].AdditionalPaths); diff != "" {
+			t.Errorf("AdditionalPaths got unexpected diff (-want, +got): %s", diff)
+		}
+		if r.IsPathDeviated("/interfaces/interface/config/mtu") {
+			t.Errorf("IsPathDeviated(/interfaces/interface/config/mtu) got true, want false")
+		}
+	})
+
+	t.Run("cli", func(t *testing.T) {
+		e, ok := r.Lookup("bgp_missing_oc_max_prefixes_configuration")
+		if !ok {
+			t.Fatalf("Lookup() got false, want true")
+		}
+		if cli := "router bgp 65000\n   neighbor pg maximum-routes 100"; !e.MatchesCLI(cli) {
+			t.Errorf("MatchesCLI(%q) got false, want true", cli)
+		}
+	})
+
+	t.Run("value", func(t *testing.T) {
+		const path = "/network-instances/network-instance/config/name"
+		ocVal := &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "default"}}
+		vendorVal := &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "DEFAULT"}}
+		got, ok := r.VendorValue(path, ocVal)
+		if !ok {
+			t.Fatalf("VendorValue() got false, want true")
+		}
+		if diff := cmp.Diff(vendorVal, got, protocmp.Transform()); diff != "" {
+			t.Errorf("VendorValue() got unexpected diff (-want, +got): %s", diff)
+		}
+		got, ok = r.OCValue(path, vendorVal)
+		if !ok {
+			t.Fatalf("OCValue() got false, want true")
+		}
+		if diff := cmp.Diff(ocVal, got, protocmp.Transform()); diff != "" {
+			t.Errorf("OCValue() got unexpected diff (-want, +got): %s", diff)
+		}
+		if _, ok := r.VendorValue(path, vendorVal); ok {
+			t.Errorf("VendorValue() of a vendor value got true, want false")
+		}
+	})
+}
+
+func TestNilRegistry(t *testing.T) {
+	var r *Registry
+	if got := r.Names(); got != nil {
+		t.Errorf("Names() got %v, want nil", got)
+	}
+	if r.IsPathDeviated("/interfaces/interface/config/mtu") {
+		t.Errorf("IsPathDeviated() got true, want false")
+	}
+}
+
+// TestRegistryFile checks that the checked-in registry is valid for every vendor.
+func TestRegistryFile(t *testing.T) {
+	bytes, err := os.ReadFile("deviations.textproto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, err := parseRegistry(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, vendor := range []opb.Device_Vendor{opb.Device_ARISTA, opb.Device_CISCO, opb.Device_JUNIPER, opb.Device_NOKIA} {
+		if _, err := newRegistry(reg, vendor, "", ""); err != nil {
+			t.Errorf("newRegistry(%v) got unexpected error: %v", vendor, err)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// RewriteGNMI wraps a gNMI client so that test code can be written against
+// OpenConfig standard values only.  The DEVIATION_TYPE_VALUE deviations in
+// the registry are applied to list keys and leaf values of outgoing Get, Set
+// and Subscribe requests, replacing the OpenConfig standard value with the
+// vendor specific value, and reversed on the notifications coming back.
+//
+// Only paths in the OpenConfig origin are rewritten.  If the registry has no
+// value deviations, the client is returned unchanged.
+func (r *Registry) RewriteGNMI(c gpb.GNMIClient) gpb.GNMIClient {
+	if len(r.ValueDeviations()) == 0 {
+		return c
+	}
+	return &rewritingGNMI{GNMIClient: c, r: r}
+}
+
+type rewritingGNMI struct {
+	gpb.GNMIClient
+	r *Registry
+}
+
+func (c *rewritingGNMI) Get(ctx context.Context, req *gpb.GetRequest, opts ...grpc.CallOption) (*gpb.GetResponse, error) {
+	req = proto.Clone(req).(*gpb.GetRequest)
+	c.r.rewritePath(nil, req.GetPrefix(), true)
+	for _, p := range req.GetPath() {
+		c.r.rewritePath(req.GetPrefix(), p, true)
+	}
+	resp, err := c.GNMIClient.Get(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range resp.GetNotification() {
+		if err := c.r.rewriteNotification(n, false); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (c *rewritingGNMI) Set(ctx context.Context, req *gpb.SetRequest, opts ...grpc.CallOption) (*gpb.SetResponse, error) {
+	req = proto.Clone(req).(*gpb.SetRequest)
+	prefix := req.GetPrefix()
+	c.r.rewritePath(nil, prefix, true)
+	for _, p := range req.GetDelete() {
+		c.r.rewritePath(prefix, p, true)
+	}
+	for _, updates := range [][]*gpb.Update{req.GetReplace(), req.GetUpdate(), req.GetUnionReplace()} {
+		for _, u := range updates {
+			if err := c.r.rewriteUpdate(prefix, u, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	resp, err := c.GNMIClient.Set(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.r.rewritePath(nil, resp.GetPrefix(), false)
+	for _, res := range resp.GetResponse() {
+		c.r.rewritePath(resp.GetPrefix(), res.GetPath(), false)
+	}
+	return resp, nil
+}
+
+func (c *rewritingGNMI) Subscribe(ctx context.Context, opts ...grpc.CallOption) (gpb.GNMI_SubscribeClient, error) {
+	sc, err := c.GNMIClient.Subscribe(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rewritingSubscribe{GNMI_SubscribeClient: sc, r: c.r}, nil
+}
+
+type rewritingSubscribe struct {
+	gpb.GNMI_SubscribeClient
+	r *Registry
+}
+
+func (s *rewritingSubscribe) Send(req *gpb.SubscribeRequest) error {
+	if sl := req.GetSubscribe(); sl != nil {
+		req = proto.Clone(req).(*gpb.SubscribeRequest)
+		sl = req.GetSubscribe()
+		s.r.rewritePath(nil, sl.GetPrefix(), true)
+		for _, sub := range sl.GetSubscription() {
+			s.r.rewritePath(sl.GetPrefix(), sub.GetPath(), true)
+		}
+	}
+	return s.GNMI_SubscribeClient.Send(req)
+}
+
+func (s *rewritingSubscribe) Recv() (*gpb.SubscribeResponse, error) {
+	resp, err := s.GNMI_SubscribeClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if n := resp.GetUpdate(); n != nil {
+		if err := s.r.rewriteNotification(n, false); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (r *Registry) rewriteNotification(n *gpb.Notification, toVendor bool) error {
+	prefix := n.GetPrefix()
+	r.rewritePath(nil, prefix, toVendor)
+	for _, p := range n.GetDelete() {
+		r.rewritePath(prefix, p, toVendor)
+	}
+	for _, u := range n.GetUpdate() {
+		if err := r.rewriteUpdate(prefix, u, toVendor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteUpdate rewrites the path and the value of an update in place.
+func (r *Registry) rewriteUpdate(prefix *gpb.Path, u *gpb.Update, toVendor bool) error {
+	r.rewritePath(prefix, u.GetPath(), toVendor)
+	if !isOpenConfig(prefix, u.GetPath()) || u.GetVal() == nil {
+		return nil
+	}
+	elems := elemNames(prefix, u.GetPath())
+	switch v := u.GetVal().GetValue().(type) {
+	case *gpb.TypedValue_JsonIetfVal:
+		b, err := r.rewriteJSON(elems, v.JsonIetfVal, toVendor)
+		if err != nil {
+			return err
+		}
+		v.JsonIetfVal = b
+	case *gpb.TypedValue_JsonVal:
+		b, err := r.rewriteJSON(elems, v.JsonVal, toVendor)
+		if err != nil {
+			return err
+		}
+		v.JsonVal = b
+	default:
+		for _, sp := range leafSchemaPaths(elems) {
+			if val, ok := r.translate(sp, u.GetVal(), toVendor); ok {
+				u.Val = proto.Clone(val).(*gpb.TypedValue)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// rewritePath rewrites the list keys of path in place.  The prefix, if any,
+// provides the schema context of the path and is not modified.
+func (r *Registry) rewritePath(prefix, path *gpb.Path, toVendor bool) {
+	if path == nil || !isOpenConfig(prefix, path) {
+		return
+	}
+	names := elemNames(prefix, nil)
+	for _, e := range path.GetElem() {
+		names = append(names, trimModule(e.GetName()))
+		for k, v := range e.GetKey() {
+			if nv, ok := r.translateString(append(names[:len(names):len(names)], k), v, toVendor); ok {
+				e.Key[k] = nv
+			}
+		}
+	}
+}
+
+// rewriteJSON rewrites the string leaves of a JSON encoded subtree rooted at
+// the schema path elems.
+func (r *Registry) rewriteJSON(elems []string, b []byte, toVendor bool) ([]byte, error) {
+	var v any
+	d := json.NewDecoder(bytes.NewReader(b))
+	// Keep numbers as they are encoded, e.g. 64-bit integers.
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal JSON value at %s: %w", "/"+strings.Join(elems, "/"), err)
+	}
+	v, changed := r.rewriteJSONNode(elems, v, toVendor)
+	if !changed {
+		return b, nil
+	}
+	return json.Marshal(v)
+}
+
+func (r *Registry) rewriteJSONNode(elems []string, v any, toVendor bool) (any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		changed := false
+		for k, child := range v {
+			nc, ok := r.rewriteJSONNode(append(elems[:len(elems):len(elems)], trimModule(k)), child, toVendor)
+			if ok {
+				v[k] = nc
+				changed = true
+			}
+		}
+		return v, changed
+	case []any:
+		// List entries and leaf-list values share the schema path of the list.
+		changed := false
+		for i, child := range v {
+			nc, ok := r.rewriteJSONNode(elems, child, toVendor)
+			if ok {
+				v[i] = nc
+				changed = true
+			}
+		}
+		return v, changed
+	case string:
+		return r.translateString(elems, v, toVendor)
+	}
+	return v, false
+}
+
+// translateString translates a string value of the leaf at the schema path
+// elems.
+func (r *Registry) translateString(elems []string, s string, toVendor bool) (string, bool) {
+	val := &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	for _, sp := range leafSchemaPaths(elems) {
+		if nv, ok := r.translate(sp, val, toVendor); ok {
+			if nv.GetStringVal() == "" {
+				return s, false
+			}
+			return nv.GetStringVal(), true
+		}
+	}
+	return s, false
+}
+
+// leafSchemaPaths returns the schema paths under which a deviation of the leaf
+// at elems may be registered.  Deviations are usually registered against the
+// config leaf only, so a state leaf is also looked up under config and vice
+// versa.  A list key leaf, or the key of a list in a gNMI path, is looked up
+// under its config and state containers, e.g. the key network-instance[name]
+// is found as /network-instances/network-instance/config/name.
+func leafSchemaPaths(elems []string) []string {
+	n := len(elems)
+	paths := []string{"/" + strings.Join(elems, "/")}
+	if n < 2 {
+		return paths
+	}
+	switch parent := "/" + strings.Join(elems[:n-2], "/"); elems[n-2] {
+	case "config":
+		paths = append(paths, parent+"/state/"+elems[n-1])
+	case "state":
+		paths = append(paths, parent+"/config/"+elems[n-1])
+	default:
+		parent = "/" + strings.Join(elems[:n-1], "/")
+		paths = append(paths, parent+"/config/"+elems[n-1], parent+"/state/"+elems[n-1])
+	}
+	return paths
+}
+
+func elemNames(prefix, path *gpb.Path) []string {
+	var names []string
+	for _, p := range []*gpb.Path{prefix, path} {
+		for _, e := range p.GetElem() {
+			names = append(names, trimModule(e.GetName()))
+		}
+	}
+	return names
+}
+
+// trimModule removes the module name from a JSON_IETF member or path element
+// name, e.g. "openconfig-network-instance:network-instances".
+func trimModule(name string) string {
+	if i := strings.Index(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func isOpenConfig(prefix, path *gpb.Path) bool {
+	origin := path.GetOrigin()
+	if origin == "" {
+		origin = prefix.GetOrigin()
+	}
+	return origin == "" || origin == "openconfig"
+}
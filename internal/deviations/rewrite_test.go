@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+type fakeGNMI struct {
+	gpb.GNMIClient
+	gotGet  *gpb.GetRequest
+	gotSet  *gpb.SetRequest
+	getResp *gpb.GetResponse
+}
+
+func (f *fakeGNMI) Get(_ context.Context, req *gpb.GetRequest, _ ...grpc.CallOption) (*gpb.GetResponse, error) {
+	f.gotGet = req
+	return f.getResp, nil
+}
+
+func (f *fakeGNMI) Set(_ context.Context, req *gpb.SetRequest, _ ...grpc.CallOption) (*gpb.SetResponse, error) {
+	f.gotSet = req
+	return &gpb.SetResponse{}, nil
+}
+
+// mustPath parses a simple path string such as "/a/b[k=v]/c".  Key values
+// must not contain "/" or "]".
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p := &gpb.Path{}
+	for _, part := range strings.Split(strings.TrimPrefix(s, "/"), "/") {
+		name, keys, _ := strings.Cut(part, "[")
+		e := &gpb.PathElem{Name: name}
+		for _, kv := range strings.Split(keys, "[") {
+			if kv == "" {
+				continue
+			}
+			k, v, ok := strings.Cut(strings.TrimSuffix(kv, "]"), "=")
+			if !ok {
+				t.Fatalf("mustPath(%q): invalid key %q", s, kv)
+			}
+			if e.Key == nil {
+				e.Key = map[string]string{}
+			}
+			e.Key[k] = v
+		}
+		p.Elem = append(p.Elem, e)
+	}
+	return p
+}
+
+func stringVal(s string) *gpb.TypedValue {
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+}
+
+func aristaRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r, err := newRegistry(mustParseRegistry(t, testRegistry), opb.Device_ARISTA, "7280R3", "4.33")
+	if err != nil {
+		t.Fatalf("newRegistry() got unexpected error: %v", err)
+	}
+	return r
+}
+
+func TestRewriteGNMISet(t *testing.T) {
+	fake := &fakeGNMI{}
+	c := aristaRegistry(t).RewriteGNMI(fake)
+
+	req := &gpb.SetRequest{
+		Delete: []*gpb.Path{mustPath(t, "/network-instances/network-instance[name=default]/protocols")},
+		Update: []*gpb.Update{{
+			Path: mustPath(t, "/network-instances/network-instance[name=default]/config/name"),
+			Val:  stringVal("default"),
+		}, {
+			Path: mustPath(t, "/network-instances/network-instance[name=VRF-1]/config/name"),
+			Val:  stringVal("VRF-1"),
+		}},
+		Replace: []*gpb.Update{{
+			Path: mustPath(t, "/network-instances/network-instance[name=default]"),
+			Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{
+				JsonIetfVal: []byte(`{"openconfig-network-instance:name":"default","openconfig-network-instance:config":{"name":"default","description":"default"}}`),
+			}},
+		}},
+	}
+	if _, err := c.Set(context.Background(), req); err != nil {
+		t.Fatalf("Set() got unexpected error: %v", err)
+	}
+
+	want := &gpb.SetRequest{
+		Delete: []*gpb.Path{mustPath(t, "/network-instances/network-instance[name=DEFAULT]/protocols")},
+		Update: []*gpb.Update{{
+			Path: mustPath(t, "/network-instances/network-instance[name=DEFAULT]/config/name"),
+			Val:  stringVal("DEFAULT"),
+		}, {
+			Path: mustPath(t, "/network-instances/network-instance[name=VRF-1]/config/name"),
+			Val:  stringVal("VRF-1"),
+		}},
+	}
+	gotReplace := fake.gotSet.GetReplace()
+	fake.gotSet.Replace = nil
+	if diff := cmp.Diff(want, fake.gotSet, protocmp.Transform()); diff != "" {
+		t.Errorf("Set() sent unexpected request diff (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(mustPath(t, "/network-instances/network-instance[name=DEFAULT]"), gotReplace[0].GetPath(), protocmp.Transform()); diff != "" {
+		t.Errorf("Set() sent unexpected replace path diff (-want, +got): %s", diff)
+	}
+	var gotJSON, wantJSON any
+	if err := json.Unmarshal(gotReplace[0].GetVal().GetJsonIetfVal(), &gotJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"openconfig-network-instance:name":"DEFAULT","openconfig-network-instance:config":{"name":"DEFAULT","description":"default"}}`), &wantJSON); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+		t.Errorf("Set() sent unexpected JSON diff (-want, +got): %s", diff)
+	}
+	if got := req.GetDelete()[0].GetElem()[1].GetKey()["name"]; got != "default" {
+		t.Errorf("Set() modified the caller's request: got key %q, want %q", got, "default")
+	}
+}
+
+func TestRewriteGNMIGet(t *testing.T) {
+	fake := &fakeGNMI{
+		getResp: &gpb.GetResponse{
+			Notification: []*gpb.Notification{{
+				Prefix: mustPath(t, "/network-instances/network-instance[name=DEFAULT]"),
+				Update: []*gpb.Update{{
+					Path: mustPath(t, "/state/name"),
+					Val:  stringVal("DEFAULT"),
+				}},
+			}},
+		},
+	}
+	c := aristaRegistry(t).RewriteGNMI(fake)
+
+	req := &gpb.GetRequest{
+		Path: []*gpb.Path{mustPath(t, "/network-instances/network-instance[name=default]/state/name")},
+	}
+	resp, err := c.Get(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Get() got unexpected error: %v", err)
+	}
+	wantReq := &gpb.GetRequest{
+		Path: []*gpb.Path{mustPath(t, "/network-instances/network-instance[name=DEFAULT]/state/name")},
+	}
+	if diff := cmp.Diff(wantReq, fake.gotGet, protocmp.Transform()); diff != "" {
+		t.Errorf("Get() sent unexpected request diff (-want, +got): %s", diff)
+	}
+	wantResp := &gpb.GetResponse{
+		Notification: []*gpb.Notification{{
+			Prefix: mustPath(t, "/network-instances/network-instance[name=default]"),
+			Update: []*gpb.Update{{
+				Path: mustPath(t, "/state/name"),
+				Val:  stringVal("default"),
+			}},
+		}},
+	}
+	if diff := cmp.Diff(wantResp, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("Get() got unexpected response diff (-want, +got): %s", diff)
+	}
+}
+
+func TestRewriteGNMINoValueDeviations(t *testing.T) {
+	r, err := newRegistry(mustParseRegistry(t, testRegistry), opb.Device_CISCO, "8808", "24.1")
+	if err != nil {
+		t.Fatalf("newRegistry() got unexpected error: %v", err)
+	}
+	fake := &fakeGNMI{}
+	if got := r.RewriteGNMI(fake); got != fake {
+		t.Errorf("RewriteGNMI() got %T, want the client unchanged", got)
+	}
+}
+
+func TestLeafSchemaPaths(t *testing.T) {
+	tests := []struct {
+		elems []string
+		want  []string
+	}{{
+		elems: []string{"network-instances", "network-instance", "name"},
+		want: []string{
+			"/network-instances/network-instance/name",
+			"/network-instances/network-instance/config/name",
+			"/network-instances/network-instance/state/name",
+		},
+	}, {
+		elems: []string{"network-instances", "network-instance", "state", "name"},
+		want: []string{
+			"/network-instances/network-instance/state/name",
+			"/network-instances/network-instance/config/name",
+		},
+	}, {
+		elems: []string{"system"},
+		want:  []string{"/system"},
+	}}
+	for _, tc := range tests {
+		if diff := cmp.Diff(tc.want, leafSchemaPaths(tc.elems)); diff != "" {
+			t.Errorf("leafSchemaPaths(%v) got unexpected diff (-want, +got): %s", tc.elems, diff)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var strictCompliance = flag.Bool("strict_compliance", false, "Force every deviation to its OpenConfig compliant default, ignoring metadata.textproto and deviation flags, so that a passing test is Tier 1 compliant.")
+
+// Compliance tiers reported for a test.  See the package documentation.
+const (
+	tier1    = "1"
+	tier2    = "2"
+	tierNone = "none"
+)
+
+// trackedTest is a test that opted in to deviation tracking with Track.
+type trackedTest struct {
+	name string
+	// deviated are the accessors that read a non-default deviation while the
+	// test was running, whether or not the deviation was applied.
+	deviated map[string]bool
+}
+
+var (
+	// tracked is the stack of tests being tracked, guarded by exercisedMu.
+	// The innermost subtest is last.
+	tracked []*trackedTest
+	// verdicts maps the names of tracked tests that have finished to their
+	// compliance tier, guarded by exercisedMu.
+	verdicts = map[string]string{}
+	// deviatedTests maps the names of tracked tests that have finished to
+	// the accessors that read a non-default deviation, guarded by exercisedMu.
+	deviatedTests = map[string][]string{}
+)
+
+// markTracked records that the innermost tracked test read a non-default
+// deviation through accessor.  The caller must hold exercisedMu.
+func markTracked(accessor string) {
+	if len(tracked) == 0 {
+		return
+	}
+	tracked[len(tracked)-1].deviated[accessor] = true
+}
+
+// Track attributes the deviations looked up while t is running to t, and
+// reports a compliance tier for t when it finishes:
+//
+//   - Tier 1 if t passed without applying any deviation, or passed under
+//     -strict_compliance.
+//   - Tier 2 if t passed with a deviation applied.
+//   - "none" if t failed.
+//
+// Under -strict_compliance, t is also marked with the deviated branches it
+// would have taken without the flag.  Track may be called from a top-level
+// test or from a subtest; lookups are attributed to the innermost tracked
+// test, since an accessor is not given the test it is called from.  A lookup
+// from a parallel test is therefore attributed to whichever tracked test
+// started last.
+func Track(t testing.TB) {
+	tt := &trackedTest{name: t.Name(), deviated: map[string]bool{}}
+	exercisedMu.Lock()
+	tracked = append(tracked, tt)
+	exercisedMu.Unlock()
+
+	t.Cleanup(func() {
+		exercisedMu.Lock()
+		defer exercisedMu.Unlock()
+		for i := len(tracked) - 1; i >= 0; i-- {
+			if tracked[i] == tt {
+				tracked = append(tracked[:i], tracked[i+1:]...)
+				break
+			}
+		}
+
+		var accessors []string
+		for accessor := range tt.deviated {
+			accessors = append(accessors, accessor)
+		}
+		sort.Strings(accessors)
+		if len(accessors) > 0 {
+			deviatedTests[tt.name] = accessors
+			if *strictCompliance {
+				t.Logf("Under -strict_compliance, %s would have taken deviated branches: %s", tt.name, strings.Join(accessors, ", "))
+			}
+		}
+
+		switch {
+		case t.Failed():
+			verdicts[tt.name] = tierNone
+		case len(accessors) > 0 && !*strictCompliance:
+			verdicts[tt.name] = tier2
+		default:
+			verdicts[tt.name] = tier1
+		}
+	})
+}
+
+// ComplianceProperties returns the test properties describing the compliance
+// tier of the test run, given the exit code of the tests:
+//
+//   - compliance.strict - true if the tests ran with -strict_compliance.
+//   - compliance.tier - the compliance tier of the whole test run.
+//   - compliance.tier.<test> - the compliance tier of each test that called
+//     Track.
+//   - compliance.deviated.<test> - a comma separated list of the accessors
+//     that read a non-default deviation while a tracked test was running.
+//     Under -strict_compliance these are the deviated branches that the test
+//     would have taken.
+func ComplianceProperties(exitCode int) map[string]string {
+	exercisedMu.Lock()
+	defer exercisedMu.Unlock()
+
+	m := map[string]string{
+		"compliance.strict": fmt.Sprint(*strictCompliance),
+	}
+	applied := false
+	for _, accessors := range exercised {
+		for _, e := range accessors {
+			applied = applied || e.applied
+		}
+	}
+	switch {
+	case exitCode != 0:
+		m["compliance.tier"] = tierNone
+	case applied && !*strictCompliance:
+		m["compliance.tier"] = tier2
+	default:
+		m["compliance.tier"] = tier1
+	}
+	for name, tier := range verdicts {
+		m["compliance.tier."+name] = tier
+	}
+	for name, accessors := range deviatedTests {
+		m["compliance.deviated."+name] = strings.Join(accessors, ",")
+	}
+	return m
+}
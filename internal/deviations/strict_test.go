@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+)
+
+func resetExercised() {
+	exercised = map[string]map[string]exercise{}
+	tracked = nil
+	verdicts = map[string]string{}
+	deviatedTests = map[string][]string{}
+}
+
+func TestCompliance(t *testing.T) {
+	devs := &mpb.Metadata_Deviations{OmitL2Mtu: true}
+
+	tests := []struct {
+		desc     string
+		strict   bool
+		exitCode int
+		wantOmit bool
+		want     map[string]string
+	}{{
+		desc:     "deviated",
+		wantOmit: true,
+		want: map[string]string{
+			"compliance.strict": "false",
+			"compliance.tier":   "2",
+			"compliance.tier.TestCompliance/deviated/clean":    "1",
+			"compliance.tier.TestCompliance/deviated/omit":     "2",
+			"compliance.deviated.TestCompliance/deviated/omit": "omitL2Mtu",
+		},
+	}, {
+		desc:     "failed",
+		exitCode: 1,
+		wantOmit: true,
+		want: map[string]string{
+			"compliance.strict": "false",
+			"compliance.tier":   "none",
+			"compliance.tier.TestCompliance/failed/clean":    "1",
+			"compliance.tier.TestCompliance/failed/omit":     "2",
+			"compliance.deviated.TestCompliance/failed/omit": "omitL2Mtu",
+		},
+	}, {
+		desc:   "strict",
+		strict: true,
+		want: map[string]string{
+			"compliance.strict": "true",
+			"compliance.tier":   "1",
+			"compliance.tier.TestCompliance/strict/clean":    "1",
+			"compliance.tier.TestCompliance/strict/omit":     "1",
+			"compliance.deviated.TestCompliance/strict/omit": "omitL2Mtu",
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			resetExercised()
+			defer resetExercised()
+			*strictCompliance = tc.strict
+			defer func() { *strictCompliance = false }()
+
+			t.Run("clean", func(t *testing.T) {
+				Track(t)
+				defaultNetworkInstance("dut", devs)
+			})
+			t.Run("omit", func(t *testing.T) {
+				Track(t)
+				if got := omitL2Mtu("dut", devs); got != tc.wantOmit {
+					t.Errorf("omitL2Mtu() got %v, want %v", got, tc.wantOmit)
+				}
+			})
+
+			if diff := cmp.Diff(tc.want, ComplianceProperties(tc.exitCode)); diff != "" {
+				t.Errorf("ComplianceProperties() -want,+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStrictExercisedProperties(t *testing.T) {
+	resetExercised()
+	defer resetExercised()
+	*strictCompliance = true
+	defer func() { *strictCompliance = false }()
+
+	omitL2Mtu("dut", &mpb.Metadata_Deviations{OmitL2Mtu: true})
+
+	want := map[string]string{
+		"deviations.exercised.dut": "omitL2Mtu=false",
+		"deviations.applied":       "",
+		"deviations.clean":         "true",
+		"deviations.suppressed":    "omitL2Mtu",
+	}
+	if diff := cmp.Diff(want, ExercisedProperties()); diff != "" {
+		t.Errorf("ExercisedProperties() -want,+got:\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakedevice provides in-process fake devices, so that tests and
+// their helpers can run without hardware.
+//
+// A fake DUT serves gNMI from an in-memory OpenConfig datastore that
+// reflects the config to the state, gRIBI from the gribigo reference
+// server, and the gNOI System and File services.  A fake ATE serves a stub
+// OTG API that accepts any config but does not generate traffic.  The
+// devices listen on in-memory connections, not on the network.
+package fakedevice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi/otg"
+	"github.com/openconfig/gribigo/server"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	fpb "github.com/openconfig/gnoi/file"
+	spb "github.com/openconfig/gnoi/system"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+const bufSize = 1 << 20
+
+// Device is a fake device serving gRPC services in-process.
+type Device struct {
+	name string
+	lis  *bufconn.Listener
+	srv  *grpc.Server
+	gnmi *gnmiServer
+}
+
+// NewDUT starts a fake DUT with the given interfaces, which are up.
+func NewDUT(name string, ports []string) (*Device, error) {
+	root := &oc.Root{}
+	root.GetOrCreateSystem().Hostname = ygot.String(name)
+	root.GetOrCreateSystem().BootTime = ygot.Uint64(uint64(time.Now().UnixNano()))
+	root.GetOrCreateNetworkInstance(server.DefaultNetworkInstanceName).Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_DEFAULT_INSTANCE
+	for _, p := range ports {
+		intf := root.GetOrCreateInterface(p)
+		intf.AdminStatus = oc.Interface_AdminStatus_UP
+		intf.OperStatus = oc.Interface_OperStatus_UP
+	}
+
+	gribi, err := server.New()
+	if err != nil {
+		return nil, fmt.Errorf("cannot create gRIBI server of %s: %w", name, err)
+	}
+	d := newDevice(name)
+	d.gnmi = newGNMIServer(root)
+	gpb.RegisterGNMIServer(d.srv, d.gnmi)
+	grpb.RegisterGRIBIServer(d.srv, &gribiServer{s: gribi})
+	spb.RegisterSystemServer(d.srv, &systemServer{gnmi: d.gnmi})
+	fpb.RegisterFileServer(d.srv, &fileServer{})
+	d.serve()
+	return d, nil
+}
+
+// NewATE starts a fake ATE serving the OTG API.
+func NewATE(name string) *Device {
+	d := newDevice(name)
+	otg.RegisterOpenapiServer(d.srv, &otgServer{})
+	d.serve()
+	return d
+}
+
+func newDevice(name string) *Device {
+	return &Device{
+		name: name,
+		lis:  bufconn.Listen(bufSize),
+		srv:  grpc.NewServer(),
+	}
+}
+
+func (d *Device) serve() {
+	go d.srv.Serve(d.lis)
+}
+
+// Name returns the name of the device.
+func (d *Device) Name() string {
+	return d.name
+}
+
+// Dial returns a connection to the services of the device.
+func (d *Device) Dial(_ context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return d.lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	return grpc.NewClient("passthrough:///"+d.name, opts...)
+}
+
+// Datastore returns a copy of the OpenConfig datastore of a DUT.
+func (d *Device) Datastore() (*oc.Root, error) {
+	if d.gnmi == nil {
+		return nil, fmt.Errorf("%s has no OpenConfig datastore", d.name)
+	}
+	return d.gnmi.Root()
+}
+
+// UpdateDatastore changes the OpenConfig datastore of a DUT with fn, e.g.
+// to set state that the fake does not derive from the config, such as
+// counters.
+func (d *Device) UpdateDatastore(fn func(root *oc.Root) error) error {
+	if d.gnmi == nil {
+		return fmt.Errorf("%s has no OpenConfig datastore", d.name)
+	}
+	return d.gnmi.update(fn)
+}
+
+// Close stops the device.
+func (d *Device) Close() {
+	d.srv.Stop()
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakedevice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/ocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+func newDUT(t *testing.T) (*Device, *ygnmi.Client) {
+	t.Helper()
+	d, err := NewDUT("dut", []string{"port1", "port2"})
+	if err != nil {
+		t.Fatalf("NewDUT() got unexpected error: %v", err)
+	}
+	t.Cleanup(d.Close)
+	conn, err := d.Dial(context.Background())
+	if err != nil {
+		t.Fatalf("Dial() got unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	c, err := ygnmi.NewClient(gpb.NewGNMIClient(conn), ygnmi.WithTarget("dut"))
+	if err != nil {
+		t.Fatalf("ygnmi.NewClient() got unexpected error: %v", err)
+	}
+	return d, c
+}
+
+func TestGNMI(t *testing.T) {
+	ctx := context.Background()
+	_, c := newDUT(t)
+	intf := ocpath.Root().Interface("port1")
+
+	if _, err := ygnmi.Replace(ctx, c, intf.Config(), &oc.Interface{
+		Name:        ygot.String("port1"),
+		Description: ygot.String("uplink"),
+		Mtu:         ygot.Uint16(9000),
+	}); err != nil {
+		t.Fatalf("Replace() got unexpected error: %v", err)
+	}
+
+	// The config is reflected to the state.
+	mtu, err := ygnmi.Get(ctx, c, intf.Mtu().State())
+	if err != nil {
+		t.Fatalf("Get(Mtu().State()) got unexpected error: %v", err)
+	}
+	if mtu != 9000 {
+		t.Errorf("Get(Mtu().State()) got %v, want 9000", mtu)
+	}
+	config, err := ygnmi.Get(ctx, c, intf.Config())
+	if err != nil {
+		t.Fatalf("Get(Config()) got unexpected error: %v", err)
+	}
+	if got, want := config.GetDescription(), "uplink"; got != want {
+		t.Errorf("Get(Config()) got description %q, want %q", got, want)
+	}
+	// Replacing the interface removed its state.
+	if got := config.GetOperStatus(); got != oc.Interface_OperStatus_UNSET {
+		t.Errorf("Get(Config()) got oper status %v, want unset", got)
+	}
+
+	states, err := ygnmi.GetAll(ctx, c, ocpath.Root().InterfaceAny().State())
+	if err != nil {
+		t.Fatalf("GetAll(InterfaceAny().State()) got unexpected error: %v", err)
+	}
+	var names []string
+	for _, s := range states {
+		names = append(names, s.GetName())
+	}
+	if diff := cmp.Diff([]string{"port1", "port2"}, names); diff != "" {
+		t.Errorf("GetAll(InterfaceAny().State()) names -want,+got:\n%s", diff)
+	}
+
+	if _, err := ygnmi.Delete(ctx, c, intf.Mtu().Config()); err != nil {
+		t.Fatalf("Delete(Mtu().Config()) got unexpected error: %v", err)
+	}
+	if v, err := ygnmi.Lookup(ctx, c, intf.Mtu().State()); err != nil || v.IsPresent() {
+		t.Errorf("Lookup(Mtu().State()) after Delete got %v, %v, want not present", v, err)
+	}
+
+	root, err := ygnmi.Get(ctx, c, ocpath.Root().Config())
+	if err != nil {
+		t.Fatalf("Get(Root().Config()) got unexpected error: %v", err)
+	}
+	if got, want := root.GetSystem().GetHostname(), "dut"; got != want {
+		t.Errorf("Get(Root().Config()) got hostname %q, want %q", got, want)
+	}
+}
+
+func TestGNMIWatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, c := newDUT(t)
+	desc := ocpath.Root().Interface("port2").Description()
+
+	w := ygnmi.Watch(ctx, c, desc.State(), func(v *ygnmi.Value[string]) error {
+		if got, ok := v.Val(); ok && got == "changed" {
+			return nil
+		}
+		return ygnmi.Continue
+	})
+	if _, err := ygnmi.Update(ctx, c, desc.Config(), "changed"); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	if _, err := w.Await(); err != nil {
+		t.Errorf("Await() got unexpected error: %v", err)
+	}
+}
+
+func TestUpdateDatastore(t *testing.T) {
+	ctx := context.Background()
+	d, c := newDUT(t)
+	err := d.UpdateDatastore(func(root *oc.Root) error {
+		root.GetOrCreateInterface("port1").GetOrCreateCounters().InPkts = ygot.Uint64(42)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateDatastore() got unexpected error: %v", err)
+	}
+	got, err := ygnmi.Get(ctx, c, ocpath.Root().Interface("port1").Counters().InPkts().State())
+	if err != nil {
+		t.Fatalf("Get(InPkts().State()) got unexpected error: %v", err)
+	}
+	if got != 42 {
+		t.Errorf("Get(InPkts().State()) got %d, want 42", got)
+	}
+	ate := NewATE("ate")
+	defer ate.Close()
+	if _, err := ate.Datastore(); err == nil {
+		t.Errorf("Datastore() of an ATE unexpectedly succeeded")
+	}
+}
+
+func TestGRIBIAndGNOI(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newDUT(t)
+	conn, err := d.Dial(ctx)
+	if err != nil {
+		t.Fatalf("Dial() got unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := grpb.NewGRIBIClient(conn).Get(ctx, &grpb.GetRequest{
+		NetworkInstance: &grpb.GetRequest_All{All: &grpb.Empty{}},
+		Aft:             grpb.AFTType_ALL,
+	})
+	if err != nil {
+		t.Fatalf("gRIBI Get() got unexpected error: %v", err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("gRIBI Get() of an empty RIB got %v, want EOF", err)
+	}
+
+	before, err := d.Datastore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := spb.NewSystemClient(conn).Reboot(ctx, &spb.RebootRequest{}); err != nil {
+		t.Fatalf("Reboot() got unexpected error: %v", err)
+	}
+	after, err := d.Datastore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.GetSystem().GetBootTime() <= before.GetSystem().GetBootTime() {
+		t.Errorf("Reboot() did not update the boot time: before %d, after %d", before.GetSystem().GetBootTime(), after.GetSystem().GetBootTime())
+	}
+}
+
+func TestOTG(t *testing.T) {
+	ate := NewATE("ate")
+	defer ate.Close()
+	conn, err := ate.Dial(context.Background())
+	if err != nil {
+		t.Fatalf("Dial() got unexpected error: %v", err)
+	}
+	defer conn.Close()
+	api := gosnappi.NewApi()
+	api.NewGrpcTransport().SetClientConnection(conn)
+
+	config := gosnappi.NewConfig()
+	config.Ports().Add().SetName("port1")
+	if _, err := api.SetConfig(config); err != nil {
+		t.Fatalf("SetConfig() got unexpected error: %v", err)
+	}
+	got, err := api.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() got unexpected error: %v", err)
+	}
+	if n := len(got.Ports().Items()); n != 1 {
+		t.Errorf("GetConfig() got %d ports, want 1", n)
+	}
+
+	req := gosnappi.NewMetricsRequest()
+	req.Flow()
+	metrics, err := api.GetMetrics(req)
+	if err != nil {
+		t.Fatalf("GetMetrics() got unexpected error: %v", err)
+	}
+	if got, want := metrics.Choice(), gosnappi.MetricsResponseChoice.FLOW_METRICS; got != want {
+		t.Errorf("GetMetrics() got choice %v, want %v", got, want)
+	}
+}
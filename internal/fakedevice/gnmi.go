@@ -0,0 +1,393 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakedevice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// gnmiServer is a gNMI server backed by an in-memory OpenConfig datastore.
+// The ondatra oc structs store the config and the state of a leaf in the
+// same field, so the config set on the device is reflected to its state.
+type gnmiServer struct {
+	gpb.UnimplementedGNMIServer
+
+	mu   sync.RWMutex
+	root *oc.Root
+	// changed is closed and replaced after every Set, to wake up the
+	// streaming subscriptions.
+	changed chan struct{}
+}
+
+func newGNMIServer(root *oc.Root) *gnmiServer {
+	return &gnmiServer{root: root, changed: make(chan struct{})}
+}
+
+// Root returns a copy of the datastore.
+func (s *gnmiServer) Root() (*oc.Root, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, err := ygot.DeepCopy(s.root)
+	if err != nil {
+		return nil, err
+	}
+	return c.(*oc.Root), nil
+}
+
+// update changes the datastore with fn, and wakes up the subscriptions.
+func (s *gnmiServer) update(fn func(root *oc.Root) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := ygot.DeepCopy(s.root)
+	if err != nil {
+		return err
+	}
+	root := c.(*oc.Root)
+	if err := fn(root); err != nil {
+		return err
+	}
+	s.root = root
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return nil
+}
+
+func (s *gnmiServer) Capabilities(context.Context, *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	return &gpb.CapabilityResponse{
+		SupportedEncodings: []gpb.Encoding{gpb.Encoding_JSON_IETF, gpb.Encoding_PROTO},
+		GNMIVersion:        "0.10.0",
+	}, nil
+}
+
+func (s *gnmiServer) Get(_ context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var notifs []*gpb.Notification
+	for _, p := range req.GetPath() {
+		n, err := s.notification(req.GetPrefix(), p)
+		if err != nil {
+			return nil, err
+		}
+		notifs = append(notifs, n)
+	}
+	return &gpb.GetResponse{Notification: notifs}, nil
+}
+
+func (s *gnmiServer) Set(_ context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	var results []*gpb.UpdateResult
+	err := s.update(func(root *oc.Root) error {
+		for _, p := range req.GetDelete() {
+			path, err := fullPath(req.GetPrefix(), p)
+			if err != nil {
+				return err
+			}
+			if err := deleteNode(root, path); err != nil {
+				return err
+			}
+			results = append(results, &gpb.UpdateResult{Path: p, Op: gpb.UpdateResult_DELETE})
+		}
+		for _, u := range req.GetReplace() {
+			path, err := fullPath(req.GetPrefix(), u.GetPath())
+			if err != nil {
+				return err
+			}
+			if err := deleteNode(root, path); err != nil {
+				return err
+			}
+			if err := setNode(root, path, u.GetVal()); err != nil {
+				return err
+			}
+			results = append(results, &gpb.UpdateResult{Path: u.GetPath(), Op: gpb.UpdateResult_REPLACE})
+		}
+		for _, u := range req.GetUpdate() {
+			path, err := fullPath(req.GetPrefix(), u.GetPath())
+			if err != nil {
+				return err
+			}
+			if err := setNode(root, path, u.GetVal()); err != nil {
+				return err
+			}
+			results = append(results, &gpb.UpdateResult{Path: u.GetPath(), Op: gpb.UpdateResult_UPDATE})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gpb.SetResponse{
+		Prefix:    req.GetPrefix(),
+		Response:  results,
+		Timestamp: time.Now().UnixNano(),
+	}, nil
+}
+
+func (s *gnmiServer) Subscribe(stream gpb.GNMI_SubscribeServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	list := req.GetSubscribe()
+	if list == nil {
+		return status.Errorf(codes.InvalidArgument, "first SubscribeRequest should be a SubscriptionList: %v", req)
+	}
+	if err := s.sendAll(stream, list); err != nil {
+		return err
+	}
+
+	switch list.GetMode() {
+	case gpb.SubscriptionList_ONCE:
+		return nil
+	case gpb.SubscriptionList_POLL:
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if req.GetPoll() == nil {
+				return status.Errorf(codes.InvalidArgument, "expected a Poll request: %v", req)
+			}
+			if err := s.sendAll(stream, list); err != nil {
+				return err
+			}
+		}
+	default:
+		// Every change sends the current values of all the subscribed paths,
+		// which ygnmi watchers treat as updates.
+		for {
+			s.mu.RLock()
+			changed := s.changed
+			s.mu.RUnlock()
+			select {
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			case <-changed:
+			}
+			if err := s.sendAll(stream, list); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendAll sends the values of all the subscribed paths, followed by a sync
+// response.
+func (s *gnmiServer) sendAll(stream gpb.GNMI_SubscribeServer, list *gpb.SubscriptionList) error {
+	var notifs []*gpb.Notification
+	s.mu.RLock()
+	for _, sub := range list.GetSubscription() {
+		n, err := s.notification(list.GetPrefix(), sub.GetPath())
+		if err != nil {
+			s.mu.RUnlock()
+			return err
+		}
+		if len(n.GetUpdate()) > 0 {
+			notifs = append(notifs, n)
+		}
+	}
+	s.mu.RUnlock()
+	for _, n := range notifs {
+		if err := stream.Send(&gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_Update{Update: n}}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true}})
+}
+
+// notification returns the values of the nodes matching a path, which may
+// contain wildcards.  Containers and lists are encoded as JSON that has both
+// their config and their state, like the JSON_IETF values of a device.
+func (s *gnmiServer) notification(prefix, p *gpb.Path) (*gpb.Notification, error) {
+	path, err := fullPath(prefix, p)
+	if err != nil {
+		return nil, err
+	}
+	n := &gpb.Notification{
+		Timestamp: time.Now().UnixNano(),
+		Prefix:    &gpb.Path{Origin: prefix.GetOrigin(), Target: prefix.GetTarget()},
+	}
+	nodes, err := getNodes(s.root, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		val, err := encode(node)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot encode %v: %v", node.Path, err)
+		}
+		if val != nil {
+			n.Update = append(n.Update, &gpb.Update{Path: node.Path, Val: val})
+		}
+	}
+	return n, nil
+}
+
+// fullPath joins the prefix and the path, and checks that the path is an
+// OpenConfig path.
+func fullPath(prefix, p *gpb.Path) (*gpb.Path, error) {
+	origin := p.GetOrigin()
+	if origin == "" {
+		origin = prefix.GetOrigin()
+	}
+	if origin != "" && origin != "openconfig" {
+		return nil, status.Errorf(codes.Unimplemented, "origin %q is not supported", origin)
+	}
+	var elems []*gpb.PathElem
+	elems = append(elems, prefix.GetElem()...)
+	elems = append(elems, p.GetElem()...)
+	return &gpb.Path{Elem: elems}, nil
+}
+
+// usesConfig reports whether the path goes through a config container, in
+// which case it matches the shadow paths of the oc structs.
+func usesConfig(path *gpb.Path) bool {
+	for _, e := range path.GetElem() {
+		if e.GetName() == "config" {
+			return true
+		}
+	}
+	return false
+}
+
+func getNodes(root *oc.Root, path *gpb.Path) ([]*ytypes.TreeNode, error) {
+	schema := oc.SchemaTree["Root"]
+	if len(path.GetElem()) == 0 {
+		return []*ytypes.TreeNode{{Schema: schema, Data: root, Path: path}}, nil
+	}
+	opts := []ytypes.GetNodeOpt{&ytypes.GetHandleWildcards{}}
+	if usesConfig(path) {
+		opts = append(opts, &ytypes.PreferShadowPath{})
+	}
+	nodes, err := ytypes.GetNode(schema, root, path, opts...)
+	switch status.Code(err) {
+	case codes.OK:
+		return nodes, nil
+	case codes.NotFound:
+		return nil, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid path %v: %v", path, err)
+	}
+}
+
+// encode encodes the value of a node.  It returns nil for empty containers.
+func encode(node *ytypes.TreeNode) (*gpb.TypedValue, error) {
+	if util.IsValueNil(node.Data) {
+		return nil, nil
+	}
+	if node.Schema.IsLeaf() || node.Schema.IsLeafList() {
+		return ygot.EncodeTypedValue(node.Data, gpb.Encoding_JSON_IETF)
+	}
+	gs, ok := node.Data.(ygot.GoStruct)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type %T", node.Data)
+	}
+	state, err := ygot.ConstructIETFJSON(gs, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, err
+	}
+	config, err := ygot.ConstructIETFJSON(gs, &ygot.RFC7951JSONConfig{AppendModuleName: true, PreferShadowPath: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(state) == 0 && len(config) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(mergeJSON(state, config))
+	if err != nil {
+		return nil, err
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: b}}, nil
+}
+
+// mergeJSON merges the config JSON into the state JSON.  Both are rendered
+// from the same struct, so their lists have the same entries in the same
+// order.
+func mergeJSON(a, b any) any {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return a
+		}
+		for k, v := range bv {
+			if old, ok := av[k]; ok {
+				av[k] = mergeJSON(old, v)
+			} else {
+				av[k] = v
+			}
+		}
+		return av
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return a
+		}
+		for i := range av {
+			av[i] = mergeJSON(av[i], bv[i])
+		}
+		return av
+	default:
+		return a
+	}
+}
+
+func setNode(root *oc.Root, path *gpb.Path, val *gpb.TypedValue) error {
+	var err error
+	if len(path.GetElem()) == 0 {
+		b := val.GetJsonIetfVal()
+		if b == nil {
+			b = val.GetJsonVal()
+		}
+		if b == nil {
+			return status.Errorf(codes.InvalidArgument, "the root can only be set with a JSON value, got %v", val)
+		}
+		err = oc.Unmarshal(b, root, &ytypes.PreferShadowPath{})
+	} else {
+		err = ytypes.SetNode(oc.SchemaTree["Root"], root, path, val, &ytypes.InitMissingElements{}, &ytypes.PreferShadowPath{})
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot set %v: %v", path, err)
+	}
+	return nil
+}
+
+func deleteNode(root *oc.Root, path *gpb.Path) error {
+	if len(path.GetElem()) == 0 {
+		*root = oc.Root{}
+		return nil
+	}
+	err := ytypes.DeleteNode(oc.SchemaTree["Root"], root, path, &ytypes.PreferShadowPath{})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return status.Errorf(codes.InvalidArgument, "cannot delete %v: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakedevice
+
+import (
+	"context"
+	"time"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+
+	fpb "github.com/openconfig/gnoi/file"
+	spb "github.com/openconfig/gnoi/system"
+)
+
+// systemServer is a gNOI System server.  A reboot is immediate, and only
+// updates the boot time of the device.
+type systemServer struct {
+	spb.UnimplementedSystemServer
+	gnmi *gnmiServer
+}
+
+func (s *systemServer) Time(context.Context, *spb.TimeRequest) (*spb.TimeResponse, error) {
+	return &spb.TimeResponse{Time: uint64(time.Now().UnixNano())}, nil
+}
+
+func (s *systemServer) Reboot(context.Context, *spb.RebootRequest) (*spb.RebootResponse, error) {
+	err := s.gnmi.update(func(root *oc.Root) error {
+		root.GetOrCreateSystem().BootTime = ygot.Uint64(uint64(time.Now().UnixNano()))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &spb.RebootResponse{}, nil
+}
+
+func (s *systemServer) RebootStatus(context.Context, *spb.RebootStatusRequest) (*spb.RebootStatusResponse, error) {
+	return &spb.RebootStatusResponse{}, nil
+}
+
+// fileServer is a gNOI File server of a device without files.
+type fileServer struct {
+	fpb.UnimplementedFileServer
+}
+
+func (s *fileServer) Stat(context.Context, *fpb.StatRequest) (*fpb.StatResponse, error) {
+	return &fpb.StatResponse{}, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakedevice
+
+import (
+	"context"
+
+	"github.com/openconfig/gribigo/server"
+
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// gribiServer serves gRIBI with the gribigo reference server, which cannot
+// be registered directly because it embeds a nil UnimplementedGRIBIServer.
+type gribiServer struct {
+	grpb.UnimplementedGRIBIServer
+	s *server.Server
+}
+
+func (g *gribiServer) Modify(stream grpb.GRIBI_ModifyServer) error {
+	return g.s.Modify(stream)
+}
+
+func (g *gribiServer) Get(req *grpb.GetRequest, stream grpb.GRIBI_GetServer) error {
+	return g.s.Get(req, stream)
+}
+
+func (g *gribiServer) Flush(ctx context.Context, req *grpb.FlushRequest) (*grpb.FlushResponse, error) {
+	return g.s.Flush(ctx, req)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakedevice
+
+import (
+	"context"
+	"sync"
+
+	"github.com/open-traffic-generator/snappi/gosnappi/otg"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// otgServer is a stub OTG server.  It keeps the config, accepts every
+// control state and action, and returns empty metrics and states.  It does
+// not generate any traffic.
+type otgServer struct {
+	otg.UnimplementedOpenapiServer
+
+	mu     sync.Mutex
+	config *otg.Config
+}
+
+func (s *otgServer) SetConfig(_ context.Context, req *otg.SetConfigRequest) (*otg.SetConfigResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = proto.Clone(req.GetConfig()).(*otg.Config)
+	return &otg.SetConfigResponse{Warning: &otg.Warning{}}, nil
+}
+
+func (s *otgServer) GetConfig(context.Context, *emptypb.Empty) (*otg.GetConfigResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config := &otg.Config{}
+	if s.config != nil {
+		config = proto.Clone(s.config).(*otg.Config)
+	}
+	return &otg.GetConfigResponse{Config: config}, nil
+}
+
+func (s *otgServer) SetControlState(context.Context, *otg.SetControlStateRequest) (*otg.SetControlStateResponse, error) {
+	return &otg.SetControlStateResponse{Warning: &otg.Warning{}}, nil
+}
+
+func (s *otgServer) SetControlAction(context.Context, *otg.SetControlActionRequest) (*otg.SetControlActionResponse, error) {
+	return &otg.SetControlActionResponse{ControlActionResponse: &otg.ControlActionResponse{}}, nil
+}
+
+func (s *otgServer) GetMetrics(_ context.Context, req *otg.GetMetricsRequest) (*otg.GetMetricsResponse, error) {
+	resp := &otg.MetricsResponse{}
+	// The response choices are the request choices with a "_metrics" suffix.
+	if c := req.GetMetricsRequest().Choice; c != nil {
+		name := c.String() + "_metrics"
+		if v, ok := otg.MetricsResponse_Choice_Enum_value[name]; ok {
+			resp.Choice = otg.MetricsResponse_Choice_Enum(v).Enum()
+		}
+	}
+	return &otg.GetMetricsResponse{MetricsResponse: resp}, nil
+}
+
+func (s *otgServer) GetStates(_ context.Context, req *otg.GetStatesRequest) (*otg.GetStatesResponse, error) {
+	resp := &otg.StatesResponse{}
+	if c := req.GetStatesRequest().Choice; c != nil {
+		if v, ok := otg.StatesResponse_Choice_Enum_value[c.String()]; ok {
+			resp.Choice = otg.StatesResponse_Choice_Enum(v).Enum()
+		}
+	}
+	return &otg.GetStatesResponse{StatesResponse: resp}, nil
+}
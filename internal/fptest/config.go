@@ -9,6 +9,8 @@ import (
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
 	"github.com/openconfig/ygot/ygot"
+
+	opb "github.com/openconfig/ondatra/proto"
 )
 
 var (
@@ -38,55 +40,8 @@ func GetDeviceConfig(t testing.TB, dev gnmi.DeviceOrOpts) *oc.Root {
 	config := gnmi.Get[*oc.Root](t, dev, gnmi.OC().Config())
 	WriteQuery(t, "Untouched", gnmi.OC().Config(), config)
 
-	// load the base oc config from the device state when no oc config is loaded
-	if !*baseOCConfigIsPresent {
-		if ondatra.DUT(t, "dut").Vendor() == ondatra.CISCO {
-			intfsState := gnmi.GetAll(t, dev, gnmi.OC().InterfaceAny().State())
-			for _, intf := range intfsState {
-				ygot.PruneConfigFalse(oc.SchemaTree["Interface"], intf)
-				config.DeleteInterface(intf.GetName())
-				if intf.GetName() == "Loopback0" || intf.GetName() == "PTP0/RP1/CPU0/0" || intf.GetName() == "Null0" || intf.GetName() == "PTP0/RP0/CPU0/0" {
-					continue
-				}
-				intf.ForwardingViable = nil
-				intf.Mtu = nil
-				intf.HoldTime = nil
-				if intf.Subinterface != nil {
-					if intf.Subinterface[0].Ipv6 != nil {
-						intf.Subinterface[0].Ipv6.Autoconf = nil
-					}
-				}
-				config.AppendInterface(intf)
-			}
-			vrfsStates := gnmi.GetAll(t, dev, gnmi.OC().NetworkInstanceAny().State())
-			for _, vrf := range vrfsStates {
-				// only needed for containerOp
-				if vrf.GetName() == "**iid" {
-					continue
-				}
-				if vrf.GetName() == "DEFAULT" {
-					config.NetworkInstance = nil
-					vrf.Interface = nil
-					for _, ni := range config.NetworkInstance {
-						ni.Mpls = nil
-					}
-				}
-				ygot.PruneConfigFalse(oc.SchemaTree["NetworkInstance"], vrf)
-				vrf.Table = nil
-				vrf.RouteLimit = nil
-				vrf.Mpls = nil
-				for _, intf := range vrf.Interface {
-					intf.AssociatedAddressFamilies = nil
-				}
-				for _, protocol := range vrf.Protocol {
-					for _, routes := range protocol.Static {
-						routes.Description = nil
-					}
-				}
-				config.AppendNetworkInstance(vrf)
-			}
-		}
-	}
+	// Apply the refurbishment rules of the vendor from refurbish.textproto.
+	refurbish(t, dev, opb.Device_Vendor(ondatra.DUT(t, "dut").Vendor()), config)
 
 	if *pruneComponents {
 		for cname, component := range config.Component {
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/core"
+)
+
+// CheckCores fails t if core files are created on the DUTs while t runs, and
+// records them against the name of t in the test properties.  It should be
+// called at the beginning of each top-level test function, so that a crash in
+// a package with many tests can be tied to the test that triggered it:
+//
+//	func TestFoo(t *testing.T) {
+//	  fptest.CheckCores(t)
+//	  ...
+//	}
+//
+// The core files are still reported for the whole package at the end of the
+// tests.
+func CheckCores(t testing.TB) {
+	t.Helper()
+	tc := core.StartTest(t.Name())
+	t.Cleanup(func() {
+		if err := tc.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+}
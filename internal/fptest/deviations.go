@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// OCValueOpts returns gNMI options for the DUT whose client applies the value
+// deviations registered for the DUT, so that the test can be written against
+// OpenConfig standard values.  For example, with the default_network_instance
+// value deviation the test may use the network instance "default" even if the
+// device calls it "DEFAULT":
+//
+//	opts := fptest.OCValueOpts(t, dut)
+//	gnmi.Replace(t, opts, gnmi.OC().NetworkInstance("default").Config(), ni)
+func OCValueOpts(t testing.TB, dut *ondatra.DUTDevice) *gnmi.Opts {
+	t.Helper()
+	c := deviations.DUTRegistry(dut).RewriteGNMI(dut.RawAPIs().GNMI(t))
+	return dut.GNMIOpts().WithClient(c)
+}
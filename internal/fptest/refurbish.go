@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/confirm"
+	"github.com/openconfig/featureprofiles/internal/pathutil"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	rpb "github.com/openconfig/featureprofiles/proto/refurbish_go_proto"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// refurbishFile is the location of the config refurbishment rules relative
+// to the root of the featureprofiles repository.
+const refurbishFile = "internal/fptest/refurbish.textproto"
+
+var (
+	refurbishOnce  sync.Once
+	refurbishRules *rpb.Rules
+	refurbishErr   error
+
+	// Stub out for unit tests.
+	refurbishPathFn = defaultRefurbishPath
+)
+
+// stateList gets the entries of a list from the state of a device and
+// merges them into a config.
+type stateList struct {
+	// get returns the entries of the list in the state of the device, in an
+	// otherwise empty root.
+	get func(t testing.TB, dev gnmi.DeviceOrOpts) *oc.Root
+	// merge deletes the entries of taken from the config, or all the entries
+	// if replace, and adds the entries of kept.
+	merge func(config, taken, kept *oc.Root, replace bool)
+}
+
+// stateLists maps the paths allowed in config_from_state to their lists.
+var stateLists = map[string]stateList{
+	"/interfaces/interface": {
+		get: func(t testing.TB, dev gnmi.DeviceOrOpts) *oc.Root {
+			root := &oc.Root{}
+			for _, intf := range gnmi.GetAll(t, dev, gnmi.OC().InterfaceAny().State()) {
+				root.DeleteInterface(intf.GetName())
+				root.AppendInterface(intf)
+			}
+			return root
+		},
+		merge: func(config, taken, kept *oc.Root, replace bool) {
+			if replace {
+				config.Interface = nil
+			}
+			for name := range taken.Interface {
+				config.DeleteInterface(name)
+			}
+			for _, intf := range kept.Interface {
+				config.AppendInterface(intf)
+			}
+		},
+	},
+	"/network-instances/network-instance": {
+		get: func(t testing.TB, dev gnmi.DeviceOrOpts) *oc.Root {
+			root := &oc.Root{}
+			for _, ni := range gnmi.GetAll(t, dev, gnmi.OC().NetworkInstanceAny().State()) {
+				root.DeleteNetworkInstance(ni.GetName())
+				root.AppendNetworkInstance(ni)
+			}
+			return root
+		},
+		merge: func(config, taken, kept *oc.Root, replace bool) {
+			if replace {
+				config.NetworkInstance = nil
+			}
+			for name := range taken.NetworkInstance {
+				config.DeleteNetworkInstance(name)
+			}
+			for _, ni := range kept.NetworkInstance {
+				config.AppendNetworkInstance(ni)
+			}
+		},
+	},
+}
+
+// fromState merges the entries of a list taken from the state of a device
+// into the config, after pruning their config false leaves and applying the
+// rules of the list to them.
+func fromState(config, state *oc.Root, sl *rpb.StateList) error {
+	ygot.PruneConfigFalse(oc.SchemaTree["Root"], state)
+	c, err := ygot.DeepCopy(state)
+	if err != nil {
+		return fmt.Errorf("cannot copy the state of %s: %w", sl.GetPath(), err)
+	}
+	kept := c.(*oc.Root)
+	if err := applyRules(kept, sl.GetRules()); err != nil {
+		return err
+	}
+	stateLists[sl.GetPath()].merge(config, state, kept, sl.GetReplace())
+	return nil
+}
+
+func defaultRefurbishPath() (string, error) {
+	rootPath, err := pathutil.RootPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(rootPath, refurbishFile), nil
+}
+
+// loadRefurbishRules reads and validates the refurbishment rules.  The rules
+// are only read once per test binary.
+func loadRefurbishRules() (*rpb.Rules, error) {
+	refurbishOnce.Do(func() {
+		path, err := refurbishPathFn()
+		if err != nil {
+			refurbishErr = err
+			return
+		}
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			refurbishErr = err
+			return
+		}
+		refurbishRules, refurbishErr = parseRefurbishRules(bytes)
+	})
+	return refurbishRules, refurbishErr
+}
+
+// parseRefurbishRules unmarshals a Rules textproto and checks that it is
+// well-formed.
+func parseRefurbishRules(bytes []byte) (*rpb.Rules, error) {
+	rules := new(rpb.Rules)
+	if err := prototext.Unmarshal(bytes, rules); err != nil {
+		return nil, fmt.Errorf("unable to parse refurbishment rules: %w", err)
+	}
+	for _, vr := range rules.GetVendorRules() {
+		if vr.GetVendor() == opb.Device_VENDOR_UNSPECIFIED {
+			return nil, fmt.Errorf("vendor should be specified in refurbishment rules %v", vr)
+		}
+		all := vr.GetRules()
+		for _, sl := range vr.GetConfigFromState() {
+			if _, ok := stateLists[sl.GetPath()]; !ok {
+				return nil, fmt.Errorf("%v: unsupported config_from_state path %q", vr.GetVendor(), sl.GetPath())
+			}
+			all = append(slices.Clip(all), sl.GetRules()...)
+		}
+		for _, r := range all {
+			if _, err := ygot.StringToStructuredPath(r.GetPath()); err != nil {
+				return nil, fmt.Errorf("%v: invalid rule path %q: %w", vr.GetVendor(), r.GetPath(), err)
+			}
+			if r.GetAction() == nil {
+				return nil, fmt.Errorf("%v: rule %q should either drop or replace", vr.GetVendor(), r.GetPath())
+			}
+		}
+	}
+	return rules, nil
+}
+
+// vendorRules returns the rules of the vendor that apply, depending on
+// whether the base OpenConfig config is present on the device.
+func vendorRules(rules *rpb.Rules, vendor opb.Device_Vendor, baseOCConfigPresent bool) []*rpb.VendorRules {
+	var vrs []*rpb.VendorRules
+	for _, vr := range rules.GetVendorRules() {
+		if vr.GetVendor() != vendor {
+			continue
+		}
+		if vr.GetCondition() == rpb.VendorRules_BASE_OC_CONFIG_ABSENT && baseOCConfigPresent {
+			continue
+		}
+		vrs = append(vrs, vr)
+	}
+	return vrs
+}
+
+// refurbish applies the refurbishment rules of the vendor to the config
+// taken from a device.
+func refurbish(t testing.TB, dev gnmi.DeviceOrOpts, vendor opb.Device_Vendor, config *oc.Root) {
+	t.Helper()
+	rules, err := loadRefurbishRules()
+	if err != nil {
+		t.Fatalf("Cannot load refurbishment rules: %v", err)
+	}
+	state := func(path string) *oc.Root {
+		return stateLists[path].get(t, dev)
+	}
+	if err := applyVendorRules(config, vendorRules(rules, vendor, *baseOCConfigIsPresent), state); err != nil {
+		t.Fatalf("Cannot refurbish config: %v", err)
+	}
+}
+
+// applyVendorRules applies the rules of a vendor to the config, taking the
+// entries of the config_from_state lists from the state, by path.
+func applyVendorRules(config *oc.Root, vrs []*rpb.VendorRules, state func(path string) *oc.Root) error {
+	for _, vr := range vrs {
+		for _, sl := range vr.GetConfigFromState() {
+			if err := fromState(config, state(sl.GetPath()), sl); err != nil {
+				return err
+			}
+		}
+		if err := applyRules(config, vr.GetRules()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRules applies the rules in order to the config.
+func applyRules(config *oc.Root, rules []*rpb.Rule) error {
+	schema := oc.SchemaTree["Root"]
+	for _, r := range rules {
+		path, err := ygot.StringToStructuredPath(r.GetPath())
+		if err != nil {
+			return fmt.Errorf("invalid rule path %q: %w", r.GetPath(), err)
+		}
+		nodes, err := ytypes.GetNode(schema, config, path, &ytypes.GetHandleWildcards{}, &ytypes.GetTolerateNil{}, &ytypes.PreferShadowPath{})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot match rule path %q: %w", r.GetPath(), err)
+		}
+		for _, n := range nodes {
+			switch a := r.GetAction().(type) {
+			case *rpb.Rule_Drop:
+				if !a.Drop {
+					continue
+				}
+				err = ytypes.DeleteNode(schema, config, n.Path, &ytypes.PreferShadowPath{})
+			case *rpb.Rule_Replace:
+				err = ytypes.SetNode(schema, config, n.Path, a.Replace, &ytypes.PreferShadowPath{})
+			}
+			if err != nil {
+				return fmt.Errorf("cannot apply rule %q to %s: %w", r.GetPath(), confirm.PathLabel(n.Path), err)
+			}
+		}
+	}
+	return nil
+}
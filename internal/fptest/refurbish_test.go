@@ -0,0 +1,254 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	rpb "github.com/openconfig/featureprofiles/proto/refurbish_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+func TestParseRefurbishRules(t *testing.T) {
+	tests := []struct {
+		desc    string
+		text    string
+		wantErr string
+	}{{
+		desc: "valid",
+		text: `vendor_rules { vendor: CISCO config_from_state { path: "/interfaces/interface" rules { path: "/interfaces/interface[name=*]/config/mtu" drop: true } } }`,
+	}, {
+		desc:    "no vendor",
+		text:    `vendor_rules { rules { path: "/interfaces" drop: true } }`,
+		wantErr: "vendor should be specified",
+	}, {
+		desc:    "unsupported state list",
+		text:    `vendor_rules { vendor: ARISTA config_from_state { path: "/system" } }`,
+		wantErr: "unsupported config_from_state",
+	}, {
+		desc:    "invalid state list rule",
+		text:    `vendor_rules { vendor: CISCO config_from_state { path: "/interfaces/interface" rules { path: "/interfaces/interface[name=*]" } } }`,
+		wantErr: "should either drop or replace",
+	}, {
+		desc:    "invalid path",
+		text:    `vendor_rules { vendor: ARISTA rules { path: "/interfaces/interface[name]" drop: true } }`,
+		wantErr: "invalid rule path",
+	}, {
+		desc:    "no action",
+		text:    `vendor_rules { vendor: ARISTA rules { path: "/interfaces" } }`,
+		wantErr: "should either drop or replace",
+	}, {
+		desc:    "not a textproto",
+		text:    `vendor_rules {`,
+		wantErr: "unable to parse",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := parseRefurbishRules([]byte(tt.text))
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Errorf("parseRefurbishRules() unexpected error: %s", s)
+			}
+		})
+	}
+}
+
+func TestVendorRules(t *testing.T) {
+	always := &rpb.VendorRules{Vendor: opb.Device_JUNIPER}
+	absent := &rpb.VendorRules{Vendor: opb.Device_JUNIPER, Condition: rpb.VendorRules_BASE_OC_CONFIG_ABSENT}
+	other := &rpb.VendorRules{Vendor: opb.Device_NOKIA}
+	rules := &rpb.Rules{VendorRules: []*rpb.VendorRules{always, absent, other}}
+
+	tests := []struct {
+		desc    string
+		present bool
+		want    []*rpb.VendorRules
+	}{{
+		desc:    "base config absent",
+		present: false,
+		want:    []*rpb.VendorRules{always, absent},
+	}, {
+		desc:    "base config present",
+		present: true,
+		want:    []*rpb.VendorRules{always},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := vendorRules(rules, opb.Device_JUNIPER, tt.present)
+			if len(got) != len(tt.want) {
+				t.Fatalf("vendorRules() got %d rules, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("vendorRules()[%d] got %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// unmarshalConfig reads a config recorded as RFC7951 JSON, like the config
+// returned by gnmi.Get of gnmi.OC().Config().
+func unmarshalConfig(t *testing.T, name string) *oc.Root {
+	t.Helper()
+	bytes, err := os.ReadFile(filepath.Join("testdata", "refurbish", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := new(oc.Root)
+	if err := oc.Unmarshal(bytes, config, &ytypes.PreferShadowPath{}); err != nil {
+		t.Fatalf("Cannot unmarshal %s: %v", name, err)
+	}
+	return config
+}
+
+// configDiff returns the differences between the config leaves of two
+// configs.
+func configDiff(t *testing.T, want, got *oc.Root) string {
+	t.Helper()
+	jsonConfig := &ygot.RFC7951JSONConfig{PreferShadowPath: true}
+	wantJSON, err := ygot.ConstructIETFJSON(want, jsonConfig)
+	if err != nil {
+		t.Fatalf("Cannot convert config to JSON: %v", err)
+	}
+	gotJSON, err := ygot.ConstructIETFJSON(got, jsonConfig)
+	if err != nil {
+		t.Fatalf("Cannot convert config to JSON: %v", err)
+	}
+	return cmp.Diff(wantJSON, gotJSON)
+}
+
+// TestRefurbishRecorded applies the rules in refurbish.textproto to configs
+// and states recorded from devices.
+func TestRefurbishRecorded(t *testing.T) {
+	refurbishPathFn = func() (string, error) { return "refurbish.textproto", nil }
+	rules, err := loadRefurbishRules()
+	if err != nil {
+		t.Fatalf("loadRefurbishRules() got unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		vendor opb.Device_Vendor
+		config string
+		state  string
+		want   string
+	}{{
+		vendor: opb.Device_CISCO,
+		config: "cisco_config.json",
+		state:  "cisco_state.json",
+		want:   "cisco_refurbished.json",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.config, func(t *testing.T) {
+			config := unmarshalConfig(t, tt.config)
+			state := func(string) *oc.Root { return unmarshalConfig(t, tt.state) }
+			if err := applyVendorRules(config, vendorRules(rules, tt.vendor, false), state); err != nil {
+				t.Fatalf("applyVendorRules() got unexpected error: %v", err)
+			}
+			if diff := configDiff(t, unmarshalConfig(t, tt.want), config); diff != "" {
+				t.Errorf("Refurbished config differs from %s -want,+got:\n%s", tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestApplyRulesReplace(t *testing.T) {
+	config := &oc.Root{}
+	config.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(9000)
+	config.GetOrCreateInterface("eth1").Mtu = ygot.Uint16(1500)
+	config.GetOrCreateInterface("eth1").Description = ygot.String("uplink")
+
+	rules := []*rpb.Rule{{
+		Path:   "/interfaces/interface[name=*]/config/mtu",
+		Action: &rpb.Rule_Replace{Replace: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1514}}},
+	}, {
+		Path:   "/interfaces/interface[name=eth1]/config/description",
+		Action: &rpb.Rule_Drop{Drop: true},
+	}, {
+		Path:   "/interfaces/interface[name=eth2]",
+		Action: &rpb.Rule_Drop{Drop: true},
+	}}
+	if err := applyRules(config, rules); err != nil {
+		t.Fatalf("applyRules() got unexpected error: %v", err)
+	}
+
+	want := &oc.Root{}
+	want.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(1514)
+	want.GetOrCreateInterface("eth1").Mtu = ygot.Uint16(1514)
+	if diff := configDiff(t, want, config); diff != "" {
+		t.Errorf("applyRules() config -want,+got:\n%s", diff)
+	}
+}
+
+func TestFromState(t *testing.T) {
+	dropMTU := &rpb.Rule{Path: "/interfaces/interface[name=*]/config/mtu", Action: &rpb.Rule_Drop{Drop: true}}
+	dropLo0 := &rpb.Rule{Path: "/interfaces/interface[name=lo0]", Action: &rpb.Rule_Drop{Drop: true}}
+	tests := []struct {
+		desc string
+		sl   *rpb.StateList
+		want func() *oc.Root
+	}{{
+		desc: "merge",
+		sl:   &rpb.StateList{Path: "/interfaces/interface", Rules: []*rpb.Rule{dropMTU, dropLo0}},
+		want: func() *oc.Root {
+			want := &oc.Root{}
+			want.GetOrCreateInterface("eth0").Description = ygot.String("uplink")
+			intf := want.GetOrCreateInterface("eth1")
+			intf.Description = ygot.String("config only")
+			intf.Mtu = ygot.Uint16(1500)
+			return want
+		},
+	}, {
+		desc: "replace",
+		sl:   &rpb.StateList{Path: "/interfaces/interface", Replace: true, Rules: []*rpb.Rule{dropMTU, dropLo0}},
+		want: func() *oc.Root {
+			want := &oc.Root{}
+			want.GetOrCreateInterface("eth0").Description = ygot.String("uplink")
+			return want
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			config := &oc.Root{}
+			config.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(9000)
+			config.GetOrCreateInterface("lo0").Mtu = ygot.Uint16(1500)
+			intf := config.GetOrCreateInterface("eth1")
+			intf.Description = ygot.String("config only")
+			intf.Mtu = ygot.Uint16(1500)
+
+			state := &oc.Root{}
+			intf = state.GetOrCreateInterface("eth0")
+			intf.Description = ygot.String("uplink")
+			intf.Mtu = ygot.Uint16(9100)
+			intf.OperStatus = oc.Interface_OperStatus_UP
+			state.GetOrCreateInterface("lo0")
+
+			if err := fromState(config, state, tt.sl); err != nil {
+				t.Fatalf("fromState() got unexpected error: %v", err)
+			}
+			if diff := configDiff(t, tt.want(), config); diff != "" {
+				t.Errorf("fromState() config -want,+got:\n%s", diff)
+			}
+		})
+	}
+}
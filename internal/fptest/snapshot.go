@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/devconfig"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// SnapshotOptions are the options of SnapshotConfig.
+type SnapshotOptions struct {
+	// CLI also snapshots and restores the CLI-origin config, for devices
+	// that support getting and replacing the config with origin "cli".
+	CLI bool
+}
+
+// SnapshotConfig snapshots the config of the DUT before a test, and restores
+// it when the test finishes.  The OpenConfig config is taken with
+// GetDeviceConfig, and restored with a gNMI replace.  The config of the DUT
+// is then compared with the snapshot, and the test fails with every
+// difference if the DUT did not return to the snapshot.  It should be called
+// at the beginning of a test:
+//
+//	func TestFoo(t *testing.T) {
+//	  dut := ondatra.DUT(t, "dut")
+//	  fptest.SnapshotConfig(t, dut, fptest.SnapshotOptions{})
+//	  ...
+//	}
+func SnapshotConfig(t testing.TB, dut *ondatra.DUTDevice, opts SnapshotOptions) {
+	t.Helper()
+	var cli string
+	if opts.CLI {
+		var err error
+		if cli, err = devconfig.GetCLI(context.Background(), dut.RawAPIs().GNMI(t)); err != nil {
+			t.Fatalf("Cannot snapshot the CLI config of %s: %v", dut.Name(), err)
+		}
+	}
+	snapshot := GetDeviceConfig(t, dut)
+
+	t.Cleanup(func() {
+		t.Helper()
+		if opts.CLI {
+			if err := devconfig.ReplaceCLI(context.Background(), dut.RawAPIs().GNMI(t), cli); err != nil {
+				t.Errorf("Cannot restore the CLI config of %s: %v", dut.Name(), err)
+			}
+		}
+		gnmi.Replace(t, dut, gnmi.OC().Config(), snapshot)
+
+		got := gnmi.Get[*oc.Root](t, dut, gnmi.OC().Config())
+		WriteQuery(t, "Restored", gnmi.OC().Config(), got)
+		changes, err := devconfig.Changes(snapshot, got)
+		if err != nil {
+			t.Errorf("Cannot compare the config of %s with the snapshot: %v", dut.Name(), err)
+			return
+		}
+		for _, change := range changes {
+			t.Errorf("Config of %s did not return to the snapshot: %s", dut.Name(), devconfig.FormatChange(change))
+		}
+	})
+}
@@ -0,0 +1,361 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/constants"
+	"github.com/openconfig/gribigo/fluent"
+	"google.golang.org/grpc"
+
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// BenchmarkOptions configure a gRIBI programming benchmark.
+type BenchmarkOptions struct {
+	// Name of the benchmark, which names the report in the test outputs.
+	// Defaults to the name of the test.
+	Name string
+	// Rate is the target number of entries sent per second, or 0 to send the
+	// entries as fast as possible.
+	Rate float64
+	// BatchSize is the number of entries sent in a ModifyRequest.  Defaults
+	// to 1000.
+	BatchSize int
+	// Timeout is how long to wait for the results after the last entry is
+	// sent.  Defaults to a minute.
+	Timeout time.Duration
+}
+
+// BenchmarkReport is the outcome of a gRIBI programming benchmark.  The
+// latencies are in milliseconds, from when an entry is sent to when its
+// result is received on the gRIBI stream.
+type BenchmarkReport struct {
+	Name string `json:"name"`
+	// Entries is the number of entries sent.
+	Entries int `json:"entries"`
+	// Rate is the target rate of the benchmark, or 0 if unlimited.
+	Rate      float64 `json:"target_rate,omitempty"`
+	BatchSize int     `json:"batch_size"`
+	// SendSeconds is how long sending the entries took.
+	SendSeconds float64 `json:"send_seconds"`
+	// DurationSeconds is how long the benchmark took, including waiting for
+	// the results.
+	DurationSeconds float64 `json:"duration_seconds"`
+	// RIB are the RIB acknowledgements.
+	RIB *AckStats `json:"rib_ack"`
+	// FIB are the FIB acknowledgements, if the client requests FIB ACKs.
+	FIB *AckStats `json:"fib_ack,omitempty"`
+	// Failures counts the entries that were not acknowledged by their worst
+	// result, e.g. FAILED, FIB_FAILED or NO_RESULT.
+	Failures map[string]int `json:"failures,omitempty"`
+	// FailuresByType counts the entries that were not acknowledged by their
+	// type, e.g. next-hop or ipv4.
+	FailuresByType map[string]int `json:"failures_by_type,omitempty"`
+	// Output is the file that the report is written to, if any.
+	Output string `json:"-"`
+}
+
+// AckStats summarize one kind of acknowledgement of a benchmark.
+type AckStats struct {
+	// Acked is the number of entries acknowledged.
+	Acked int `json:"acked"`
+	// Throughput is the number of entries acknowledged per second, from
+	// when the first entry was sent to when the last one was acknowledged.
+	Throughput float64 `json:"throughput_per_second"`
+	MinMs      float64 `json:"min_ms"`
+	P50Ms      float64 `json:"p50_ms"`
+	P90Ms      float64 `json:"p90_ms"`
+	P99Ms      float64 `json:"p99_ms"`
+	MaxMs      float64 `json:"max_ms"`
+}
+
+// Benchmark adds the entries at the target rate of the options, without
+// waiting for the results of one batch before sending the next one, and
+// then waits for all the results.  It reports the latencies and throughput
+// of the RIB and FIB acknowledgements and the failures, and writes the
+// report as JSON to the test outputs with fptest.WriteOutput.  The
+// acknowledged entries are recorded in the RIB of the client.
+//
+// The entries may be expanded from a programming profile, e.g.
+//
+//	entries, err := gribi.ExpandProfile(p, deviations.DefaultNetworkInstance(dut))
+//	report := client.Benchmark(t, entries.All(), &gribi.BenchmarkOptions{Rate: 5000})
+func (c *Client) Benchmark(t testing.TB, entries []fluent.GRIBIEntry, opts *BenchmarkOptions) *BenchmarkReport {
+	t.Helper()
+	if opts == nil {
+		opts = &BenchmarkOptions{}
+	}
+	report := &BenchmarkReport{
+		Name:      orDefault(opts.Name, t.Name()),
+		Entries:   len(entries),
+		Rate:      opts.Rate,
+		BatchSize: opts.BatchSize,
+	}
+	if report.BatchSize <= 0 {
+		report.BatchSize = defaultBatchSize
+	}
+	wait := opts.Timeout
+	if wait == 0 {
+		wait = timeout
+	}
+	aftEntries := make([]*gpb.AFTEntry, 0, len(entries))
+	for _, e := range entries {
+		ep, err := e.EntryProto()
+		if err != nil {
+			t.Fatalf("Invalid gRIBI entry: %v", err)
+		}
+		aftEntries = append(aftEntries, ep)
+	}
+
+	// Batches are sent at multiples of the interval after the start, so that
+	// a slow send does not lower the rate of the following batches.
+	var interval time.Duration
+	if opts.Rate > 0 {
+		interval = time.Duration(float64(report.BatchSize) / opts.Rate * float64(time.Second))
+	}
+	prev, ids, err := c.operationIDs(t, len(entries))
+	if err != nil {
+		t.Fatalf("Error waiting for the gRIBI results before the benchmark: %v", err)
+	}
+	n := len(prev)
+	c.received.start()
+	sent := make(map[uint64]time.Time, len(ids))
+	start := time.Now()
+	for i, b := 0, 0; i < len(entries); i, b = i+report.BatchSize, b+1 {
+		if interval > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(b) * interval)))
+		}
+		end := min(i+report.BatchSize, len(entries))
+		now := time.Now()
+		for _, id := range ids[i:end] {
+			sent[id] = now
+		}
+		c.fluentC.Modify().AddEntry(t, entries[i:end]...)
+	}
+	report.SendSeconds = time.Since(start).Seconds()
+	if err := c.AwaitTimeout(context.Background(), t, wait); err != nil {
+		t.Errorf("Error waiting for the gRIBI benchmark results: %v", err)
+	}
+	received := c.received.stop()
+	report.DurationSeconds = time.Since(start).Seconds()
+	results := c.fluentC.Results(t)[n:]
+	if err := c.RIB().applyResults(constants.Add, aftEntries, ids, results); err != nil {
+		t.Fatalf("Cannot record gRIBI results: %v", err)
+	}
+
+	report.summarize(start, aftEntries, ids, sent, results, received, c.FIBACK)
+	t.Logf("gRIBI benchmark %q: %d entries, %d RIB acks at %.0f/s (p50 %.2fms, p99 %.2fms), failures %v",
+		report.Name, report.Entries, report.RIB.Acked, report.RIB.Throughput, report.RIB.P50Ms, report.RIB.P99Ms, report.Failures)
+	if report.FIB != nil {
+		t.Logf("gRIBI benchmark %q: %d FIB acks at %.0f/s (p50 %.2fms, p99 %.2fms)",
+			report.Name, report.FIB.Acked, report.FIB.Throughput, report.FIB.P50Ms, report.FIB.P99Ms)
+	}
+
+	js, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		t.Fatalf("Cannot marshal gRIBI benchmark report: %v", err)
+	}
+	report.Output, err = fptest.WriteOutput(report.Name, ".json", string(js))
+	if err != nil {
+		t.Errorf("Cannot write gRIBI benchmark report: %v", err)
+	}
+	return report
+}
+
+// resultID identifies a result of an operation.  An operation may have
+// several results, e.g. a RIB and a FIB acknowledgement.
+type resultID struct {
+	id     uint64
+	status gpb.AFTResult_Status
+}
+
+// resultTimes records when the results of a client are received.  The
+// timestamps of the results themselves cannot be used, since the gRIBI
+// client takes them from a clock that does not advance.
+type resultTimes struct {
+	mu sync.Mutex
+	// times is nil unless recording.
+	times map[resultID]time.Time
+}
+
+// start starts recording the results.
+func (r *resultTimes) start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.times = make(map[resultID]time.Time)
+}
+
+// stop stops recording and returns when each result was first received.
+func (r *resultTimes) stop() map[resultID]time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	times := r.times
+	r.times = nil
+	return times
+}
+
+// record records that the results were received at now, if recording.
+func (r *resultTimes) record(now time.Time, results []*gpb.AFTResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.times == nil {
+		return
+	}
+	for _, res := range results {
+		id := resultID{res.GetId(), res.GetStatus()}
+		if _, ok := r.times[id]; !ok {
+			r.times[id] = now
+		}
+	}
+}
+
+// timedStub is a gRIBI stub that records when the results of its Modify
+// streams are received.
+type timedStub struct {
+	gpb.GRIBIClient
+	received *resultTimes
+}
+
+func (s *timedStub) Modify(ctx context.Context, opts ...grpc.CallOption) (gpb.GRIBI_ModifyClient, error) {
+	stream, err := s.GRIBIClient.Modify(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &timedModifyClient{GRIBI_ModifyClient: stream, received: s.received}, nil
+}
+
+type timedModifyClient struct {
+	gpb.GRIBI_ModifyClient
+	received *resultTimes
+}
+
+func (s *timedModifyClient) Recv() (*gpb.ModifyResponse, error) {
+	resp, err := s.GRIBI_ModifyClient.Recv()
+	if err == nil {
+		s.received.record(time.Now(), resp.GetResult())
+	}
+	return resp, err
+}
+
+// summarize fills in the acknowledgements and failures of the report from
+// the results of the entries and when they were received.  The entries
+// were first sent at start, and each of them at the time in sent, by the
+// operation ID in ids.
+func (r *BenchmarkReport) summarize(start time.Time, entries []*gpb.AFTEntry, ids []uint64, sent map[uint64]time.Time, results []*client.OpResult, received map[resultID]time.Time, fibACK bool) {
+	var ribLatencies, fibLatencies []time.Duration
+	var ribLast, fibLast time.Time
+	worst := make(map[uint64]gpb.AFTResult_Status)
+	best := make(map[uint64]gpb.AFTResult_Status)
+	ribACKed := make(map[uint64]bool)
+	for _, res := range results {
+		if res.ProgrammingResult == gpb.AFTResult_RIB_PROGRAMMED {
+			ribACKed[res.OperationID] = true
+		}
+	}
+	for _, res := range results {
+		id := res.OperationID
+		if _, ok := sent[id]; !ok {
+			continue
+		}
+		at, ok := received[resultID{id, res.ProgrammingResult}]
+		latency := at.Sub(sent[id])
+		switch res.ProgrammingResult {
+		case gpb.AFTResult_RIB_PROGRAMMED:
+			if ok {
+				ribLatencies = append(ribLatencies, latency)
+				ribLast = at
+			}
+		case gpb.AFTResult_FIB_PROGRAMMED:
+			if ok {
+				fibLatencies = append(fibLatencies, latency)
+				fibLast = at
+				if !ribACKed[id] {
+					// A server may FIB ACK an entry without a RIB ACK.
+					ribLatencies = append(ribLatencies, latency)
+					ribLast = at
+				}
+			}
+		case gpb.AFTResult_FAILED, gpb.AFTResult_FIB_FAILED:
+			worst[id] = res.ProgrammingResult
+		}
+		if ackRank(res.ProgrammingResult) > ackRank(best[id]) {
+			best[id] = res.ProgrammingResult
+		}
+	}
+	r.RIB = newAckStats(start, ribLatencies, ribLast)
+	want := gpb.AFTResult_RIB_PROGRAMMED
+	if fibACK {
+		r.FIB = newAckStats(start, fibLatencies, fibLast)
+		want = gpb.AFTResult_FIB_PROGRAMMED
+	}
+	for i, e := range entries {
+		id := ids[i]
+		if ackRank(best[id]) >= ackRank(want) {
+			continue
+		}
+		failure := "NO_RESULT"
+		switch {
+		case worst[id] != gpb.AFTResult_UNSET:
+			failure = worst[id].String()
+		case best[id] != gpb.AFTResult_UNSET:
+			failure = "NO_FIB_ACK"
+		}
+		if r.Failures == nil {
+			r.Failures = make(map[string]int)
+			r.FailuresByType = make(map[string]int)
+		}
+		r.Failures[failure]++
+		typ, _, _ := strings.Cut(entryKey(e), " ")
+		r.FailuresByType[typ]++
+	}
+}
+
+// newAckStats returns the statistics of the latencies of acknowledgements,
+// the last of which was received at last.
+func newAckStats(start time.Time, latencies []time.Duration, last time.Time) *AckStats {
+	s := &AckStats{Acked: len(latencies)}
+	if len(latencies) == 0 {
+		return s
+	}
+	if d := last.Sub(start); d > 0 {
+		s.Throughput = float64(len(latencies)) / d.Seconds()
+	}
+	slices.Sort(latencies)
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	s.MinMs = ms(latencies[0])
+	s.P50Ms = ms(percentile(latencies, 50))
+	s.P90Ms = ms(percentile(latencies, 90))
+	s.P99Ms = ms(percentile(latencies, 99))
+	s.MaxMs = ms(latencies[len(latencies)-1])
+	return s
+}
+
+// percentile returns the nearest-rank percentile p of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
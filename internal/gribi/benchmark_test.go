@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/fluent"
+
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+func TestBenchmark(t *testing.T) {
+	c := newFakeClient(t)
+	var entries []fluent.GRIBIEntry
+	for i := uint64(1); i <= 10; i++ {
+		entries = append(entries, fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(i).WithIPAddress("192.0.2.1"))
+	}
+	opts := &BenchmarkOptions{Name: "bench", Rate: 200, BatchSize: 2}
+	report := c.Benchmark(t, entries, opts)
+
+	if got, want := report.Entries, 10; got != want {
+		t.Errorf("Benchmark() got %d entries, want %d", got, want)
+	}
+	// The last of 5 batches is sent 4 intervals of 10ms after the first.
+	if got, want := report.SendSeconds, 0.04; got < want {
+		t.Errorf("Benchmark() sent the entries in %vs, want at least %vs", got, want)
+	}
+	for name, stats := range map[string]*AckStats{"RIB": report.RIB, "FIB": report.FIB} {
+		if stats == nil || stats.Acked != 10 {
+			t.Errorf("Benchmark() got %s acks %+v, want 10 acks", name, stats)
+			continue
+		}
+		if !(stats.MinMs <= stats.P50Ms && stats.P50Ms <= stats.P99Ms && stats.P99Ms <= stats.MaxMs) {
+			t.Errorf("Benchmark() got %s latencies out of order: %+v", name, stats)
+		}
+	}
+	if len(report.Failures) != 0 {
+		t.Errorf("Benchmark() got unexpected failures: %v", report.Failures)
+	}
+	if got, want := len(c.RIB().NetworkInstance("DEFAULT").NextHops), 10; got != want {
+		t.Errorf("Benchmark() recorded %d next hops, want %d", got, want)
+	}
+}
+
+func TestBenchmarkSummarize(t *testing.T) {
+	nh := func(ni string, index uint64) *gpb.AFTEntry {
+		return entryProto(t, fluent.NextHopEntry().WithNetworkInstance(ni).WithIndex(index))
+	}
+	result := func(id uint64, status gpb.AFTResult_Status) *client.OpResult {
+		return &client.OpResult{
+			OperationID:       id,
+			ProgrammingResult: status,
+			Details:           &client.OpDetailsResults{NextHopIndex: id},
+		}
+	}
+	start := time.Unix(1, 0)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	// The last entry has the same key as the first one in another network
+	// instance, and no result.
+	entries := []*gpb.AFTEntry{nh("DEFAULT", 1), nh("DEFAULT", 2), nh("DEFAULT", 3), nh("VRF-A", 1)}
+	ids := []uint64{1, 2, 3, 4}
+	sent := map[uint64]time.Time{1: at(0), 2: at(0), 3: at(0), 4: at(time.Millisecond)}
+	results := []*client.OpResult{
+		result(1, gpb.AFTResult_RIB_PROGRAMMED),
+		result(2, gpb.AFTResult_RIB_PROGRAMMED),
+		result(3, gpb.AFTResult_FAILED),
+		result(2, gpb.AFTResult_FIB_FAILED),
+		result(1, gpb.AFTResult_FIB_PROGRAMMED),
+	}
+	received := map[resultID]time.Time{
+		{1, gpb.AFTResult_RIB_PROGRAMMED}: at(time.Millisecond),
+		{2, gpb.AFTResult_RIB_PROGRAMMED}: at(2 * time.Millisecond),
+		{3, gpb.AFTResult_FAILED}:         at(3 * time.Millisecond),
+		{2, gpb.AFTResult_FIB_FAILED}:     at(5 * time.Millisecond),
+		{1, gpb.AFTResult_FIB_PROGRAMMED}: at(500 * time.Millisecond),
+	}
+
+	r := &BenchmarkReport{}
+	r.summarize(start, entries, ids, sent, results, received, true)
+	want := &BenchmarkReport{
+		RIB:            &AckStats{Acked: 2, Throughput: 1000, MinMs: 1, P50Ms: 1, P90Ms: 2, P99Ms: 2, MaxMs: 2},
+		FIB:            &AckStats{Acked: 1, Throughput: 2, MinMs: 500, P50Ms: 500, P90Ms: 500, P99Ms: 500, MaxMs: 500},
+		Failures:       map[string]int{"FAILED": 1, "FIB_FAILED": 1, "NO_RESULT": 1},
+		FailuresByType: map[string]int{"next-hop": 3},
+	}
+	if diff := cmp.Diff(want, r); diff != "" {
+		t.Errorf("summarize() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// A server that only FIB ACKs the entries also acknowledges them in the
+	// RIB.
+	results = []*client.OpResult{
+		result(1, gpb.AFTResult_FIB_PROGRAMMED),
+		result(2, gpb.AFTResult_FIB_PROGRAMMED),
+	}
+	received = map[resultID]time.Time{
+		{1, gpb.AFTResult_FIB_PROGRAMMED}: at(2 * time.Millisecond),
+		{2, gpb.AFTResult_FIB_PROGRAMMED}: at(4 * time.Millisecond),
+	}
+	r = &BenchmarkReport{}
+	r.summarize(start, entries[:2], ids[:2], sent, results, received, true)
+	acks := &AckStats{Acked: 2, Throughput: 500, MinMs: 2, P50Ms: 2, P90Ms: 4, P99Ms: 4, MaxMs: 4}
+	if diff := cmp.Diff(&BenchmarkReport{RIB: acks, FIB: acks}, r); diff != "" {
+		t.Errorf("summarize() of FIB ACKs only got unexpected diff (-want +got):\n%s", diff)
+	}
+}
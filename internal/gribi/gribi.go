@@ -17,6 +17,8 @@
 // change clients roles easily without keep tracking of the server election id.
 // It also packs modify operations with the corresponding verifications to
 // prevent code duplications and increase the test code readability.
+// The client keeps a shadow RIB of the entries that the device acknowledged,
+// which tests can reconcile with the AFT telemetry of the device.
 package gribi
 
 import (
@@ -30,6 +32,7 @@ import (
 	"github.com/openconfig/gribigo/constants"
 	"github.com/openconfig/gribigo/fluent"
 	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
 
 	gpb "github.com/openconfig/gribi/v1/proto/service"
 )
@@ -86,6 +89,12 @@ type Client struct {
 	// Unexport fields below.
 	fluentC    *fluent.GRIBIClient
 	electionID Uint128
+	rib        *RIB
+	// opCount is the ID of the last operation sent by fluentC, which numbers
+	// the operations from 1 in the order they are sent.
+	opCount uint64
+	// received records when the results are received during a benchmark.
+	received *resultTimes
 }
 
 // Fluent resturns the fluent client that can be used to directly call the gribi fluent APIs
@@ -93,6 +102,33 @@ func (c *Client) Fluent(_ testing.TB) *fluent.GRIBIClient {
 	return c.fluentC
 }
 
+// RIB returns the shadow of the entries that the client programmed and the
+// device acknowledged, which is kept across sessions and cleared by flushes.
+func (c *Client) RIB() *RIB {
+	if c.rib == nil {
+		c.rib = NewRIB()
+	}
+	return c.rib
+}
+
+// ReconcileAFT compares the RIB of the client with the AFT telemetry of the
+// network instances, or of every network instance in the RIB if none are
+// given.  See RIB.Reconcile.
+func (c *Client) ReconcileAFT(t testing.TB, nis ...string) []*Diff {
+	t.Helper()
+	if len(nis) == 0 {
+		nis = c.RIB().NetworkInstances()
+	}
+	afts := make(map[string]*AFT)
+	for _, ni := range nis {
+		afts[ni] = newAFT()
+		if v, ok := gnmi.Lookup(t, c.DUT, gnmi.OC().NetworkInstance(ni).Afts().State()).Val(); ok {
+			afts[ni] = AFTFromOC(v)
+		}
+	}
+	return c.RIB().Reconcile(afts)
+}
+
 // NHGOptions are optional parameters to a GRIBI next-hop-group.
 type NHGOptions struct {
 	// BackupNHG specifies the backup next-hop-group to be used when all next-hops are unavailable.
@@ -115,10 +151,18 @@ type NHOptions struct {
 func (c *Client) Start(t testing.TB) error {
 	t.Helper()
 	t.Logf("Starting GRIBI connection for dut: %s", c.DUT.Name())
-	gribiC := c.DUT.RawAPIs().GRIBI(t)
+	return c.start(t, c.DUT.RawAPIs().GRIBI(t))
+}
+
+// start establishes a client connection with the gRIBI server of the stub.
+func (c *Client) start(t testing.TB, gribiC gpb.GRIBIClient) error {
+	t.Helper()
 	c.fluentC = fluent.NewClient()
 	c.electionID = Uint128{Low: 1, High: 0}
+	c.opCount = 0
+	c.received = &resultTimes{}
 
+	gribiC = &timedStub{GRIBIClient: gribiC, received: c.received}
 	conn := c.fluentC.Connection().WithStub(gribiC).WithRedundancyMode(fluent.ElectedPrimaryClient)
 	conn.WithInitialElectionID(c.electionID.Low, c.electionID.High)
 	if c.Persistence {
@@ -266,8 +310,7 @@ func NHGEntry(nhgIndex uint64, nhWeights map[uint64]uint64, instance string, exp
 // AddEntries adds the input gRIBI entries and checks the success of the input OperationResults.
 func (c *Client) AddEntries(t testing.TB, entries []fluent.GRIBIEntry, expectedResults []*client.OpResult) {
 	t.Helper()
-	c.fluentC.Modify().AddEntry(t, entries...)
-	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+	if _, _, err := c.modify(t, constants.Add, entries...); err != nil {
 		t.Fatalf("Error waiting to add entries: %v", err)
 	}
 	for _, result := range expectedResults {
@@ -281,8 +324,7 @@ func (c *Client) AddEntries(t testing.TB, entries []fluent.GRIBIEntry, expectedR
 // DeleteEntries deletes the input gRIBI entries and checks the success of the input OperationResults.
 func (c *Client) DeleteEntries(t testing.TB, entries []fluent.GRIBIEntry, expectedResults []*client.OpResult) {
 	t.Helper()
-	c.fluentC.Modify().DeleteEntry(t, entries...)
-	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+	if _, _, err := c.modify(t, constants.Delete, entries...); err != nil {
 		t.Fatalf("Error waiting to delete entries: %v", err)
 	}
 	for _, result := range expectedResults {
@@ -302,8 +344,7 @@ func (c *Client) AddIPv4(t testing.TB, prefix string, nhgIndex uint64, instance,
 	if nhgInstance != "" && nhgInstance != instance {
 		ipv4Entry.WithNextHopGroupNetworkInstance(nhgInstance)
 	}
-	c.fluentC.Modify().AddEntry(t, ipv4Entry)
-	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+	if _, _, err := c.modify(t, constants.Add, ipv4Entry); err != nil {
 		t.Fatalf("Error waiting to add IPv4: %v", err)
 	}
 	chk.HasResult(t, c.fluentC.Results(t),
@@ -325,8 +366,7 @@ func (c *Client) AddIPv6(t testing.TB, prefix string, nhgIndex uint64, instance,
 	if nhgInstance != "" && nhgInstance != instance {
 		ipv6Entry.WithNextHopGroupNetworkInstance(nhgInstance)
 	}
-	c.fluentC.Modify().AddEntry(t, ipv6Entry)
-	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+	if _, _, err := c.modify(t, constants.Add, ipv6Entry); err != nil {
 		t.Fatalf("Error waiting to add IPv6: %v", err)
 	}
 	chk.HasResult(t, c.fluentC.Results(t),
@@ -343,8 +383,7 @@ func (c *Client) AddIPv6(t testing.TB, prefix string, nhgIndex uint64, instance,
 func (c *Client) DeleteIPv4(t testing.TB, prefix string, instance string, expectedResult fluent.ProgrammingResult) {
 	t.Helper()
 	ipv4Entry := fluent.IPv4Entry().WithPrefix(prefix).WithNetworkInstance(instance)
-	c.fluentC.Modify().DeleteEntry(t, ipv4Entry)
-	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+	if _, _, err := c.modify(t, constants.Delete, ipv4Entry); err != nil {
 		t.Fatalf("Error waiting to delete IPv4: %v", err)
 	}
 	chk.HasResult(t, c.fluentC.Results(t),
@@ -361,8 +400,7 @@ func (c *Client) DeleteIPv4(t testing.TB, prefix string, instance string, expect
 func (c *Client) DeleteIPv6(t testing.TB, prefix string, instance string, expectedResult fluent.ProgrammingResult) {
 	t.Helper()
 	ipv6Entry := fluent.IPv6Entry().WithPrefix(prefix).WithNetworkInstance(instance)
-	c.fluentC.Modify().DeleteEntry(t, ipv6Entry)
-	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+	if _, _, err := c.modify(t, constants.Delete, ipv6Entry); err != nil {
 		t.Fatalf("Error waiting to delete IPv6: %v", err)
 	}
 	chk.HasResult(t, c.fluentC.Results(t),
@@ -375,19 +413,96 @@ func (c *Client) DeleteIPv6(t testing.TB, prefix string, instance string, expect
 	)
 }
 
-// FlushAll flushes all the gribi entries
+// modify adds or deletes the entries, waits for the results and records the
+// acknowledged operations in the RIB of the client.  It returns the
+// operation IDs of the entries and the results of the operations.
+func (c *Client) modify(t testing.TB, op constants.OpType, entries ...fluent.GRIBIEntry) ([]uint64, []*client.OpResult, error) {
+	t.Helper()
+	aftEntries := make([]*gpb.AFTEntry, 0, len(entries))
+	for _, e := range entries {
+		ep, err := e.EntryProto()
+		if err != nil {
+			t.Fatalf("Invalid gRIBI entry: %v", err)
+		}
+		aftEntries = append(aftEntries, ep)
+	}
+	prev, ids, err := c.operationIDs(t, len(entries))
+	if err != nil {
+		return nil, nil, err
+	}
+	switch op {
+	case constants.Delete:
+		c.fluentC.Modify().DeleteEntry(t, entries...)
+	default:
+		c.fluentC.Modify().AddEntry(t, entries...)
+	}
+	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+		return nil, nil, err
+	}
+	results := c.fluentC.Results(t)[len(prev):]
+	received := make(map[uint64]bool)
+	for _, res := range results {
+		received[res.OperationID] = true
+	}
+	for i, id := range ids {
+		if !received[id] {
+			return nil, nil, fmt.Errorf("no result for operation %d of %s, its operation ID was mispredicted", id, entryKey(aftEntries[i]))
+		}
+	}
+	if err := c.RIB().applyResults(op, aftEntries, ids, results); err != nil {
+		t.Fatalf("Cannot record gRIBI results: %v", err)
+	}
+	return ids, results, nil
+}
+
+// operationIDs waits for the results of the pending operations, including
+// those sent directly with the fluent client, and returns the results so far
+// and the IDs that fluentC assigns to the next n operations.
+func (c *Client) operationIDs(t testing.TB, n int) ([]*client.OpResult, []uint64, error) {
+	if err := c.AwaitTimeout(context.Background(), t, timeout); err != nil {
+		return nil, nil, fmt.Errorf("pending operations: %w", err)
+	}
+	results := c.fluentC.Results(t)
+	for _, res := range results {
+		c.opCount = max(c.opCount, res.OperationID)
+	}
+	ids := make([]uint64, n)
+	for i := range ids {
+		c.opCount++
+		ids[i] = c.opCount
+	}
+	return results, ids, nil
+}
+
+// FlushAll flushes all the gribi entries.  The RIB of the client is cleared
+// if the flush succeeds.
 func (c *Client) FlushAll(t testing.TB) {
-	if err := FlushAll(c.fluentC); err != nil {
+	resp, err := flushAll(c.fluentC)
+	if err != nil {
 		t.Fatal(err)
 	}
+	c.flushRIB(t, resp, "")
 }
 
-// Flush flushes gRIBI entries specific to the provided NetworkInstance end electionID
+// Flush flushes gRIBI entries specific to the provided NetworkInstance end
+// electionID.  The network instance is cleared from the RIB of the client if
+// the flush succeeds.
 func (c *Client) Flush(t testing.TB, electionID Uint128, networkInstanceName string) {
-	_, err := Flush(c.fluentC, electionID, networkInstanceName)
+	resp, err := Flush(c.fluentC, electionID, networkInstanceName)
 	if err != nil {
 		t.Fatal(err)
 	}
+	c.flushRIB(t, resp, networkInstanceName)
+}
+
+// flushRIB clears the network instance, or all of them if empty, from the
+// RIB of the client if the flush response reports success.
+func (c *Client) flushRIB(t testing.TB, resp *gpb.FlushResponse, networkInstanceName string) {
+	if result := resp.GetResult(); result != gpb.FlushResponse_OK {
+		t.Logf("gRIBI flush of %q got result %v, keeping the RIB of the client", networkInstanceName, result)
+		return
+	}
+	c.RIB().Flush(networkInstanceName)
 }
 
 // LearnElectionID learns the current server election id by sending
@@ -434,14 +549,19 @@ func Flush(client *fluent.GRIBIClient, electionID Uint128, networkInstanceName s
 
 // FlushAll flushes all the gribi entries.
 func FlushAll(c *fluent.GRIBIClient) error {
-	_, err := c.Flush().
+	_, err := flushAll(c)
+	return err
+}
+
+func flushAll(c *fluent.GRIBIClient) (*gpb.FlushResponse, error) {
+	resp, err := c.Flush().
 		WithElectionOverride().
 		WithAllNetworkInstances().
 		Send()
 	if err != nil {
-		return fmt.Errorf("could not remove all gribi entries, got error: %v", err)
+		return nil, fmt.Errorf("could not remove all gribi entries, got error: %v", err)
 	}
-	return nil
+	return resp, nil
 }
 
 func awaitTimeout(ctx context.Context, t testing.TB, c *fluent.GRIBIClient, timeout time.Duration) error {
@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/fakedevice"
+
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// newFakeStub returns a gRIBI stub of a fake device.
+func newFakeStub(t *testing.T) gpb.GRIBIClient {
+	t.Helper()
+	dev, err := fakedevice.NewDUT("dut", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(dev.Close)
+	conn, err := dev.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return gpb.NewGRIBIClient(conn)
+}
+
+// newFakeClient returns a primary client of a fake device, which requests
+// FIB ACKs.
+func newFakeClient(t *testing.T) *Client {
+	t.Helper()
+	c := &Client{FIBACK: true, Persistence: true}
+	stub := newFakeStub(t)
+	t.Cleanup(func() {
+		if c.fluentC != nil {
+			c.fluentC.Stop(t)
+		}
+	})
+	if err := c.start(t, stub); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
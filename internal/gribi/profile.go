@@ -0,0 +1,364 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/iputil"
+	"github.com/openconfig/featureprofiles/internal/tescale"
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/constants"
+	"github.com/openconfig/gribigo/fluent"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"gopkg.in/yaml.v3"
+
+	gppb "github.com/openconfig/featureprofiles/proto/gribi_programming_go_proto"
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+const defaultBatchSize = 1000
+
+// LoadProfile loads a programming profile from a textproto file, or from a
+// YAML file if its extension is .yaml or .yml, in which case the fields have
+// their proto or JSON names.
+func LoadProfile(file string) (*gppb.Profile, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p := &gppb.Profile{}
+	switch filepath.Ext(file) {
+	case ".yaml", ".yml":
+		var v any
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+		}
+		js, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+		}
+		err = protojson.Unmarshal(js, p)
+	default:
+		err = prototext.Unmarshal(b, p)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+	}
+	return p, nil
+}
+
+// ProfileEntries are the entries of a programming profile, in the order in
+// which they are programmed.
+type ProfileEntries struct {
+	NextHops      []fluent.GRIBIEntry
+	NextHopGroups []fluent.GRIBIEntry
+	Prefixes      []fluent.GRIBIEntry
+}
+
+// All returns all the entries in the order in which they are programmed.
+func (e *ProfileEntries) All() []fluent.GRIBIEntry {
+	var all []fluent.GRIBIEntry
+	all = append(all, e.NextHops...)
+	all = append(all, e.NextHopGroups...)
+	return append(all, e.Prefixes...)
+}
+
+// ipPool is an IP pool of a profile, which knows how many addresses remain.
+type ipPool struct {
+	*tescale.IPPool
+	remaining int
+}
+
+type expander struct {
+	defaultNI string
+	ipPools   map[string]*ipPool
+	idPools   map[string]*tescale.IDPool
+	// backups maps the names of the groups of one next-hop-group to their
+	// network instance and ID.
+	backups map[string]backupNHG
+	entries *ProfileEntries
+}
+
+type backupNHG struct {
+	ni string
+	id uint64
+}
+
+// ExpandProfile expands a programming profile into its entries.  defaultNI is
+// the name of the default network instance of the DUT, e.g. from
+// deviations.DefaultNetworkInstance.
+func ExpandProfile(p *gppb.Profile, defaultNI string) (*ProfileEntries, error) {
+	e := &expander{
+		defaultNI: defaultNI,
+		ipPools:   make(map[string]*ipPool),
+		idPools:   map[string]*tescale.IDPool{"": tescale.NewIDPool(0)},
+		backups:   make(map[string]backupNHG),
+		entries:   &ProfileEntries{},
+	}
+	for _, pool := range p.GetIpPools() {
+		if _, ok := e.ipPools[pool.GetName()]; ok {
+			return nil, fmt.Errorf("duplicate IP pool %q", pool.GetName())
+		}
+		ips, err := generateIPs(pool)
+		if err != nil {
+			return nil, fmt.Errorf("IP pool %q: %w", pool.GetName(), err)
+		}
+		e.ipPools[pool.GetName()] = &ipPool{IPPool: tescale.NewIPPool(ips), remaining: len(ips)}
+	}
+	for _, pool := range p.GetIdPools() {
+		if _, ok := e.idPools[pool.GetName()]; ok {
+			return nil, fmt.Errorf("duplicate ID pool %q", pool.GetName())
+		}
+		e.idPools[pool.GetName()] = tescale.NewIDPool(pool.GetBase())
+	}
+	for _, g := range p.GetGroups() {
+		if err := e.expandGroup(g); err != nil {
+			return nil, fmt.Errorf("group %q: %w", g.GetName(), err)
+		}
+	}
+	return e.entries, nil
+}
+
+func generateIPs(pool *gppb.IPPool) ([]string, error) {
+	if strings.Contains(pool.GetStart(), ":") {
+		return iputil.GenerateIPv6sWithStep(pool.GetStart(), int(pool.GetCount()), orDefault(pool.GetStep(), "::1"))
+	}
+	return iputil.GenerateIPsWithStep(pool.GetStart(), int(pool.GetCount()), orDefault(pool.GetStep(), "0.0.0.1"))
+}
+
+func (e *expander) nextIP(pool string) (string, error) {
+	p, ok := e.ipPools[pool]
+	if !ok {
+		return "", fmt.Errorf("no IP pool %q", pool)
+	}
+	if p.remaining == 0 {
+		return "", fmt.Errorf("IP pool %q is exhausted", pool)
+	}
+	p.remaining--
+	return p.NextIP(), nil
+}
+
+func (e *expander) expandGroup(g *gppb.EntryGroup) error {
+	ids, ok := e.idPools[g.GetIdPool()]
+	if !ok {
+		return fmt.Errorf("no ID pool %q", g.GetIdPool())
+	}
+	ni := orDefault(g.GetNetworkInstance(), e.defaultNI)
+	var backup *backupNHG
+	if name := g.GetBackup(); name != "" {
+		b, ok := e.backups[name]
+		if !ok {
+			return fmt.Errorf("backup %q is not a preceding group of one next-hop-group", name)
+		}
+		if b.ni != ni {
+			return fmt.Errorf("backup %q is in network instance %q, not %q", name, b.ni, ni)
+		}
+		backup = &b
+	}
+	count := max(g.GetCount(), 1)
+
+	for range count {
+		nhgID := ids.NextNHGID()
+		nhg := fluent.NextHopGroupEntry().WithNetworkInstance(ni).WithID(nhgID)
+		if backup != nil {
+			nhg.WithBackupNHG(backup.id)
+		}
+		for _, tmpl := range g.GetNextHops() {
+			for range max(tmpl.GetCount(), 1) {
+				index := ids.NextNHID()
+				nh, err := e.nextHop(tmpl, ni, index)
+				if err != nil {
+					return err
+				}
+				e.entries.NextHops = append(e.entries.NextHops, nh)
+				weight := tmpl.GetWeight()
+				if weight == 0 {
+					weight = 1
+				}
+				nhg.AddNextHop(index, weight)
+			}
+		}
+		e.entries.NextHopGroups = append(e.entries.NextHopGroups, nhg)
+		if count == 1 {
+			e.backups[g.GetName()] = backupNHG{ni: ni, id: nhgID}
+		}
+		if err := e.prefixes(g.GetPrefixes(), ni, nhgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *expander) nextHop(tmpl *gppb.NextHop, ni string, index uint64) (fluent.GRIBIEntry, error) {
+	nh := fluent.NextHopEntry().WithNetworkInstance(ni).WithIndex(index)
+	switch addr := tmpl.GetAddress().(type) {
+	case *gppb.NextHop_IpAddress:
+		nh.WithIPAddress(addr.IpAddress)
+	case *gppb.NextHop_IpPool:
+		ip, err := e.nextIP(addr.IpPool)
+		if err != nil {
+			return nil, err
+		}
+		nh.WithIPAddress(ip)
+	}
+	if intf := tmpl.GetInterface(); intf != "" {
+		nh.WithInterfaceRef(intf)
+	}
+	if mac := tmpl.GetMacAddress(); mac != "" {
+		nh.WithMacAddress(mac)
+	}
+	if vrf := tmpl.GetNextHopNetworkInstance(); vrf != "" {
+		nh.WithNextHopNetworkInstance(vrf)
+	}
+	if tmpl.GetDecapsulate() {
+		nh.WithDecapsulateHeader(fluent.IPinIP)
+	}
+	if encap := tmpl.GetEncapsulate(); encap != nil {
+		dst := encap.GetDstAddress()
+		if pool := encap.GetDstPool(); pool != "" {
+			ip, err := e.nextIP(pool)
+			if err != nil {
+				return nil, err
+			}
+			dst = ip
+		}
+		nh.WithEncapsulateHeader(fluent.IPinIP).WithIPinIP(encap.GetSrc(), dst)
+	}
+	return nh, nil
+}
+
+func (e *expander) prefixes(p *gppb.Prefixes, nhgNI string, nhgID uint64) error {
+	if p == nil {
+		return nil
+	}
+	ni := orDefault(p.GetNetworkInstance(), nhgNI)
+	for range max(p.GetCount(), 1) {
+		ip, err := e.nextIP(p.GetIpPool())
+		if err != nil {
+			return err
+		}
+		v6 := strings.Contains(ip, ":")
+		length := p.GetPrefixLength()
+		switch {
+		case length != 0:
+		case v6:
+			length = 128
+		default:
+			length = 32
+		}
+		prefix := fmt.Sprintf("%s/%d", ip, length)
+		if v6 {
+			entry := fluent.IPv6Entry().WithNetworkInstance(ni).WithPrefix(prefix).WithNextHopGroup(nhgID)
+			if ni != nhgNI {
+				entry.WithNextHopGroupNetworkInstance(nhgNI)
+			}
+			e.entries.Prefixes = append(e.entries.Prefixes, entry)
+			continue
+		}
+		entry := fluent.IPv4Entry().WithNetworkInstance(ni).WithPrefix(prefix).WithNextHopGroup(nhgID)
+		if ni != nhgNI {
+			entry.WithNextHopGroupNetworkInstance(nhgNI)
+		}
+		e.entries.Prefixes = append(e.entries.Prefixes, entry)
+	}
+	return nil
+}
+
+// ProgramReport is the outcome of programming a profile.
+type ProgramReport struct {
+	// Entries is the number of entries programmed.
+	Entries int
+	// Batches is the number of batches the entries were sent in.
+	Batches int
+	// Unacknowledged are the entries that were not programmed in the FIB, if
+	// the client requests FIB ACKs, or else in the RIB.
+	Unacknowledged []string
+	// Duration is how long the programming took, including waiting for the
+	// results.
+	Duration time.Duration
+}
+
+// ProgramProfile expands a profile and adds its entries in batches of the
+// batch size of the profile, waiting for the results of each batch before
+// sending the next one.  The acknowledged entries are recorded in the RIB of
+// the client.
+func (c *Client) ProgramProfile(t testing.TB, p *gppb.Profile, defaultNI string) *ProgramReport {
+	t.Helper()
+	entries, err := ExpandProfile(p, defaultNI)
+	if err != nil {
+		t.Fatalf("Cannot expand gRIBI programming profile: %v", err)
+	}
+	all := entries.All()
+	batchSize := int(p.GetBatchSize())
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	want := gpb.AFTResult_RIB_PROGRAMMED
+	if c.FIBACK {
+		want = gpb.AFTResult_FIB_PROGRAMMED
+	}
+
+	report := &ProgramReport{Entries: len(all)}
+	start := time.Now()
+	for i := 0; i < len(all); i += batchSize {
+		batch := all[i:min(i+batchSize, len(all))]
+		ids, results, err := c.modify(t, constants.Add, batch...)
+		if err != nil {
+			t.Fatalf("Error waiting to program batch %d: %v", report.Batches+1, err)
+		}
+		report.Batches++
+		report.Unacknowledged = append(report.Unacknowledged, unacknowledged(t, batch, ids, results, want)...)
+	}
+	report.Duration = time.Since(start)
+	t.Logf("Programmed %d gRIBI entries in %d batches in %v, %d unacknowledged", report.Entries, report.Batches, report.Duration, len(report.Unacknowledged))
+	return report
+}
+
+// unacknowledged returns the entries whose best result is worse than want.
+// The entries are matched to their results by their operation IDs in ids.
+func unacknowledged(t testing.TB, entries []fluent.GRIBIEntry, ids []uint64, results []*client.OpResult, want gpb.AFTResult_Status) []string {
+	best := bestResults(results)
+	var keys []string
+	for i, e := range entries {
+		ep, err := e.EntryProto()
+		if err != nil {
+			t.Fatalf("Invalid gRIBI entry: %v", err)
+		}
+		if status := best[ids[i]]; ackRank(status) < ackRank(want) {
+			keys = append(keys, fmt.Sprintf("%s in %q: %v", entryKey(ep), ep.GetNetworkInstance(), status))
+		}
+	}
+	return keys
+}
+
+// ackRank ranks the results from not programmed to programmed in the FIB.
+func ackRank(s gpb.AFTResult_Status) int {
+	switch s {
+	case gpb.AFTResult_RIB_PROGRAMMED:
+		return 1
+	case gpb.AFTResult_FIB_PROGRAMMED:
+		return 2
+	default:
+		return 0
+	}
+}
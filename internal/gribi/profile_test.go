@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/constants"
+	"github.com/openconfig/gribigo/fluent"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gppb "github.com/openconfig/featureprofiles/proto/gribi_programming_go_proto"
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// teProfile is a small version of the tescale VRF_T programming: tunnel
+// prefixes in a VRF resolve through groups of VIP next hops in the default
+// network instance, which are backed up by a redirect to another VRF.
+const teProfile = `
+ip_pools { name: "tunnels" start: "198.18.0.1" count: 4 }
+ip_pools { name: "vips" start: "198.18.196.1" count: 4 }
+id_pools { name: "te" base: 10000 }
+groups {
+  name: "redirect"
+  id_pool: "te"
+  next_hops { next_hop_network_instance: "vrf_r" }
+}
+groups {
+  name: "tunnels"
+  count: 2
+  id_pool: "te"
+  backup: "redirect"
+  next_hops { count: 2 ip_pool: "vips" weight: 3 }
+  prefixes { count: 2 ip_pool: "tunnels" network_instance: "vrf_t" }
+}
+batch_size: 4
+`
+
+const teProfileYAML = `
+ip_pools:
+  - {name: tunnels, start: 198.18.0.1, count: 4}
+  - {name: vips, start: 198.18.196.1, count: 4}
+id_pools:
+  - {name: te, base: 10000}
+groups:
+  - name: redirect
+    id_pool: te
+    next_hops:
+      - nextHopNetworkInstance: vrf_r
+  - name: tunnels
+    count: 2
+    id_pool: te
+    backup: redirect
+    next_hops:
+      - {count: 2, ip_pool: vips, weight: 3}
+    prefixes: {count: 2, ip_pool: tunnels, network_instance: vrf_t}
+batch_size: 4
+`
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	var profiles []*gppb.Profile
+	for _, f := range []struct{ name, content string }{
+		{"te.textproto", teProfile},
+		{"te.yaml", teProfileYAML},
+	} {
+		file := filepath.Join(dir, f.name)
+		if err := os.WriteFile(file, []byte(f.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		p, err := LoadProfile(file)
+		if err != nil {
+			t.Fatalf("LoadProfile(%q) got unexpected error: %v", f.name, err)
+		}
+		profiles = append(profiles, p)
+	}
+	if diff := cmp.Diff(profiles[0], profiles[1], protocmp.Transform()); diff != "" {
+		t.Errorf("LoadProfile() of textproto and YAML differ (-textproto +yaml):\n%s", diff)
+	}
+}
+
+func TestExpandProfile(t *testing.T) {
+	p := &gppb.Profile{}
+	if err := prototext.Unmarshal([]byte(teProfile), p); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ExpandProfile(p, "DEFAULT")
+	if err != nil {
+		t.Fatalf("ExpandProfile() got unexpected error: %v", err)
+	}
+
+	want := &ProfileEntries{
+		NextHops: []fluent.GRIBIEntry{
+			fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(10001).WithNextHopNetworkInstance("vrf_r"),
+			fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(10002).WithIPAddress("198.18.196.1"),
+			fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(10003).WithIPAddress("198.18.196.2"),
+			fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(10004).WithIPAddress("198.18.196.3"),
+			fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(10005).WithIPAddress("198.18.196.4"),
+		},
+		NextHopGroups: []fluent.GRIBIEntry{
+			fluent.NextHopGroupEntry().WithNetworkInstance("DEFAULT").WithID(10001).AddNextHop(10001, 1),
+			fluent.NextHopGroupEntry().WithNetworkInstance("DEFAULT").WithID(10002).WithBackupNHG(10001).AddNextHop(10002, 3).AddNextHop(10003, 3),
+			fluent.NextHopGroupEntry().WithNetworkInstance("DEFAULT").WithID(10003).WithBackupNHG(10001).AddNextHop(10004, 3).AddNextHop(10005, 3),
+		},
+		Prefixes: []fluent.GRIBIEntry{
+			fluent.IPv4Entry().WithNetworkInstance("vrf_t").WithPrefix("198.18.0.1/32").WithNextHopGroup(10002).WithNextHopGroupNetworkInstance("DEFAULT"),
+			fluent.IPv4Entry().WithNetworkInstance("vrf_t").WithPrefix("198.18.0.2/32").WithNextHopGroup(10002).WithNextHopGroupNetworkInstance("DEFAULT"),
+			fluent.IPv4Entry().WithNetworkInstance("vrf_t").WithPrefix("198.18.0.3/32").WithNextHopGroup(10003).WithNextHopGroupNetworkInstance("DEFAULT"),
+			fluent.IPv4Entry().WithNetworkInstance("vrf_t").WithPrefix("198.18.0.4/32").WithNextHopGroup(10003).WithNextHopGroupNetworkInstance("DEFAULT"),
+		},
+	}
+	entryProtos := func(entries []fluent.GRIBIEntry) []any {
+		var eps []any
+		for _, e := range entries {
+			eps = append(eps, entryProto(t, e))
+		}
+		return eps
+	}
+	if diff := cmp.Diff(entryProtos(want.All()), entryProtos(entries.All()), protocmp.Transform()); diff != "" {
+		t.Errorf("ExpandProfile() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestExpandProfileErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		profile string
+		wantErr string
+	}{{
+		desc:    "exhausted pool",
+		profile: `ip_pools { name: "p" start: "192.0.2.1" count: 1 } groups { name: "g" next_hops { count: 2 ip_pool: "p" } }`,
+		wantErr: `group "g": IP pool "p" is exhausted`,
+	}, {
+		desc:    "unknown backup",
+		profile: `groups { name: "g" backup: "b" }`,
+		wantErr: `group "g": backup "b" is not a preceding group`,
+	}, {
+		desc:    "unknown ID pool",
+		profile: `groups { name: "g" id_pool: "ids" }`,
+		wantErr: `group "g": no ID pool "ids"`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			p := &gppb.Profile{}
+			if err := prototext.Unmarshal([]byte(test.profile), p); err != nil {
+				t.Fatal(err)
+			}
+			_, err := ExpandProfile(p, "DEFAULT")
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("ExpandProfile() got unexpected error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProgramProfile(t *testing.T) {
+	c := newFakeClient(t)
+	p := &gppb.Profile{}
+	if err := prototext.Unmarshal([]byte(`
+ip_pools { name: "nhs" start: "192.0.2.1" count: 8 }
+ip_pools { name: "prefixes" start: "198.51.100.0" step: "0.0.1.0" count: 4 }
+groups {
+  count: 4
+  next_hops { count: 2 ip_pool: "nhs" }
+  prefixes { ip_pool: "prefixes" prefix_length: 24 }
+}
+batch_size: 5
+`), p); err != nil {
+		t.Fatal(err)
+	}
+	report := c.ProgramProfile(t, p, "DEFAULT")
+	if got, want := *report, (ProgramReport{Entries: 16, Batches: 4, Duration: report.Duration}); !cmp.Equal(got, want) {
+		t.Errorf("ProgramProfile() got report %+v, want %+v", got, want)
+	}
+	if got, want := len(c.RIB().NetworkInstance("DEFAULT").IPv4), 4; got != want {
+		t.Errorf("ProgramProfile() recorded %d IPv4 entries, want %d", got, want)
+	}
+}
+
+func TestUnacknowledged(t *testing.T) {
+	entries := []fluent.GRIBIEntry{
+		fluent.IPv4Entry().WithNetworkInstance("DEFAULT").WithPrefix("198.51.100.0/24").WithNextHopGroup(10),
+		fluent.IPv4Entry().WithNetworkInstance("VRF-A").WithPrefix("198.51.100.0/24").WithNextHopGroup(10),
+		fluent.IPv4Entry().WithNetworkInstance("VRF-B").WithPrefix("198.51.100.0/24").WithNextHopGroup(10),
+	}
+	results := []*client.OpResult{
+		result(7, constants.Add, gpb.AFTResult_RIB_PROGRAMMED, &client.OpDetailsResults{IPv4Prefix: "198.51.100.0/24"}),
+		result(7, constants.Add, gpb.AFTResult_FIB_PROGRAMMED, &client.OpDetailsResults{IPv4Prefix: "198.51.100.0/24"}),
+		result(8, constants.Add, gpb.AFTResult_RIB_PROGRAMMED, &client.OpDetailsResults{IPv4Prefix: "198.51.100.0/24"}),
+	}
+	got := unacknowledged(t, entries, []uint64{7, 8, 9}, results, gpb.AFTResult_FIB_PROGRAMMED)
+	want := []string{
+		`ipv4 198.51.100.0/24 in "VRF-A": RIB_PROGRAMMED`,
+		`ipv4 198.51.100.0/24 in "VRF-B": UNSET`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unacknowledged() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
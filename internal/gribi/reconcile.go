@@ -0,0 +1,527 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/openconfig/featureprofiles/internal/telemetry/aftcache"
+	"github.com/openconfig/ondatra/gnmi/oc"
+
+	aftpb "github.com/openconfig/gribi/v1/proto/gribi_aft"
+)
+
+// AFT is the forwarding state of a network instance that a device reports,
+// keyed by the IDs that the device assigned to the next-hop-groups and the
+// next hops.
+type AFT struct {
+	// IPv4 maps the IPv4 prefixes to their entries.
+	IPv4 map[string]*AFTEntry
+	// IPv6 maps the IPv6 prefixes to their entries.
+	IPv6 map[string]*AFTEntry
+	// MPLS maps the MPLS labels to their entries.
+	MPLS map[uint64]*AFTEntry
+	// NextHopGroups maps the IDs of the next-hop-groups to the groups.
+	NextHopGroups map[uint64]*AFTNextHopGroup
+	// NextHops maps the IDs of the next hops to the next hops.
+	NextHops map[uint64]*AFTNextHop
+}
+
+// AFTEntry is a prefix or label entry of an AFT.
+type AFTEntry struct {
+	// NextHopGroup is the ID of the next-hop-group of the entry.
+	NextHopGroup uint64
+	// NextHopGroupNetworkInstance is the network instance of the
+	// next-hop-group, if it is not the network instance of the entry.
+	NextHopGroupNetworkInstance string
+}
+
+// AFTNextHopGroup is a next-hop-group of an AFT.
+type AFTNextHopGroup struct {
+	// ProgrammedID is the ID that a gRIBI client gave the group, or 0 if the
+	// group was not programmed with gRIBI or the device does not report it.
+	ProgrammedID uint64
+	// Weights maps the IDs of the next hops of the group to their weights.  A
+	// weight of 0 is not reported.
+	Weights map[uint64]uint64
+	// BackupNextHopGroup is the ID of the backup next-hop-group, if any.
+	BackupNextHopGroup uint64
+}
+
+// AFTNextHop is a next hop of an AFT.  Empty attributes are not reported.
+type AFTNextHop struct {
+	// ProgrammedIndex is the index that a gRIBI client gave the next hop, or
+	// 0 if the next hop was not programmed with gRIBI or the device does not
+	// report it.
+	ProgrammedIndex uint64
+	IPAddress       string
+	MACAddress      string
+	Interface       string
+}
+
+// AFTFromOC returns the AFT of the AFT telemetry of a network instance.
+func AFTFromOC(afts *oc.NetworkInstance_Afts) *AFT {
+	a := newAFT()
+	for prefix, e := range afts.Ipv4Entry {
+		a.IPv4[prefix] = &AFTEntry{NextHopGroup: e.GetNextHopGroup(), NextHopGroupNetworkInstance: e.GetNextHopGroupNetworkInstance()}
+	}
+	for prefix, e := range afts.Ipv6Entry {
+		a.IPv6[prefix] = &AFTEntry{NextHopGroup: e.GetNextHopGroup(), NextHopGroupNetworkInstance: e.GetNextHopGroupNetworkInstance()}
+	}
+	for label, e := range afts.LabelEntry {
+		if l, ok := label.(oc.UnionUint32); ok {
+			a.MPLS[uint64(l)] = &AFTEntry{NextHopGroup: e.GetNextHopGroup(), NextHopGroupNetworkInstance: e.GetNextHopGroupNetworkInstance()}
+		}
+	}
+	for id, g := range afts.NextHopGroup {
+		nhg := &AFTNextHopGroup{
+			ProgrammedID:       g.GetProgrammedId(),
+			Weights:            make(map[uint64]uint64),
+			BackupNextHopGroup: g.GetBackupNextHopGroup(),
+		}
+		for nhID, nh := range g.NextHop {
+			nhg.Weights[nhID] = nh.GetWeight()
+		}
+		a.NextHopGroups[id] = nhg
+	}
+	for id, nh := range afts.NextHop {
+		a.NextHops[id] = &AFTNextHop{
+			ProgrammedIndex: nh.GetProgrammedIndex(),
+			IPAddress:       nh.GetIpAddress(),
+			MACAddress:      nh.GetMacAddress(),
+			Interface:       nh.GetInterfaceRef().GetInterface(),
+		}
+	}
+	return a
+}
+
+// AFTFromCache returns the AFTs of the network instances of the AFT data of
+// an aftcache stream session, keyed by network instance as Reconcile takes
+// them.  The data does not have the programmed IDs or the backup
+// next-hop-groups.
+func AFTFromCache(data *aftcache.AFTData) map[string]*AFT {
+	afts := make(map[string]*AFT)
+	for ni, niAFT := range data.NetworkInstances {
+		a := newAFT()
+		for prefix, nhgID := range niAFT.Prefixes {
+			e := &AFTEntry{NextHopGroup: nhgID, NextHopGroupNetworkInstance: niAFT.PrefixNHGNetworkInstances[prefix]}
+			if strings.Contains(prefix, ":") {
+				a.IPv6[prefix] = e
+			} else {
+				a.IPv4[prefix] = e
+			}
+		}
+		for label, e := range niAFT.LabelEntries {
+			a.MPLS[label] = &AFTEntry{NextHopGroup: e.NHGID, NextHopGroupNetworkInstance: e.NHGNetworkInstance}
+		}
+		for id, g := range niAFT.NextHopGroups {
+			nhg := &AFTNextHopGroup{Weights: make(map[uint64]uint64)}
+			for _, nhID := range g.NHIDs {
+				nhg.Weights[nhID] = g.NHWeights[nhID]
+			}
+			a.NextHopGroups[id] = nhg
+		}
+		for id, nh := range niAFT.NextHops {
+			a.NextHops[id] = &AFTNextHop{IPAddress: nh.IP, Interface: nh.IntfName}
+		}
+		afts[ni] = a
+	}
+	return afts
+}
+
+func newAFT() *AFT {
+	return &AFT{
+		IPv4:          make(map[string]*AFTEntry),
+		IPv6:          make(map[string]*AFTEntry),
+		MPLS:          make(map[uint64]*AFTEntry),
+		NextHopGroups: make(map[uint64]*AFTNextHopGroup),
+		NextHops:      make(map[uint64]*AFTNextHop),
+	}
+}
+
+// DiffKind is the kind of a difference between a RIB and an AFT.
+type DiffKind int
+
+const (
+	// Missing is an acknowledged entry that is not in the AFT.
+	Missing DiffKind = iota
+	// Extra is an entry of the AFT that was programmed with gRIBI, but is not
+	// in the RIB.
+	Extra
+	// Mismatched is an acknowledged entry that differs in the AFT.
+	Mismatched
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case Missing:
+		return "missing"
+	case Extra:
+		return "extra"
+	case Mismatched:
+		return "mismatched"
+	default:
+		return fmt.Sprintf("DiffKind(%d)", int(k))
+	}
+}
+
+// Diff is a difference between a RIB and an AFT.
+type Diff struct {
+	Kind            DiffKind
+	NetworkInstance string
+	// Entry is the entry that differs, e.g. "ipv4 198.51.100.0/24" or
+	// "next-hop-group 10".
+	Entry string
+	// Reasons are how a mismatched entry differs.
+	Reasons []string
+}
+
+func (d *Diff) String() string {
+	s := fmt.Sprintf("network instance %q: %s %s", d.NetworkInstance, d.Kind, d.Entry)
+	if len(d.Reasons) > 0 {
+		s += ": " + strings.Join(d.Reasons, "; ")
+	}
+	return s
+}
+
+// Reconcile compares the RIB with the AFTs of network instances, and returns
+// the entries that are missing from the AFTs, extra in the AFTs or that
+// mismatch, including the weights of the next hops and the backup
+// next-hop-groups.  Only the network instances in afts are reconciled.
+//
+// The prefix and label entries are matched by their keys, and their
+// next-hop-groups and next hops by their programmed IDs if the device
+// reports them, or else by their attributes.  Extra next-hop-groups and next
+// hops are only found by their programmed IDs, and extra prefix and label
+// entries only if their next-hop-groups have programmed IDs, because the AFT
+// also holds the entries of the other protocols.
+func (r *RIB) Reconcile(afts map[string]*AFT) []*Diff {
+	rc := &reconciler{afts: afts, ribs: make(map[string]*NIRIB)}
+	for ni := range afts {
+		rc.ribs[ni] = r.NetworkInstance(ni)
+	}
+	for _, ni := range sortedKeys(afts) {
+		rc.reconcile(ni)
+	}
+	return rc.diffs
+}
+
+type reconciler struct {
+	afts  map[string]*AFT
+	ribs  map[string]*NIRIB
+	diffs []*Diff
+}
+
+func (rc *reconciler) add(kind DiffKind, ni, entry string, reasons ...string) {
+	rc.diffs = append(rc.diffs, &Diff{Kind: kind, NetworkInstance: ni, Entry: entry, Reasons: reasons})
+}
+
+// ribEntry is a prefix or label entry of a RIB.
+type ribEntry struct {
+	nhg   uint64
+	nhgNI string
+}
+
+func (rc *reconciler) reconcile(ni string) {
+	rib, aft := rc.ribs[ni], rc.afts[ni]
+
+	v4 := make(map[string]ribEntry)
+	for prefix, e := range rib.IPv4 {
+		v4[prefix] = ribEntry{e.GetNextHopGroup().GetValue(), e.GetNextHopGroupNetworkInstance().GetValue()}
+	}
+	reconcileEntries(rc, ni, "ipv4", v4, aft.IPv4)
+	v6 := make(map[string]ribEntry)
+	for prefix, e := range rib.IPv6 {
+		v6[prefix] = ribEntry{e.GetNextHopGroup().GetValue(), e.GetNextHopGroupNetworkInstance().GetValue()}
+	}
+	reconcileEntries(rc, ni, "ipv6", v6, aft.IPv6)
+	mpls := make(map[uint64]ribEntry)
+	for label, e := range rib.MPLS {
+		mpls[label] = ribEntry{e.GetNextHopGroup().GetValue(), e.GetNextHopGroupNetworkInstance().GetValue()}
+	}
+	reconcileEntries(rc, ni, "mpls", mpls, aft.MPLS)
+
+	if nhgs := programmedNHGs(aft); len(nhgs) > 0 {
+		for _, id := range sortedKeys(rib.NextHopGroups) {
+			entry := fmt.Sprintf("next-hop-group %d", id)
+			gotID, ok := nhgs[id]
+			if !ok {
+				rc.add(Missing, ni, entry)
+				continue
+			}
+			if reasons := rc.nhgDiff(ni, id, ni, gotID, false); len(reasons) > 0 {
+				rc.add(Mismatched, ni, entry, reasons...)
+			}
+		}
+		for _, id := range sortedKeys(nhgs) {
+			if _, ok := rib.NextHopGroups[id]; !ok {
+				rc.add(Extra, ni, fmt.Sprintf("next-hop-group %d", id))
+			}
+		}
+	}
+
+	if nhs := programmedNHs(aft); len(nhs) > 0 {
+		for _, index := range sortedKeys(rib.NextHops) {
+			entry := fmt.Sprintf("next-hop %d", index)
+			gotID, ok := nhs[index]
+			if !ok {
+				rc.add(Missing, ni, entry)
+				continue
+			}
+			if reasons := nhDiff(rib.NextHops[index], aft.NextHops[gotID]); len(reasons) > 0 {
+				rc.add(Mismatched, ni, entry, reasons...)
+			}
+		}
+		for _, index := range sortedKeys(nhs) {
+			if _, ok := rib.NextHops[index]; !ok {
+				rc.add(Extra, ni, fmt.Sprintf("next-hop %d", index))
+			}
+		}
+	}
+}
+
+// reconcileEntries reconciles the prefix or label entries of a network
+// instance.
+func reconcileEntries[K cmp.Ordered](rc *reconciler, ni, kind string, want map[K]ribEntry, got map[K]*AFTEntry) {
+	for _, key := range sortedKeys(want) {
+		entry := fmt.Sprintf("%s %v", kind, key)
+		g, ok := got[key]
+		if !ok {
+			rc.add(Missing, ni, entry)
+			continue
+		}
+		w := want[key]
+		wantNI, gotNI := orDefault(w.nhgNI, ni), orDefault(g.NextHopGroupNetworkInstance, ni)
+		if wantNI != gotNI {
+			rc.add(Mismatched, ni, entry, fmt.Sprintf("next-hop-group network instance is %q, want %q", gotNI, wantNI))
+			continue
+		}
+		if reasons := rc.nhgDiff(wantNI, w.nhg, gotNI, g.NextHopGroup, true); len(reasons) > 0 {
+			rc.add(Mismatched, ni, entry, reasons...)
+		}
+	}
+	for _, key := range sortedKeys(got) {
+		if _, ok := want[key]; ok {
+			continue
+		}
+		g := got[key]
+		if aft := rc.afts[orDefault(g.NextHopGroupNetworkInstance, ni)]; aft != nil && aft.NextHopGroups[g.NextHopGroup].GetProgrammedID() != 0 {
+			rc.add(Extra, ni, fmt.Sprintf("%s %v", kind, key))
+		}
+	}
+}
+
+// nhgDiff returns how a next-hop-group of an AFT differs from a
+// next-hop-group of the RIB.  If the AFT group has a programmed ID and ref is
+// set, only the IDs are compared, since the groups themselves are
+// reconciled separately.
+func (rc *reconciler) nhgDiff(wantNI string, wantID uint64, gotNI string, gotID uint64, ref bool) []string {
+	aft := rc.afts[gotNI]
+	if aft == nil {
+		return nil
+	}
+	got, ok := aft.NextHopGroups[gotID]
+	if !ok {
+		return []string{fmt.Sprintf("next-hop-group %d is not in the AFT", gotID)}
+	}
+	if got.ProgrammedID != 0 {
+		if got.ProgrammedID != wantID {
+			return []string{fmt.Sprintf("next-hop-group is %d, want %d", got.ProgrammedID, wantID)}
+		}
+		if ref {
+			return nil
+		}
+	}
+	rib := rc.ribs[wantNI]
+	if rib == nil {
+		return nil
+	}
+	want, ok := rib.NextHopGroups[wantID]
+	if !ok {
+		// The group was not programmed by this client.
+		return nil
+	}
+	reasons := rc.nextHopsDiff(rib, want, aft, got)
+
+	switch wantBackup := want.GetBackupNextHopGroup().GetValue(); {
+	case wantBackup == 0 && got.BackupNextHopGroup != 0:
+		reasons = append(reasons, fmt.Sprintf("unexpected backup next-hop-group %d", got.BackupNextHopGroup))
+	case wantBackup != 0 && got.BackupNextHopGroup == 0:
+		reasons = append(reasons, fmt.Sprintf("missing backup next-hop-group %d", wantBackup))
+	case wantBackup != 0:
+		for _, r := range rc.nhgDiff(wantNI, wantBackup, gotNI, got.BackupNextHopGroup, true) {
+			reasons = append(reasons, "backup "+r)
+		}
+	}
+	return reasons
+}
+
+// nextHopsDiff returns how the next hops of a next-hop-group of an AFT differ
+// from those of a next-hop-group of the RIB.  The next hops are paired by
+// their programmed indices, or else by their attributes.
+func (rc *reconciler) nextHopsDiff(rib *NIRIB, want *aftpb.Afts_NextHopGroup, aft *AFT, got *AFTNextHopGroup) []string {
+	var reasons []string
+	paired := make(map[uint64]bool)
+	for _, nh := range want.GetNextHop() {
+		index, weight := nh.GetIndex(), nh.GetNextHop().GetWeight().GetValue()
+		gotID, ok := pairNextHop(rib.NextHops[index], index, aft, got, paired)
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("missing next hop %d", index))
+			continue
+		}
+		paired[gotID] = true
+		if gotWeight := got.Weights[gotID]; weight != 0 && gotWeight != 0 && gotWeight != weight {
+			reasons = append(reasons, fmt.Sprintf("next hop %d has weight %d, want %d", index, gotWeight, weight))
+		}
+	}
+	for _, id := range sortedKeys(got.Weights) {
+		if !paired[id] {
+			reasons = append(reasons, fmt.Sprintf("unexpected next hop %s", describeNH(id, aft.NextHops[id])))
+		}
+	}
+	return reasons
+}
+
+// pairNextHop returns the ID of the unpaired next hop of a group of an AFT
+// that matches a next hop of the RIB.
+func pairNextHop(want *aftpb.Afts_NextHop, index uint64, aft *AFT, got *AFTNextHopGroup, paired map[uint64]bool) (uint64, bool) {
+	for _, id := range sortedKeys(got.Weights) {
+		if paired[id] {
+			continue
+		}
+		nh := aft.NextHops[id]
+		if nh.GetProgrammedIndex() != 0 {
+			if nh.GetProgrammedIndex() == index {
+				return id, true
+			}
+			continue
+		}
+		if len(nhDiff(want, nh)) == 0 {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// nhDiff returns how a next hop of an AFT differs from a next hop of the
+// RIB.  Only the attributes that both set are compared, since devices may
+// resolve a next hop, e.g. to an interface, or not report every attribute.
+func nhDiff(want *aftpb.Afts_NextHop, got *AFTNextHop) []string {
+	var reasons []string
+	compare := func(name, want, got string) {
+		if want != "" && got != "" && want != got {
+			reasons = append(reasons, fmt.Sprintf("%s is %q, want %q", name, got, want))
+		}
+	}
+	compare("ip-address", want.GetIpAddress().GetValue(), got.GetIPAddress())
+	compare("mac-address", want.GetMacAddress().GetValue(), got.GetMACAddress())
+	compare("interface", want.GetInterfaceRef().GetInterface().GetValue(), got.GetInterface())
+	return reasons
+}
+
+func describeNH(id uint64, nh *AFTNextHop) string {
+	s := fmt.Sprint(id)
+	var attrs []string
+	if nh.GetIPAddress() != "" {
+		attrs = append(attrs, nh.GetIPAddress())
+	}
+	if nh.GetInterface() != "" {
+		attrs = append(attrs, nh.GetInterface())
+	}
+	if len(attrs) > 0 {
+		s += " (" + strings.Join(attrs, ", ") + ")"
+	}
+	return s
+}
+
+// programmedNHGs maps the programmed IDs of the next-hop-groups of an AFT to
+// their IDs.
+func programmedNHGs(aft *AFT) map[uint64]uint64 {
+	ids := make(map[uint64]uint64)
+	for id, nhg := range aft.NextHopGroups {
+		if nhg.ProgrammedID != 0 {
+			ids[nhg.ProgrammedID] = id
+		}
+	}
+	return ids
+}
+
+// programmedNHs maps the programmed indices of the next hops of an AFT to
+// their IDs.
+func programmedNHs(aft *AFT) map[uint64]uint64 {
+	ids := make(map[uint64]uint64)
+	for id, nh := range aft.NextHops {
+		if nh.ProgrammedIndex != 0 {
+			ids[nh.ProgrammedIndex] = id
+		}
+	}
+	return ids
+}
+
+// GetProgrammedID returns the programmed ID of the group, or 0 if it is nil.
+func (g *AFTNextHopGroup) GetProgrammedID() uint64 {
+	if g == nil {
+		return 0
+	}
+	return g.ProgrammedID
+}
+
+// GetProgrammedIndex returns the programmed index of the next hop, or 0 if
+// it is nil.
+func (nh *AFTNextHop) GetProgrammedIndex() uint64 {
+	if nh == nil {
+		return 0
+	}
+	return nh.ProgrammedIndex
+}
+
+// GetIPAddress returns the IP address of the next hop, or "" if it is nil.
+func (nh *AFTNextHop) GetIPAddress() string {
+	if nh == nil {
+		return ""
+	}
+	return nh.IPAddress
+}
+
+// GetMACAddress returns the MAC address of the next hop, or "" if it is nil.
+func (nh *AFTNextHop) GetMACAddress() string {
+	if nh == nil {
+		return ""
+	}
+	return nh.MACAddress
+}
+
+// GetInterface returns the interface of the next hop, or "" if it is nil.
+func (nh *AFTNextHop) GetInterface() string {
+	if nh == nil {
+		return ""
+	}
+	return nh.Interface
+}
+
+func orDefault(ni, def string) string {
+	if ni == "" {
+		return def
+	}
+	return ni
+}
+
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/constants"
+	"google.golang.org/protobuf/proto"
+
+	aftpb "github.com/openconfig/gribi/v1/proto/gribi_aft"
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// RIB is a shadow of the gRIBI entries that the device acknowledged, per
+// network instance.  A Client keeps the entries it programs in its RIB.
+type RIB struct {
+	mu  sync.Mutex
+	nis map[string]*NIRIB
+}
+
+// NIRIB holds the acknowledged entries of a network instance.
+type NIRIB struct {
+	// NextHops maps the indices of the next hops to the next hops.
+	NextHops map[uint64]*aftpb.Afts_NextHop
+	// NextHopGroups maps the IDs of the next-hop-groups to the groups.
+	NextHopGroups map[uint64]*aftpb.Afts_NextHopGroup
+	// IPv4 maps the IPv4 prefixes to their entries.
+	IPv4 map[string]*aftpb.Afts_Ipv4Entry
+	// IPv6 maps the IPv6 prefixes to their entries.
+	IPv6 map[string]*aftpb.Afts_Ipv6Entry
+	// MPLS maps the MPLS labels to their entries.
+	MPLS map[uint64]*aftpb.Afts_LabelEntry
+}
+
+// NewRIB returns an empty RIB.
+func NewRIB() *RIB {
+	return &RIB{nis: make(map[string]*NIRIB)}
+}
+
+func newNIRIB() *NIRIB {
+	return &NIRIB{
+		NextHops:      make(map[uint64]*aftpb.Afts_NextHop),
+		NextHopGroups: make(map[uint64]*aftpb.Afts_NextHopGroup),
+		IPv4:          make(map[string]*aftpb.Afts_Ipv4Entry),
+		IPv6:          make(map[string]*aftpb.Afts_Ipv6Entry),
+		MPLS:          make(map[uint64]*aftpb.Afts_LabelEntry),
+	}
+}
+
+// Apply records an operation on an entry that the device acknowledged.  An
+// add or replace stores the entry, replacing an entry with the same key, and
+// a delete removes it.
+func (r *RIB) Apply(op constants.OpType, entry *gpb.AFTEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ni := entry.GetNetworkInstance()
+	rib, ok := r.nis[ni]
+	if !ok {
+		rib = newNIRIB()
+	}
+	del := false
+	switch op {
+	case constants.Add, constants.Replace:
+	case constants.Delete:
+		del = true
+	default:
+		return fmt.Errorf("unsupported operation %v", op)
+	}
+
+	switch e := entry.GetEntry().(type) {
+	case *gpb.AFTEntry_NextHop:
+		applyEntry(rib.NextHops, e.NextHop.GetIndex(), e.NextHop.GetNextHop(), del)
+	case *gpb.AFTEntry_NextHopGroup:
+		applyEntry(rib.NextHopGroups, e.NextHopGroup.GetId(), e.NextHopGroup.GetNextHopGroup(), del)
+	case *gpb.AFTEntry_Ipv4:
+		applyEntry(rib.IPv4, e.Ipv4.GetPrefix(), e.Ipv4.GetIpv4Entry(), del)
+	case *gpb.AFTEntry_Ipv6:
+		applyEntry(rib.IPv6, e.Ipv6.GetPrefix(), e.Ipv6.GetIpv6Entry(), del)
+	case *gpb.AFTEntry_Mpls:
+		label, ok := e.Mpls.GetLabel().(*aftpb.Afts_LabelEntryKey_LabelUint64)
+		if !ok {
+			return fmt.Errorf("unsupported MPLS label %v", e.Mpls.GetLabel())
+		}
+		applyEntry(rib.MPLS, label.LabelUint64, e.Mpls.GetLabelEntry(), del)
+	default:
+		return fmt.Errorf("unsupported entry %v", entry)
+	}
+	r.nis[ni] = rib
+	return nil
+}
+
+func applyEntry[K comparable, V proto.Message](m map[K]V, key K, v V, del bool) {
+	if del {
+		delete(m, key)
+		return
+	}
+	m[key] = proto.Clone(v).(V)
+}
+
+// Flush removes the entries of a network instance, or of all network
+// instances if it is empty.
+func (r *RIB) Flush(ni string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ni == "" {
+		r.nis = make(map[string]*NIRIB)
+		return
+	}
+	delete(r.nis, ni)
+}
+
+// NetworkInstances returns the sorted names of the network instances that
+// have entries.
+func (r *RIB) NetworkInstances() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var nis []string
+	for ni, rib := range r.nis {
+		if !rib.empty() {
+			nis = append(nis, ni)
+		}
+	}
+	sort.Strings(nis)
+	return nis
+}
+
+// NetworkInstance returns a copy of the entries of a network instance.
+func (r *RIB) NetworkInstance(ni string) *NIRIB {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := newNIRIB()
+	rib, ok := r.nis[ni]
+	if !ok {
+		return c
+	}
+	copyEntries(c.NextHops, rib.NextHops)
+	copyEntries(c.NextHopGroups, rib.NextHopGroups)
+	copyEntries(c.IPv4, rib.IPv4)
+	copyEntries(c.IPv6, rib.IPv6)
+	copyEntries(c.MPLS, rib.MPLS)
+	return c
+}
+
+func copyEntries[K comparable, V proto.Message](dst, src map[K]V) {
+	for k, v := range src {
+		dst[k] = proto.Clone(v).(V)
+	}
+}
+
+func (r *NIRIB) empty() bool {
+	return len(r.NextHops) == 0 && len(r.NextHopGroups) == 0 && len(r.IPv4) == 0 && len(r.IPv6) == 0 && len(r.MPLS) == 0
+}
+
+// applyResults records the operations on the entries that the results
+// acknowledge, i.e. that were programmed in the RIB or the FIB.  The entries
+// are matched to their results by their operation IDs in ids.  It returns an
+// error without recording anything if a result of an operation in ids is
+// for another entry.
+func (r *RIB) applyResults(op constants.OpType, entries []*gpb.AFTEntry, ids []uint64, results []*client.OpResult) error {
+	if err := checkResults(op, entries, ids, results); err != nil {
+		return err
+	}
+	best := bestResults(results)
+	for i, e := range entries {
+		if ackRank(best[ids[i]]) == 0 {
+			continue
+		}
+		if err := r.Apply(op, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bestResults returns the best result of each operation, by operation ID.
+func bestResults(results []*client.OpResult) map[uint64]gpb.AFTResult_Status {
+	best := make(map[uint64]gpb.AFTResult_Status)
+	for _, res := range results {
+		if res.OperationID == 0 {
+			continue
+		}
+		if status := res.ProgrammingResult; ackRank(status) > ackRank(best[res.OperationID]) {
+			best[res.OperationID] = status
+		}
+	}
+	return best
+}
+
+// checkResults checks that the results of the operations in ids are for
+// the operations on the entries, which they are not if the operation IDs of
+// the entries were mispredicted.
+func checkResults(op constants.OpType, entries []*gpb.AFTEntry, ids []uint64, results []*client.OpResult) error {
+	details := make(map[uint64]*client.OpDetailsResults)
+	for _, res := range results {
+		if res.Details != nil {
+			details[res.OperationID] = res.Details
+		}
+	}
+	for i, e := range entries {
+		d, ok := details[ids[i]]
+		if !ok {
+			continue
+		}
+		if d.Type != op || resultKey(d) != entryKey(e) {
+			return fmt.Errorf("result of operation %d is for %v %s, not %v %s", ids[i], d.Type, resultKey(d), op, entryKey(e))
+		}
+	}
+	return nil
+}
+
+// resultKey returns the key of the entry of a result, which is the same as
+// its entryKey.
+func resultKey(d *client.OpDetailsResults) string {
+	switch {
+	case d.NextHopIndex != 0:
+		return fmt.Sprintf("next-hop %d", d.NextHopIndex)
+	case d.NextHopGroupID != 0:
+		return fmt.Sprintf("next-hop-group %d", d.NextHopGroupID)
+	case d.IPv4Prefix != "":
+		return "ipv4 " + d.IPv4Prefix
+	case d.IPv6Prefix != "":
+		return "ipv6 " + d.IPv6Prefix
+	default:
+		return fmt.Sprintf("mpls %d", d.MPLSLabel)
+	}
+}
+
+// entryKey returns a key of an entry, which is unique in its network
+// instance.
+func entryKey(entry *gpb.AFTEntry) string {
+	switch e := entry.GetEntry().(type) {
+	case *gpb.AFTEntry_NextHop:
+		return fmt.Sprintf("next-hop %d", e.NextHop.GetIndex())
+	case *gpb.AFTEntry_NextHopGroup:
+		return fmt.Sprintf("next-hop-group %d", e.NextHopGroup.GetId())
+	case *gpb.AFTEntry_Ipv4:
+		return "ipv4 " + e.Ipv4.GetPrefix()
+	case *gpb.AFTEntry_Ipv6:
+		return "ipv6 " + e.Ipv6.GetPrefix()
+	case *gpb.AFTEntry_Mpls:
+		return fmt.Sprintf("mpls %d", e.Mpls.GetLabelUint64())
+	default:
+		return entry.String()
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/constants"
+	"github.com/openconfig/gribigo/fluent"
+
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+func entryProto(t *testing.T, e fluent.GRIBIEntry) *gpb.AFTEntry {
+	t.Helper()
+	ep, err := e.EntryProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ep
+}
+
+func result(id uint64, op constants.OpType, status gpb.AFTResult_Status, d *client.OpDetailsResults) *client.OpResult {
+	d.Type = op
+	return &client.OpResult{OperationID: id, ProgrammingResult: status, Details: d}
+}
+
+// newTestRIB returns a RIB with a prefix in a VRF that resolves through a
+// group with two next hops and a backup group in the default network
+// instance.
+func newTestRIB(t *testing.T) *RIB {
+	t.Helper()
+	r := NewRIB()
+	entries := []fluent.GRIBIEntry{
+		fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(1).WithIPAddress("192.0.2.1"),
+		fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(2).WithIPAddress("192.0.2.5"),
+		fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(3).WithIPAddress("192.0.2.9"),
+		fluent.NextHopGroupEntry().WithNetworkInstance("DEFAULT").WithID(10).AddNextHop(1, 1).AddNextHop(2, 3).WithBackupNHG(20),
+		fluent.NextHopGroupEntry().WithNetworkInstance("DEFAULT").WithID(20).AddNextHop(3, 1),
+		fluent.IPv4Entry().WithNetworkInstance("VRF-A").WithPrefix("198.51.100.0/24").WithNextHopGroup(10).WithNextHopGroupNetworkInstance("DEFAULT"),
+	}
+	for _, e := range entries {
+		if err := r.Apply(constants.Add, entryProto(t, e)); err != nil {
+			t.Fatalf("Apply() got unexpected error: %v", err)
+		}
+	}
+	return r
+}
+
+func TestRIBApplyResults(t *testing.T) {
+	r := NewRIB()
+	entries := []*gpb.AFTEntry{
+		entryProto(t, fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(1).WithIPAddress("192.0.2.1")),
+		entryProto(t, fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(2).WithIPAddress("192.0.2.5")),
+		entryProto(t, fluent.IPv4Entry().WithNetworkInstance("DEFAULT").WithPrefix("198.51.100.0/24").WithNextHopGroup(10)),
+		entryProto(t, fluent.IPv4Entry().WithNetworkInstance("VRF-A").WithPrefix("198.51.100.0/24").WithNextHopGroup(10)),
+	}
+	results := []*client.OpResult{
+		result(1, constants.Add, gpb.AFTResult_RIB_PROGRAMMED, &client.OpDetailsResults{NextHopIndex: 1}),
+		result(1, constants.Add, gpb.AFTResult_FIB_PROGRAMMED, &client.OpDetailsResults{NextHopIndex: 1}),
+		result(2, constants.Add, gpb.AFTResult_FAILED, &client.OpDetailsResults{NextHopIndex: 2}),
+		// The same prefix in another network instance fails.
+		result(3, constants.Add, gpb.AFTResult_FIB_PROGRAMMED, &client.OpDetailsResults{IPv4Prefix: "198.51.100.0/24"}),
+		result(4, constants.Add, gpb.AFTResult_FAILED, &client.OpDetailsResults{IPv4Prefix: "198.51.100.0/24"}),
+	}
+	if err := r.applyResults(constants.Add, entries, []uint64{1, 2, 3, 4}, results); err != nil {
+		t.Fatalf("applyResults() got unexpected error: %v", err)
+	}
+	rib := r.NetworkInstance("DEFAULT")
+	if _, ok := rib.NextHops[1]; !ok {
+		t.Errorf("applyResults() did not record acknowledged next hop 1")
+	}
+	if _, ok := rib.NextHops[2]; ok {
+		t.Errorf("applyResults() recorded failed next hop 2")
+	}
+	if _, ok := rib.IPv4["198.51.100.0/24"]; !ok {
+		t.Errorf("applyResults() did not record acknowledged prefix in DEFAULT")
+	}
+	if got := len(r.NetworkInstance("VRF-A").IPv4); got != 0 {
+		t.Errorf("applyResults() recorded %d failed prefixes in VRF-A, want 0", got)
+	}
+
+	del := []*gpb.AFTEntry{entryProto(t, fluent.IPv4Entry().WithNetworkInstance("DEFAULT").WithPrefix("198.51.100.0/24"))}
+	results = []*client.OpResult{
+		result(5, constants.Delete, gpb.AFTResult_RIB_PROGRAMMED, &client.OpDetailsResults{IPv4Prefix: "198.51.100.0/24"}),
+	}
+	if err := r.applyResults(constants.Delete, del, []uint64{5}, results); err != nil {
+		t.Fatalf("applyResults() got unexpected error: %v", err)
+	}
+	if got := len(r.NetworkInstance("DEFAULT").IPv4); got != 0 {
+		t.Errorf("applyResults() of a delete left %d IPv4 entries, want 0", got)
+	}
+
+	r.Flush("DEFAULT")
+	if got := r.NetworkInstances(); len(got) != 0 {
+		t.Errorf("Flush() left network instances %v", got)
+	}
+}
+
+func TestRIBApplyMispredictedResults(t *testing.T) {
+	r := NewRIB()
+	entries := []*gpb.AFTEntry{
+		entryProto(t, fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(1).WithIPAddress("192.0.2.1")),
+		entryProto(t, fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(2).WithIPAddress("192.0.2.5")),
+	}
+	// Operation 1 was sent directly with the fluent client.
+	results := []*client.OpResult{
+		result(1, constants.Add, gpb.AFTResult_RIB_PROGRAMMED, &client.OpDetailsResults{NextHopIndex: 9}),
+		result(2, constants.Add, gpb.AFTResult_RIB_PROGRAMMED, &client.OpDetailsResults{NextHopIndex: 1}),
+	}
+	if err := r.applyResults(constants.Add, entries, []uint64{1, 2}, results); err == nil {
+		t.Errorf("applyResults() of mispredicted operation IDs got no error")
+	}
+	if got := r.NetworkInstances(); len(got) != 0 {
+		t.Errorf("applyResults() of mispredicted operation IDs recorded network instances %v", got)
+	}
+}
+
+func TestClientAfterFluentEntries(t *testing.T) {
+	c := newFakeClient(t)
+	nh := func(index uint64) fluent.GRIBIEntry {
+		return fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(index).WithIPAddress("192.0.2.1")
+	}
+	// The results of the entries sent directly are not awaited.
+	c.Fluent(t).Modify().AddEntry(t, nh(1), nh(2))
+	c.AddEntries(t, []fluent.GRIBIEntry{nh(3)}, nil)
+	c.AddEntries(t, []fluent.GRIBIEntry{nh(4)}, nil)
+
+	var got []uint64
+	for index := range c.RIB().NetworkInstance("DEFAULT").NextHops {
+		got = append(got, index)
+	}
+	slices.Sort(got)
+	if want := []uint64{3, 4}; !slices.Equal(got, want) {
+		t.Errorf("RIB() got next hops %v, want %v", got, want)
+	}
+}
+
+func TestClientFlushRIB(t *testing.T) {
+	c := &Client{}
+	if err := c.RIB().Apply(constants.Add, entryProto(t, fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(1))); err != nil {
+		t.Fatal(err)
+	}
+	c.flushRIB(t, &gpb.FlushResponse{Result: gpb.FlushResponse_NON_ZERO_REFERENCE_REMAIN}, "DEFAULT")
+	if got := len(c.RIB().NetworkInstance("DEFAULT").NextHops); got != 1 {
+		t.Errorf("flushRIB() of a failed flush left %d next hops, want 1", got)
+	}
+	c.flushRIB(t, &gpb.FlushResponse{Result: gpb.FlushResponse_OK}, "DEFAULT")
+	if got := c.RIB().NetworkInstances(); len(got) != 0 {
+		t.Errorf("flushRIB() of a successful flush left network instances %v", got)
+	}
+}
+
+// matchingAFTs returns the AFTs of the test RIB, with IDs that the device
+// assigned and without the programmed IDs.
+func matchingAFTs() map[string]*AFT {
+	def := newAFT()
+	def.NextHops[101] = &AFTNextHop{IPAddress: "192.0.2.1", Interface: "Ethernet1"}
+	def.NextHops[102] = &AFTNextHop{IPAddress: "192.0.2.5", Interface: "Ethernet2"}
+	def.NextHops[103] = &AFTNextHop{IPAddress: "192.0.2.9", Interface: "Ethernet3"}
+	def.NextHopGroups[1010] = &AFTNextHopGroup{Weights: map[uint64]uint64{101: 1, 102: 3}, BackupNextHopGroup: 1020}
+	def.NextHopGroups[1020] = &AFTNextHopGroup{Weights: map[uint64]uint64{103: 1}}
+	// A connected route is not reported as extra.
+	def.IPv4["192.0.2.0/30"] = &AFTEntry{NextHopGroup: 1030}
+	def.NextHopGroups[1030] = &AFTNextHopGroup{Weights: map[uint64]uint64{104: 0}}
+	def.NextHops[104] = &AFTNextHop{Interface: "Ethernet1"}
+	vrf := newAFT()
+	vrf.IPv4["198.51.100.0/24"] = &AFTEntry{NextHopGroup: 1010, NextHopGroupNetworkInstance: "DEFAULT"}
+	return map[string]*AFT{"DEFAULT": def, "VRF-A": vrf}
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		desc   string
+		modify func(map[string]*AFT)
+		want   []string
+	}{{
+		desc:   "matching",
+		modify: func(map[string]*AFT) {},
+	}, {
+		desc: "missing prefix",
+		modify: func(afts map[string]*AFT) {
+			delete(afts["VRF-A"].IPv4, "198.51.100.0/24")
+		},
+		want: []string{`network instance "VRF-A": missing ipv4 198.51.100.0/24`},
+	}, {
+		desc: "weight and backup",
+		modify: func(afts map[string]*AFT) {
+			afts["DEFAULT"].NextHopGroups[1010].Weights[102] = 1
+			afts["DEFAULT"].NextHops[103].IPAddress = "192.0.2.13"
+		},
+		want: []string{
+			`network instance "VRF-A": mismatched ipv4 198.51.100.0/24: next hop 2 has weight 1, want 3; ` +
+				`backup missing next hop 3; backup unexpected next hop 103 (192.0.2.13, Ethernet3)`,
+		},
+	}, {
+		desc: "programmed IDs",
+		modify: func(afts map[string]*AFT) {
+			def := afts["DEFAULT"]
+			def.NextHopGroups[1010].ProgrammedID = 10
+			def.NextHopGroups[1020].ProgrammedID = 21
+			def.NextHops[101].ProgrammedIndex = 1
+			def.NextHops[102].ProgrammedIndex = 2
+			def.NextHops[103].ProgrammedIndex = 3
+			def.NextHops[101].IPAddress = "192.0.2.2"
+			afts["VRF-A"].IPv4["203.0.113.0/24"] = &AFTEntry{NextHopGroup: 1010, NextHopGroupNetworkInstance: "DEFAULT"}
+		},
+		want: []string{
+			`network instance "DEFAULT": mismatched next-hop-group 10: backup next-hop-group is 21, want 20`,
+			`network instance "DEFAULT": missing next-hop-group 20`,
+			`network instance "DEFAULT": extra next-hop-group 21`,
+			`network instance "DEFAULT": mismatched next-hop 1: ip-address is "192.0.2.2", want "192.0.2.1"`,
+			`network instance "VRF-A": extra ipv4 203.0.113.0/24`,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			afts := matchingAFTs()
+			test.modify(afts)
+			var got []string
+			for _, d := range newTestRIB(t).Reconcile(afts) {
+				got = append(got, d.String())
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Reconcile() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
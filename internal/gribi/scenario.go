@@ -0,0 +1,341 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/openconfig/gribigo/chk"
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// ScenarioClient is a client of a Scenario, with its own gRIBI session.
+type ScenarioClient struct {
+	// Name identifies the client in the steps of the scenario.
+	Name string
+	// Stub is the gRIBI stub of the client, e.g. dialed with a dial profile
+	// of the binding to connect as another user.  Defaults to the gRIBI stub
+	// of the DUT.
+	Stub gpb.GRIBIClient
+	// RedundancyMode defaults to fluent.ElectedPrimaryClient.
+	RedundancyMode fluent.RedundancyMode
+	Persistence    bool
+	FIBACK         bool
+	// ElectionID is the initial election ID of an elected client, which
+	// defaults to 1.
+	ElectionID Uint128
+}
+
+type scenarioClient struct {
+	*ScenarioClient
+	fluentC    *fluent.GRIBIClient
+	electionID Uint128
+}
+
+func (c *scenarioClient) elected() bool {
+	return c.RedundancyMode == fluent.ElectedPrimaryClient
+}
+
+// Scenario drives several gRIBI clients of a DUT through scripted steps,
+// such as one client bumping its election ID and another then trying to
+// modify the entries, and checks the results of every step and which client
+// ends up primary.
+//
+// Usage:
+//
+//	s := gribi.NewScenario(t, dut,
+//	  &gribi.ScenarioClient{Name: "A", Persistence: true},
+//	  &gribi.ScenarioClient{Name: "B", Persistence: true})
+//	defer s.Close(t)
+//	s.Run(t,
+//	  gribi.BumpElectionID("A").WantPrimary("A"),
+//	  gribi.BumpElectionID("B").WantPrimary("B"),
+//	  gribi.AddEntries("A", nh).WantResults(failed))
+type Scenario struct {
+	clients map[string]*scenarioClient
+	// serverID is the election ID of the server that was last reported to a
+	// client.
+	serverID *Uint128
+}
+
+// NewScenario connects the clients to the DUT.  The DUT may be nil if every
+// client has a stub.
+func NewScenario(t testing.TB, dut *ondatra.DUTDevice, clients ...*ScenarioClient) *Scenario {
+	t.Helper()
+	s := &Scenario{clients: make(map[string]*scenarioClient)}
+	for _, sc := range clients {
+		if _, ok := s.clients[sc.Name]; ok {
+			t.Fatalf("Duplicate gRIBI scenario client %q", sc.Name)
+		}
+		c := &scenarioClient{ScenarioClient: sc, electionID: sc.ElectionID}
+		if c.RedundancyMode == 0 {
+			c.RedundancyMode = fluent.ElectedPrimaryClient
+		}
+		if c.electionID == (Uint128{}) {
+			c.electionID = Uint128{Low: 1}
+		}
+		if c.Stub == nil {
+			c.Stub = dut.RawAPIs().GRIBI(t)
+		}
+		s.clients[sc.Name] = c
+		if err := s.connect(t, c); err != nil {
+			t.Fatalf("Could not connect gRIBI client %q: %v", sc.Name, err)
+		}
+	}
+	return s
+}
+
+func (s *Scenario) connect(t testing.TB, c *scenarioClient) error {
+	c.fluentC = fluent.NewClient()
+	conn := c.fluentC.Connection().WithStub(c.Stub).WithRedundancyMode(c.RedundancyMode)
+	if c.elected() {
+		conn.WithInitialElectionID(c.electionID.Low, c.electionID.High)
+	}
+	if c.Persistence {
+		conn.WithPersistence()
+	}
+	if c.FIBACK {
+		conn.WithFIBACK()
+	}
+	ctx := context.Background()
+	c.fluentC.Start(ctx, t)
+	c.fluentC.StartSending(ctx, t)
+	err := awaitTimeout(ctx, t, c.fluentC, timeout)
+	s.learnServerID(c.fluentC.Results(t))
+	return err
+}
+
+// Close disconnects the clients.
+func (s *Scenario) Close(t testing.TB) {
+	for _, c := range s.clients {
+		if c.fluentC != nil {
+			c.fluentC.Stop(t)
+			c.fluentC = nil
+		}
+	}
+}
+
+// Fluent returns the fluent client of a client of the scenario, or nil if
+// it is disconnected.
+func (s *Scenario) Fluent(t testing.TB, name string) *fluent.GRIBIClient {
+	t.Helper()
+	return s.client(t, name).fluentC
+}
+
+// ElectionID returns the election ID of a client of the scenario.
+func (s *Scenario) ElectionID(t testing.TB, name string) Uint128 {
+	t.Helper()
+	return s.client(t, name).electionID
+}
+
+// Primary returns the name of the connected elected client whose election ID
+// is the election ID of the server, or "" if there is none.
+func (s *Scenario) Primary() string {
+	if s.serverID == nil {
+		return ""
+	}
+	for name, c := range s.clients {
+		if c.fluentC != nil && c.elected() && c.electionID == *s.serverID {
+			return name
+		}
+	}
+	return ""
+}
+
+func (s *Scenario) client(t testing.TB, name string) *scenarioClient {
+	t.Helper()
+	c, ok := s.clients[name]
+	if !ok {
+		t.Fatalf("No gRIBI scenario client %q", name)
+	}
+	return c
+}
+
+// learnServerID records the election ID of the server reported in the
+// results.
+func (s *Scenario) learnServerID(results []*client.OpResult) {
+	for _, res := range results {
+		if id := res.CurrentServerElectionID; id != nil {
+			s.serverID = &Uint128{Low: id.Low, High: id.High}
+		}
+	}
+}
+
+// Step is a step of a scenario, which is run by one of its clients.
+type Step struct {
+	desc        string
+	client      string
+	run         func(t testing.TB, s *Scenario, c *scenarioClient) error
+	connects    bool
+	wantResults []*client.OpResult
+	wantErr     *status.Status
+	wantPrimary *string
+}
+
+// SetElectionID returns a step in which the client sends an election ID.
+func SetElectionID(client string, id Uint128) *Step {
+	return &Step{
+		desc:   fmt.Sprintf("%s sets election ID low=%d, high=%d", client, id.Low, id.High),
+		client: client,
+		run: func(t testing.TB, _ *Scenario, c *scenarioClient) error {
+			c.fluentC.Modify().UpdateElectionID(t, id.Low, id.High)
+			c.electionID = id
+			return awaitTimeout(context.Background(), t, c.fluentC, timeout)
+		},
+	}
+}
+
+// BumpElectionID returns a step in which the client learns the election ID
+// of the server and sends one that is one higher, as BecomeLeader does.
+func BumpElectionID(client string) *Step {
+	return &Step{
+		desc:   fmt.Sprintf("%s bumps its election ID", client),
+		client: client,
+		run: func(t testing.TB, _ *Scenario, c *scenarioClient) error {
+			c.electionID = BecomeLeader(t, c.fluentC)
+			return nil
+		},
+	}
+}
+
+// AddEntries returns a step in which the client adds the entries.
+func AddEntries(client string, entries ...fluent.GRIBIEntry) *Step {
+	return &Step{
+		desc:   fmt.Sprintf("%s adds %d entries", client, len(entries)),
+		client: client,
+		run: func(t testing.TB, _ *Scenario, c *scenarioClient) error {
+			c.fluentC.Modify().AddEntry(t, entries...)
+			return awaitTimeout(context.Background(), t, c.fluentC, timeout)
+		},
+	}
+}
+
+// DeleteEntries returns a step in which the client deletes the entries.
+func DeleteEntries(client string, entries ...fluent.GRIBIEntry) *Step {
+	return &Step{
+		desc:   fmt.Sprintf("%s deletes %d entries", client, len(entries)),
+		client: client,
+		run: func(t testing.TB, _ *Scenario, c *scenarioClient) error {
+			c.fluentC.Modify().DeleteEntry(t, entries...)
+			return awaitTimeout(context.Background(), t, c.fluentC, timeout)
+		},
+	}
+}
+
+// Disconnect returns a step in which the client closes its session.
+func Disconnect(client string) *Step {
+	return &Step{
+		desc:   fmt.Sprintf("%s disconnects", client),
+		client: client,
+		run: func(t testing.TB, _ *Scenario, c *scenarioClient) error {
+			c.fluentC.Stop(t)
+			c.fluentC = nil
+			return nil
+		},
+	}
+}
+
+// Reconnect returns a step in which the client opens a new session with its
+// last election ID, e.g. after a failure or a Disconnect step.
+func Reconnect(client string) *Step {
+	return &Step{
+		desc:     fmt.Sprintf("%s reconnects", client),
+		client:   client,
+		connects: true,
+		run: func(t testing.TB, s *Scenario, c *scenarioClient) error {
+			if c.fluentC != nil {
+				c.fluentC.Stop(t)
+			}
+			return s.connect(t, c)
+		},
+	}
+}
+
+// WithDesc sets the description of the step in the test log.
+func (st *Step) WithDesc(desc string) *Step {
+	st.desc = desc
+	return st
+}
+
+// WantResults sets the results that the step expects, which are checked
+// with chk.HasResult ignoring the operation IDs.
+func (st *Step) WantResults(results ...*client.OpResult) *Step {
+	st.wantResults = append(st.wantResults, results...)
+	return st
+}
+
+// WantError sets the error that the step expects the session to fail with,
+// e.g. a fluent.ModifyError.  The client must then reconnect to run more
+// steps.
+func (st *Step) WantError(want *status.Status) *Step {
+	st.wantErr = want
+	return st
+}
+
+// WantPrimary sets the client that the step expects to be primary after
+// it, or "" if none.
+func (st *Step) WantPrimary(client string) *Step {
+	st.wantPrimary = &client
+	return st
+}
+
+// Run runs the steps in order.
+func (s *Scenario) Run(t testing.TB, steps ...*Step) {
+	t.Helper()
+	for i, st := range steps {
+		t.Logf("gRIBI scenario step %d: %s", i+1, st.desc)
+		c := s.client(t, st.client)
+		if c.fluentC == nil && !st.connects {
+			t.Fatalf("Step %d (%s): client %q is disconnected", i+1, st.desc, st.client)
+		}
+		fluentC, n := c.fluentC, 0
+		if fluentC != nil {
+			n = len(fluentC.Results(t))
+		}
+		err := st.run(t, s, c)
+		var results []*client.OpResult
+		if c.fluentC != nil {
+			results = c.fluentC.Results(t)
+			if c.fluentC == fluentC {
+				results = results[n:]
+			}
+		}
+		s.learnServerID(results)
+
+		switch {
+		case st.wantErr != nil && err == nil:
+			t.Fatalf("Step %d (%s): got no error, want %v", i+1, st.desc, st.wantErr)
+		case st.wantErr != nil:
+			chk.HasRecvClientErrorWithStatus(t, err, st.wantErr, chk.IgnoreDetails())
+		case err != nil:
+			t.Fatalf("Step %d (%s): got unexpected error: %v", i+1, st.desc, err)
+		}
+		for _, want := range st.wantResults {
+			chk.HasResult(t, results, want, chk.IgnoreOperationID())
+		}
+		if st.wantPrimary != nil {
+			if got := s.Primary(); got != *st.wantPrimary {
+				t.Errorf("Step %d (%s): got primary %q, want %q", i+1, st.desc, got, *st.wantPrimary)
+			}
+		}
+	}
+}
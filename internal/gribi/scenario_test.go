@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gribi
+
+import (
+	"testing"
+
+	"github.com/openconfig/gribigo/client"
+	"github.com/openconfig/gribigo/constants"
+	"github.com/openconfig/gribigo/fluent"
+)
+
+func TestScenario(t *testing.T) {
+	stub := newFakeStub(t)
+	s := NewScenario(t, nil,
+		&ScenarioClient{Name: "A", Stub: stub, Persistence: true},
+		&ScenarioClient{Name: "B", Stub: stub, Persistence: true},
+	)
+	defer s.Close(t)
+
+	nh := func(index uint64) fluent.GRIBIEntry {
+		return fluent.NextHopEntry().WithNetworkInstance("DEFAULT").WithIndex(index).WithIPAddress("192.0.2.1")
+	}
+	nhResult := func(index uint64, res fluent.ProgrammingResult) *client.OpResult {
+		return fluent.OperationResult().
+			WithNextHopOperation(index).
+			WithOperationType(constants.Add).
+			WithProgrammingResult(res).
+			AsResult()
+	}
+
+	s.Run(t,
+		BumpElectionID("A").WantPrimary("A"),
+		AddEntries("A", nh(1)).WantResults(nhResult(1, fluent.InstalledInRIB)),
+		SetElectionID("B", Uint128{Low: 10}).WantPrimary("B"),
+		AddEntries("A", nh(2)).
+			WithDesc("A is no longer primary").
+			WantResults(nhResult(2, fluent.ProgrammingFailed)),
+		Disconnect("B").WantPrimary(""),
+		BumpElectionID("A").WantPrimary("A"),
+		Reconnect("B").WantPrimary("A"),
+	)
+	if got, want := s.ElectionID(t, "A"), (Uint128{Low: 11}); got != want {
+		t.Errorf("ElectionID(A) got %v, want %v", got, want)
+	}
+}
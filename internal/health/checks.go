@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/ocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	hpb "github.com/openconfig/gnoi/healthz"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+func init() {
+	RegisterCheck(healthzCheck{})
+	RegisterCheck(processCheck{})
+	RegisterCheck(rebootCheck{})
+	RegisterCheck(alarmCheck{})
+}
+
+// healthzCheck finds the components that became unhealthy according to gNOI
+// Healthz.
+type healthzCheck struct{}
+
+func (healthzCheck) Name() string { return "healthz" }
+
+func (healthzCheck) Baseline(ctx context.Context, dut *DUT) (any, error) {
+	return unhealthyComponents(ctx, dut)
+}
+
+func (healthzCheck) Verify(ctx context.Context, dut *DUT, baseline any) ([]string, error) {
+	unhealthy, err := unhealthyComponents(ctx, dut)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, name := range newKeys(baseline.(map[string]bool), unhealthy) {
+		problems = append(problems, fmt.Sprintf("component %q is unhealthy", name))
+	}
+	return problems, nil
+}
+
+// unhealthyComponents returns the names of the components that gNOI Healthz
+// reports as unhealthy.  Components without a health status are ignored.
+func unhealthyComponents(ctx context.Context, dut *DUT) (map[string]bool, error) {
+	y, err := dut.GNMI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, err := ygnmi.GetAll(ctx, y, ocpath.Root().ComponentAny().Name().State())
+	if err != nil {
+		return nil, err
+	}
+	gnoic, err := dut.GNOI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unhealthy := map[string]bool{}
+	for _, name := range names {
+		resp, err := gnoic.Healthz().Get(ctx, &hpb.GetRequest{
+			Path: &tpb.Path{
+				Origin: "openconfig",
+				Elem: []*tpb.PathElem{
+					{Name: "components"},
+					{Name: "component", Key: map[string]string{"name": name}},
+				},
+			},
+		})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return nil, err
+		case err != nil:
+			glog.V(2).Infof("DUT %q has no health status for component %q: %v", dut.Name(), name, err)
+		case resp.GetComponent().GetStatus() == hpb.Status_STATUS_UNHEALTHY:
+			unhealthy[name] = true
+		}
+	}
+	return unhealthy, nil
+}
+
+// processCheck finds the processes that were restarted.
+type processCheck struct{}
+
+func (processCheck) Name() string { return "process_restarts" }
+
+func (processCheck) Baseline(ctx context.Context, dut *DUT) (any, error) {
+	return processes(ctx, dut)
+}
+
+func (processCheck) Verify(ctx context.Context, dut *DUT, baseline any) ([]string, error) {
+	after, err := processes(ctx, dut)
+	if err != nil {
+		return nil, err
+	}
+	return restartedProcesses(baseline.(map[string][]process), after), nil
+}
+
+// process identifies an instance of a process.  The start time tells apart
+// the processes that reuse a PID.
+type process struct {
+	pid       uint64
+	startTime uint64
+}
+
+// processes returns the running processes by name.
+func processes(ctx context.Context, dut *DUT) (map[string][]process, error) {
+	y, err := dut.GNMI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	procs, err := ygnmi.GetAll(ctx, y, ocpath.Root().System().ProcessAny().State())
+	if err != nil {
+		return nil, err
+	}
+	return processesByName(procs), nil
+}
+
+func processesByName(procs []*oc.System_Process) map[string][]process {
+	m := map[string][]process{}
+	for _, p := range procs {
+		m[p.GetName()] = append(m[p.GetName()], process{pid: p.GetPid(), startTime: p.GetStartTime()})
+	}
+	return m
+}
+
+// restartedProcesses returns a problem for each process name that is still
+// running after, but none of whose instances in before are.
+func restartedProcesses(before, after map[string][]process) []string {
+	var problems []string
+	var names []string
+	for name := range before {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		running := map[process]bool{}
+		for _, p := range after[name] {
+			running[p] = true
+		}
+		if len(running) == 0 {
+			// Not a restart: the process exited.
+			continue
+		}
+		restarted := true
+		for _, p := range before[name] {
+			if running[p] {
+				restarted = false
+				break
+			}
+		}
+		if restarted {
+			problems = append(problems, fmt.Sprintf("process %q restarted: PIDs %v became %v", name, pids(before[name]), pids(after[name])))
+		}
+	}
+	return problems
+}
+
+func pids(procs []process) []uint64 {
+	var ids []uint64
+	for _, p := range procs {
+		ids = append(ids, p.pid)
+	}
+	return ids
+}
+
+// rebootCheck finds whether the DUT rebooted.
+type rebootCheck struct{}
+
+func (rebootCheck) Name() string { return "reboot" }
+
+func (rebootCheck) Baseline(ctx context.Context, dut *DUT) (any, error) {
+	return bootTime(ctx, dut)
+}
+
+func (rebootCheck) Verify(ctx context.Context, dut *DUT, baseline any) ([]string, error) {
+	after, err := bootTime(ctx, dut)
+	if err != nil {
+		return nil, err
+	}
+	return rebooted(baseline.(uint64), after), nil
+}
+
+// bootTime returns the boot time of the DUT in nanoseconds since the epoch.
+func bootTime(ctx context.Context, dut *DUT) (uint64, error) {
+	y, err := dut.GNMI(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ygnmi.Get(ctx, y, ocpath.Root().System().BootTime().State())
+}
+
+func rebooted(before, after uint64) []string {
+	if before == after {
+		return nil
+	}
+	return []string{fmt.Sprintf("DUT rebooted at %v", time.Unix(0, int64(after)).UTC())}
+}
+
+// alarmCheck finds the new major and critical alarms.
+type alarmCheck struct{}
+
+func (alarmCheck) Name() string { return "alarms" }
+
+func (alarmCheck) Baseline(ctx context.Context, dut *DUT) (any, error) {
+	return alarms(ctx, dut)
+}
+
+func (alarmCheck) Verify(ctx context.Context, dut *DUT, baseline any) ([]string, error) {
+	after, err := alarms(ctx, dut)
+	if err != nil {
+		return nil, err
+	}
+	return newAlarms(baseline.(map[string]*oc.System_Alarm), after), nil
+}
+
+// alarms returns the alarms of the DUT by ID.
+func alarms(ctx context.Context, dut *DUT) (map[string]*oc.System_Alarm, error) {
+	y, err := dut.GNMI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, err := ygnmi.GetAll(ctx, y, ocpath.Root().System().AlarmAny().State())
+	if err != nil && !errors.Is(err, ygnmi.ErrNotPresent) {
+		return nil, err
+	}
+	m := map[string]*oc.System_Alarm{}
+	for _, a := range all {
+		m[a.GetId()] = a
+	}
+	return m, nil
+}
+
+func newAlarms(before, after map[string]*oc.System_Alarm) []string {
+	var problems []string
+	var ids []string
+	for id := range after {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := before[id]; ok {
+			continue
+		}
+		a := after[id]
+		switch a.GetSeverity() {
+		case oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_MAJOR, oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_CRITICAL:
+			problems = append(problems, fmt.Sprintf("new %v alarm %q on %q: %s", a.GetSeverity(), id, a.GetResource(), a.GetText()))
+		}
+	}
+	return problems
+}
+
+// newKeys returns the sorted keys of after that are not in before.
+func newKeys(before, after map[string]bool) []string {
+	var keys []string
+	for k := range after {
+		if !before[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,394 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health provides a sweep of pluggable health checks that runs on the
+// DUTs before and after the tests, to find the tests that leave a DUT
+// unhealthy.
+//
+// Each Check takes a baseline of the DUT before the tests and compares the
+// DUT against it after the tests.  The built-in checks are:
+//
+//   - healthz - components that became unhealthy according to gNOI Healthz.
+//   - process_restarts - processes that were restarted, according to the
+//     process IDs and start times in /system/processes.
+//   - reboot - the DUT rebooted, according to /system/state/boot-time.
+//   - alarms - new major or critical alarms in /system/alarms.
+//
+// More checks may be added with RegisterCheck.  The sweep is enabled with
+// -health_sweep, and the checks to run may be selected with -health_checks.
+//
+// After the tests, the sweep reports the following test properties:
+//
+//   - health.healthy - false if a check found a problem on any DUT.
+//   - health.<dut>.<check> - the result of each check on each DUT: OK,
+//     UNHEALTHY or ERROR if the check could not be run.
+//   - health.report - the whole report as JSON.
+//   - health.test.<test> - healthy or unhealthy, for each test that called
+//     Track.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/glog"
+	"github.com/openconfig/gnoigo"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/eventlis"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+var (
+	sweepEnabled = flag.Bool("health_sweep", false, "Check the health of the DUTs before and after the tests, and fail the tests if a DUT becomes unhealthy.")
+	checkNames   = flag.String("health_checks", "", "Comma separated list of the health checks to run with -health_sweep; if not specified, runs all registered checks.")
+)
+
+// Check is a health check plugin.
+type Check interface {
+	// Name is the name of the check in the report and the test properties.
+	Name() string
+	// Baseline returns the state of the DUT before the tests, which is
+	// passed to Verify after the tests.
+	Baseline(ctx context.Context, dut *DUT) (any, error)
+	// Verify returns the problems found on the DUT after the tests, compared
+	// to the baseline, or none if the DUT is still healthy.
+	Verify(ctx context.Context, dut *DUT, baseline any) ([]string, error)
+}
+
+// DUT is a DUT being checked.  The gNMI and gNOI clients are dialed once and
+// shared by the checks.
+type DUT struct {
+	binding.DUT
+
+	mu   sync.Mutex
+	gnmi *ygnmi.Client
+	gnoi gnoigo.Clients
+}
+
+// GNMI returns a ygnmi client for the DUT.
+func (d *DUT) GNMI(ctx context.Context) (*ygnmi.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.gnmi == nil {
+		gnmic, err := d.DialGNMI(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if d.gnmi, err = ygnmi.NewClient(gnmic); err != nil {
+			return nil, err
+		}
+	}
+	return d.gnmi, nil
+}
+
+// GNOI returns the gNOI clients for the DUT.
+func (d *DUT) GNOI(ctx context.Context) (gnoigo.Clients, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.gnoi == nil {
+		gnoic, err := d.DialGNOI(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d.gnoi = gnoic
+	}
+	return d.gnoi, nil
+}
+
+var (
+	checksMu sync.Mutex
+	checks   []Check
+)
+
+// RegisterCheck adds a check to the health sweep.
+func RegisterCheck(c Check) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	checks = append(checks, c)
+}
+
+// enabledChecks returns the registered checks selected by -health_checks.
+func enabledChecks() ([]Check, error) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	if *checkNames == "" {
+		return append([]Check(nil), checks...), nil
+	}
+	byName := map[string]Check{}
+	for _, c := range checks {
+		byName[c.Name()] = c
+	}
+	var enabled []Check
+	for _, name := range strings.Split(*checkNames, ",") {
+		c, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown health check %q", name)
+		}
+		enabled = append(enabled, c)
+	}
+	return enabled, nil
+}
+
+// Result is the result of a check on a DUT.
+type Result struct {
+	DUT      string   `json:"dut"`
+	Check    string   `json:"check"`
+	Problems []string `json:"problems,omitempty"`
+	// Error is set if the check could not be run.
+	Error string `json:"error,omitempty"`
+}
+
+// status returns OK, UNHEALTHY or ERROR.
+func (r Result) status() string {
+	switch {
+	case len(r.Problems) > 0:
+		return "UNHEALTHY"
+	case r.Error != "":
+		return "ERROR"
+	}
+	return "OK"
+}
+
+// Report is the result of a health sweep.
+type Report struct {
+	Healthy bool     `json:"healthy"`
+	Results []Result `json:"results"`
+	// UnhealthyTests are the tests that called Track and left a DUT
+	// unhealthy.
+	UnhealthyTests []string `json:"unhealthy_tests,omitempty"`
+}
+
+// String summarizes the problems found by the sweep.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, res := range r.Results {
+		for _, p := range res.Problems {
+			fmt.Fprintf(&b, "\n  DUT %s: %s: %s", res.DUT, res.Check, p)
+		}
+	}
+	return b.String()
+}
+
+// sweep runs the checks on the DUTs.
+type sweep struct {
+	checks []Check
+	duts   map[string]*DUT
+
+	// baselines maps DUT names and check names to the baseline of the check,
+	// or to the error taking it.
+	baselines map[string]map[string]any
+	errs      map[string]map[string]error
+}
+
+func newSweep(checks []Check, duts map[string]*DUT) *sweep {
+	return &sweep{
+		checks:    checks,
+		duts:      duts,
+		baselines: map[string]map[string]any{},
+		errs:      map[string]map[string]error{},
+	}
+}
+
+// forEachDUT calls f for each DUT in parallel.
+func (s *sweep) forEachDUT(f func(name string, dut *DUT)) {
+	var wg sync.WaitGroup
+	for name, dut := range s.duts {
+		wg.Add(1)
+		go func(name string, dut *DUT) {
+			defer wg.Done()
+			f(name, dut)
+		}(name, dut)
+	}
+	wg.Wait()
+}
+
+// start takes the baselines of the checks on every DUT.
+func (s *sweep) start(ctx context.Context) {
+	var mu sync.Mutex
+	s.forEachDUT(func(name string, dut *DUT) {
+		baselines := map[string]any{}
+		errs := map[string]error{}
+		for _, c := range s.checks {
+			b, err := c.Baseline(ctx, dut)
+			if err != nil {
+				glog.Warningf("DUT %q failed to take the baseline of health check %s: %v", name, c.Name(), err)
+				errs[c.Name()] = err
+				continue
+			}
+			baselines[c.Name()] = b
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		s.baselines[name] = baselines
+		s.errs[name] = errs
+	})
+}
+
+// verify runs the checks on every DUT against their baselines.
+func (s *sweep) verify(ctx context.Context) *Report {
+	var mu sync.Mutex
+	report := &Report{Healthy: true}
+	s.forEachDUT(func(name string, dut *DUT) {
+		var results []Result
+		for _, c := range s.checks {
+			res := Result{DUT: name, Check: c.Name()}
+			if err := s.errs[name][c.Name()]; err != nil {
+				res.Error = err.Error()
+			} else if problems, err := c.Verify(ctx, dut, s.baselines[name][c.Name()]); err != nil {
+				glog.Warningf("DUT %q failed to run health check %s: %v", name, c.Name(), err)
+				res.Error = err.Error()
+			} else {
+				res.Problems = problems
+			}
+			results = append(results, res)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		report.Results = append(report.Results, results...)
+	})
+	sort.Slice(report.Results, func(i, j int) bool {
+		ri, rj := report.Results[i], report.Results[j]
+		if ri.DUT != rj.DUT {
+			return ri.DUT < rj.DUT
+		}
+		return ri.Check < rj.Check
+	})
+	for _, res := range report.Results {
+		if len(res.Problems) > 0 {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+var (
+	// mu guards the variables below.
+	mu sync.Mutex
+	// suite is the sweep of the whole test run.
+	suite *sweep
+	// testResults maps the names of the tests that called Track to whether
+	// they left the DUTs healthy.
+	testResults = map[string]bool{}
+)
+
+// Register installs the health sweep in the event listener, so that it runs
+// on all the DUTs in the reservation before and after the tests.  The sweep
+// only runs with -health_sweep.
+func Register() {
+	ondatra.EventListener().AddBeforeTestsCallback(registerBefore)
+	ondatra.EventListener().AddAfterTestsCallback(registerAfter)
+}
+
+func registerBefore(e *eventlis.BeforeTestsEvent) error {
+	if !*sweepEnabled {
+		return nil
+	}
+	checks, err := enabledChecks()
+	if err != nil {
+		return err
+	}
+	duts := map[string]*DUT{}
+	for name, dut := range e.Reservation.DUTs {
+		duts[name] = &DUT{DUT: dut}
+	}
+	s := newSweep(checks, duts)
+	s.start(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	suite = s
+	testResults = map[string]bool{}
+	ondatra.Report().AddSuiteProperty("health.sweep", "enabled")
+	return nil
+}
+
+func registerAfter(_ *eventlis.AfterTestsEvent) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if suite == nil {
+		return nil
+	}
+	report := suite.verify(context.Background())
+	for name, healthy := range testResults {
+		if !healthy {
+			report.UnhealthyTests = append(report.UnhealthyTests, name)
+		}
+	}
+	sort.Strings(report.UnhealthyTests)
+	for k, v := range properties(report, testResults) {
+		ondatra.Report().AddSuiteProperty(k, v)
+	}
+	if !report.Healthy {
+		return fmt.Errorf("health sweep found problems:%s", report)
+	}
+	return nil
+}
+
+// properties returns the test properties of the report.  See the package
+// documentation.
+func properties(report *Report, tests map[string]bool) map[string]string {
+	m := map[string]string{
+		"health.healthy": fmt.Sprint(report.Healthy),
+	}
+	for _, res := range report.Results {
+		m["health."+res.DUT+"."+res.Check] = res.status()
+	}
+	b, err := json.Marshal(report)
+	if err != nil {
+		b = []byte(fmt.Sprintf("unable to marshal health report: %v", err))
+	}
+	m["health.report"] = string(b)
+	for name, healthy := range tests {
+		if healthy {
+			m["health.test."+name] = "healthy"
+		} else {
+			m["health.test."+name] = "unhealthy"
+		}
+	}
+	return m
+}
+
+// Track runs the health checks before and after t, and fails t if it leaves
+// a DUT unhealthy.  Track reuses the DUTs and the checks of the sweep, so it
+// does nothing without -health_sweep.  The checks compare the state of the
+// whole DUT, so t is also failed for damage done by tests running in
+// parallel with it.
+func Track(t testing.TB) {
+	t.Helper()
+	mu.Lock()
+	s := suite
+	mu.Unlock()
+	if s == nil {
+		t.Log("Not tracking the health of the DUTs without -health_sweep.")
+		return
+	}
+	ts := newSweep(s.checks, s.duts)
+	ts.start(context.Background())
+	t.Cleanup(func() {
+		report := ts.verify(context.Background())
+		mu.Lock()
+		testResults[t.Name()] = report.Healthy
+		mu.Unlock()
+		if !report.Healthy {
+			t.Errorf("Test left the DUTs unhealthy:%s", report)
+		}
+	})
+}
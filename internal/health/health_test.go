@@ -0,0 +1,314 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/eventlis"
+	"github.com/openconfig/ondatra/fakebind"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// fakeCheck reports a problem on the DUTs whose counter changed.
+type fakeCheck struct {
+	name string
+	// counters maps DUT names to the values returned by successive calls.
+	counters map[string][]int
+	errs     map[string]error
+}
+
+func (c *fakeCheck) Name() string { return c.name }
+
+func (c *fakeCheck) next(dut *DUT) (int, error) {
+	if err := c.errs[dut.Name()]; err != nil {
+		return 0, err
+	}
+	values := c.counters[dut.Name()]
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no more values")
+	}
+	c.counters[dut.Name()] = values[1:]
+	return values[0], nil
+}
+
+func (c *fakeCheck) Baseline(_ context.Context, dut *DUT) (any, error) {
+	return c.next(dut)
+}
+
+func (c *fakeCheck) Verify(_ context.Context, dut *DUT, baseline any) ([]string, error) {
+	v, err := c.next(dut)
+	if err != nil {
+		return nil, err
+	}
+	if v != baseline.(int) {
+		return []string{fmt.Sprintf("counter changed from %d to %d", baseline, v)}, nil
+	}
+	return nil, nil
+}
+
+func fakeDUTs(names ...string) map[string]*DUT {
+	duts := map[string]*DUT{}
+	for _, name := range names {
+		duts[name] = &DUT{DUT: &fakebind.DUT{
+			AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: name}},
+		}}
+	}
+	return duts
+}
+
+func TestSweep(t *testing.T) {
+	checks := []Check{
+		&fakeCheck{
+			name: "counter",
+			counters: map[string][]int{
+				"dut1": {1, 1},
+				"dut2": {1, 2},
+			},
+		},
+		&fakeCheck{
+			name: "broken",
+			errs: map[string]error{"dut1": fmt.Errorf("broken")},
+			counters: map[string][]int{
+				"dut2": {1},
+			},
+		},
+	}
+	s := newSweep(checks, fakeDUTs("dut1", "dut2"))
+	s.start(context.Background())
+	got := s.verify(context.Background())
+	want := &Report{
+		Healthy: false,
+		Results: []Result{
+			{DUT: "dut1", Check: "broken", Error: "broken"},
+			{DUT: "dut1", Check: "counter"},
+			{DUT: "dut2", Check: "broken", Error: "no more values"},
+			{DUT: "dut2", Check: "counter", Problems: []string{"counter changed from 1 to 2"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("verify() -want,+got:\n%s", diff)
+	}
+	if got, want := got.String(), "\n  DUT dut2: counter: counter changed from 1 to 2"; got != want {
+		t.Errorf("String() got %q, want %q", got, want)
+	}
+}
+
+func TestEnabledChecks(t *testing.T) {
+	tests := []struct {
+		desc    string
+		flag    string
+		want    []string
+		wantErr string
+	}{{
+		desc: "all",
+		want: []string{"healthz", "process_restarts", "reboot", "alarms"},
+	}, {
+		desc: "selected",
+		flag: "reboot, alarms",
+		want: []string{"reboot", "alarms"},
+	}, {
+		desc:    "unknown",
+		flag:    "reboot,bogus",
+		wantErr: `unknown health check "bogus"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			*checkNames = tt.flag
+			defer func() { *checkNames = "" }()
+			checks, err := enabledChecks()
+			if s := errdiff.Check(err, tt.wantErr); s != "" {
+				t.Fatalf("enabledChecks() unexpected error: %s", s)
+			}
+			var got []string
+			for _, c := range checks {
+				got = append(got, c.Name())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("enabledChecks() -want,+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProperties(t *testing.T) {
+	report := &Report{
+		Healthy: false,
+		Results: []Result{
+			{DUT: "dut", Check: "alarms", Error: "unimplemented"},
+			{DUT: "dut", Check: "reboot", Problems: []string{"DUT rebooted"}},
+			{DUT: "dut", Check: "healthz"},
+		},
+		UnhealthyTests: []string{"TestBar"},
+	}
+	tests := map[string]bool{"TestFoo": true, "TestBar": false}
+	want := map[string]string{
+		"health.healthy":      "false",
+		"health.dut.alarms":   "ERROR",
+		"health.dut.reboot":   "UNHEALTHY",
+		"health.dut.healthz":  "OK",
+		"health.test.TestFoo": "healthy",
+		"health.test.TestBar": "unhealthy",
+		"health.report":       `{"healthy":false,"results":[{"dut":"dut","check":"alarms","error":"unimplemented"},{"dut":"dut","check":"reboot","problems":["DUT rebooted"]},{"dut":"dut","check":"healthz"}],"unhealthy_tests":["TestBar"]}`,
+	}
+	if diff := cmp.Diff(want, properties(report, tests)); diff != "" {
+		t.Errorf("properties() -want,+got:\n%s", diff)
+	}
+}
+
+func TestEventCallbacks(t *testing.T) {
+	checksMu.Lock()
+	origChecks := checks
+	checks = []Check{&fakeCheck{
+		name:     "counter",
+		counters: map[string][]int{"dut": {1, 1, 1, 2}},
+	}}
+	checksMu.Unlock()
+	*sweepEnabled = true
+	defer func() {
+		checksMu.Lock()
+		checks = origChecks
+		checksMu.Unlock()
+		*sweepEnabled = false
+		suite = nil
+	}()
+
+	e := &eventlis.BeforeTestsEvent{
+		Reservation: &binding.Reservation{
+			DUTs: map[string]binding.DUT{"dut": fakeDUTs("dut")["dut"].DUT},
+		},
+	}
+	if err := registerBefore(e); err != nil {
+		t.Fatalf("registerBefore() got unexpected error: %v", err)
+	}
+	t.Run("TestHealthy", func(t *testing.T) {
+		Track(t)
+	})
+	if got := testResults["TestEventCallbacks/TestHealthy"]; !got {
+		t.Errorf("Track() got unhealthy test, want healthy")
+	}
+	err := registerAfter(&eventlis.AfterTestsEvent{ExitCode: new(int)})
+	if s := errdiff.Check(err, "counter changed from 1 to 2"); s != "" {
+		t.Errorf("registerAfter() unexpected error: %s", s)
+	}
+}
+
+func TestRestartedProcesses(t *testing.T) {
+	procs := func(pids ...uint64) []*oc.System_Process {
+		var ps []*oc.System_Process
+		for _, pid := range pids {
+			ps = append(ps, &oc.System_Process{Name: ygot.String(fmt.Sprintf("p%d", pid%10)), Pid: ygot.Uint64(pid), StartTime: ygot.Uint64(pid * 100)})
+		}
+		return ps
+	}
+	// p1 is restarted, p2 exits, p3 is unchanged, one of the two instances of
+	// p4 is replaced while the other keeps running, and p5 is started.
+	before := processesByName(procs(1, 2, 3, 4, 14))
+	after := processesByName(procs(11, 3, 4, 24, 5))
+	want := []string{`process "p1" restarted: PIDs [1] became [11]`}
+	if diff := cmp.Diff(want, restartedProcesses(before, after)); diff != "" {
+		t.Errorf("restartedProcesses() -want,+got:\n%s", diff)
+	}
+}
+
+func TestRebooted(t *testing.T) {
+	if got := rebooted(1e18, 1e18); got != nil {
+		t.Errorf("rebooted() got %v, want nil", got)
+	}
+	want := []string{"DUT rebooted at 2001-09-09 01:46:40 +0000 UTC"}
+	if diff := cmp.Diff(want, rebooted(0, 1e18)); diff != "" {
+		t.Errorf("rebooted() -want,+got:\n%s", diff)
+	}
+}
+
+func TestNewAlarms(t *testing.T) {
+	alarm := func(id string, severity oc.E_AlarmTypes_OPENCONFIG_ALARM_SEVERITY) *oc.System_Alarm {
+		return &oc.System_Alarm{Id: ygot.String(id), Severity: severity, Resource: ygot.String("fan0"), Text: ygot.String("fan failed")}
+	}
+	before := map[string]*oc.System_Alarm{
+		"1": alarm("1", oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_MAJOR),
+	}
+	after := map[string]*oc.System_Alarm{
+		"1": alarm("1", oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_MAJOR),
+		"2": alarm("2", oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_MINOR),
+		"3": alarm("3", oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_CRITICAL),
+	}
+	want := []string{`new CRITICAL alarm "3" on "fan0": fan failed`}
+	if diff := cmp.Diff(want, newAlarms(before, after)); diff != "" {
+		t.Errorf("newAlarms() -want,+got:\n%s", diff)
+	}
+}
+
+// fakeGNMIClient answers every subscription with a sync response only, as a
+// DUT without any value at the subscribed paths does.
+type fakeGNMIClient struct {
+	gpb.GNMIClient
+}
+
+func (fakeGNMIClient) Subscribe(context.Context, ...grpc.CallOption) (gpb.GNMI_SubscribeClient, error) {
+	return &fakeSubscribeClient{}, nil
+}
+
+type fakeSubscribeClient struct {
+	gpb.GNMI_SubscribeClient
+	synced bool
+}
+
+func (*fakeSubscribeClient) Send(*gpb.SubscribeRequest) error { return nil }
+
+func (*fakeSubscribeClient) CloseSend() error { return nil }
+
+func (c *fakeSubscribeClient) Recv() (*gpb.SubscribeResponse, error) {
+	if c.synced {
+		return nil, io.EOF
+	}
+	c.synced = true
+	return &gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true}}, nil
+}
+
+func TestAlarmsNotPresent(t *testing.T) {
+	y, err := ygnmi.NewClient(fakeGNMIClient{})
+	if err != nil {
+		t.Fatalf("ygnmi.NewClient() got unexpected error: %v", err)
+	}
+	dut := fakeDUTs("dut")["dut"]
+	dut.gnmi = y
+
+	baseline, err := alarmCheck{}.Baseline(context.Background(), dut)
+	if err != nil {
+		t.Fatalf("Baseline() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]*oc.System_Alarm{}, baseline); diff != "" {
+		t.Errorf("Baseline() -want,+got:\n%s", diff)
+	}
+	problems, err := alarmCheck{}.Verify(context.Background(), dut, baseline)
+	if err != nil {
+		t.Fatalf("Verify() got unexpected error: %v", err)
+	}
+	if len(problems) > 0 {
+		t.Errorf("Verify() got problems %q, want none", problems)
+	}
+}
@@ -47,6 +47,14 @@
 //   - dut.vendor - the vendor of the DUT.
 //   - dut.model - the vendor model name of the DUT.
 //   - dut.os_version - the OS version running on the DUT.
+//   - ate.otg.endpoint - the endpoint of the OTG controller that the ATE is
+//     connected to, when the binding checks the health of the controller or
+//     lists failover controllers.  This is reported when the reservation is
+//     released, after a failover during the test.
+//   - dut.cores - a comma separated list of the core files found on the DUT
+//     during the test, each as name:size:sha256, where the SHA-256 is empty
+//     unless the core file was downloaded.  This is reported by the core file
+//     validator after the tests.
 package rundata
 
 import (
@@ -134,3 +142,52 @@ func Timing(context.Context) map[string]string {
 	m["time.end"] = fmt.Sprint(time.Now().Unix())
 	return m
 }
+
+// otgEndpointATE is implemented by ATEs that report the endpoint of the OTG
+// controller they are connected to, such as the ATEs of the static binding.
+type otgEndpointATE interface {
+	OTGEndpoint() string
+}
+
+// Endpoints builds the test properties with the endpoints of the OTG
+// controllers that the ATEs of the reservation are connected to.
+func Endpoints(_ context.Context, resv *binding.Reservation) map[string]string {
+	m := make(map[string]string)
+	if resv == nil {
+		return m
+	}
+	for id, ate := range resv.ATEs {
+		if e, ok := ate.(otgEndpointATE); ok {
+			if endpoint := e.OTGEndpoint(); endpoint != "" {
+				m[id+".otg.endpoint"] = endpoint
+			}
+		}
+	}
+	return m
+}
+
+// CoreFile is a core file found on a DUT during the test.
+type CoreFile struct {
+	Name string
+	Size uint64
+	// SHA256 is the hex encoded SHA-256 of the core file, if downloaded.
+	SHA256 string
+}
+
+// Cores builds the test properties with the core files found on the DUTs,
+// by DUT ID.
+func Cores(_ context.Context, cores map[string][]CoreFile) map[string]string {
+	m := make(map[string]string)
+	for id, files := range cores {
+		if len(files) == 0 {
+			continue
+		}
+		var parts []string
+		for _, f := range files {
+			parts = append(parts, fmt.Sprintf("%s:%d:%s", f.Name, f.Size, f.SHA256))
+		}
+		sort.Strings(parts)
+		m[id+".cores"] = strings.Join(parts, ",")
+	}
+	return m
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
 	"github.com/openconfig/ondatra/binding"
 )
@@ -165,3 +166,44 @@ func TestTiming(t *testing.T) {
 		}
 	}
 }
+
+type endpointATE struct {
+	*binding.AbstractATE
+	endpoint string
+}
+
+func (a *endpointATE) OTGEndpoint() string {
+	return a.endpoint
+}
+
+func TestEndpoints(t *testing.T) {
+	resv := &binding.Reservation{
+		ATEs: map[string]binding.ATE{
+			"ate1": &endpointATE{endpoint: "otg2.example.com:40051"},
+			"ate2": &endpointATE{},
+			"ate3": &binding.AbstractATE{},
+		},
+	}
+	got := Endpoints(context.Background(), resv)
+	want := map[string]string{"ate1.otg.endpoint": "otg2.example.com:40051"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Endpoints() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := Endpoints(context.Background(), nil); len(got) != 0 {
+		t.Errorf("Endpoints(nil) got %v, want empty", got)
+	}
+}
+
+func TestCores(t *testing.T) {
+	got := Cores(context.Background(), map[string][]CoreFile{
+		"dut1": {
+			{Name: "/var/core/core.2", Size: 20},
+			{Name: "/var/core/core.1", Size: 10, SHA256: "abc"},
+		},
+		"dut2": nil,
+	})
+	want := map[string]string{"dut1.cores": "/var/core/core.1:10:abc,/var/core/core.2:20:"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Cores() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"slices"
@@ -44,8 +45,10 @@ import (
 const (
 	prefixPathV4              = "/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/prefix"
 	prefixNHGPathV4           = "/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/next-hop-group"
+	prefixNHGNIPathV4         = "/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/next-hop-group-network-instance"
 	prefixPathV6              = "/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/prefix"
 	prefixNHGPathV6           = "/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/next-hop-group"
+	prefixNHGNIPathV6         = "/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/next-hop-group-network-instance"
 	nextHopWeightPath         = "/network-instances/network-instance/afts/next-hop-groups/next-hop-group/next-hops/next-hop/state/weight"
 	nextHopGroupConditionPath = "/network-instances/network-instance/afts/next-hop-groups/next-hop-group/condition"
 	// periodicInterval is the time between execution of periodic hooks.
@@ -82,7 +85,6 @@ var unusedPaths = []string{
 	"/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/counters/packets-forwarded",
 	"/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/decapsulate-header",
 	"/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/entry-metadata",
-	"/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/next-hop-group-network-instance",
 	"/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/origin-network-instance",
 	"/network-instances/network-instance/afts/ipv4-unicast/ipv4-entry/state/origin-protocol",
 	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/prefix",
@@ -90,7 +92,6 @@ var unusedPaths = []string{
 	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/counters/packets-forwarded",
 	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/decapsulate-header",
 	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/entry-metadata",
-	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/next-hop-group-network-instance",
 	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/origin-network-instance",
 	"/network-instances/network-instance/afts/ipv6-unicast/ipv6-entry/state/origin-protocol",
 	"/network-instances/network-instance/afts/next-hop-groups/next-hop-group/id",
@@ -105,30 +106,81 @@ var unusedPaths = []string{
 	"/network-instances/network-instance/afts/next-hops/next-hop/state/origin-protocol",
 }
 
-func subscriptionPaths(dut *ondatra.DUTDevice) map[string][]string {
-	defaultNetworkInstance := deviations.DefaultNetworkInstance(dut)
-	return map[string][]string{
-		"prefix": {
-			fmt.Sprintf("network-instances/network-instance[name=%s]/afts/ipv4-unicast/ipv4-entry", defaultNetworkInstance),
-			fmt.Sprintf("network-instances/network-instance[name=%s]/afts/ipv6-unicast/ipv6-entry", defaultNetworkInstance),
-		},
-		"nhg": {
-			fmt.Sprintf("network-instances/network-instance[name=%s]/afts/next-hop-groups/next-hop-group", defaultNetworkInstance),
-		},
-		"nh": {
-			fmt.Sprintf("network-instances/network-instance[name=%s]/afts/next-hops/next-hop", defaultNetworkInstance),
-		},
+// AFTTable is a table of the AFT of a network instance, named by its path
+// relative to the afts container.
+type AFTTable string
+
+const (
+	// IPv4Table contains the IPv4 prefix entries.
+	IPv4Table AFTTable = "ipv4-unicast/ipv4-entry"
+	// IPv6Table contains the IPv6 prefix entries.
+	IPv6Table AFTTable = "ipv6-unicast/ipv6-entry"
+	// NextHopGroupTable contains the next hop groups.
+	NextHopGroupTable AFTTable = "next-hop-groups/next-hop-group"
+	// NextHopTable contains the next hops.
+	NextHopTable AFTTable = "next-hops/next-hop"
+	// LabelTable contains the MPLS label entries.
+	LabelTable AFTTable = "mpls/label-entry"
+	// PolicyForwardingTable contains the policy forwarding entries.
+	PolicyForwardingTable AFTTable = "policy-forwarding/policy-forwarding-entry"
+)
+
+// defaultTables are the tables streamed if the session options do not list any.
+var defaultTables = []AFTTable{IPv4Table, IPv6Table, NextHopGroupTable, NextHopTable}
+
+// SessionOptions select the AFTs that an AFTStreamSession streams.
+type SessionOptions struct {
+	// NetworkInstances are the network instances whose AFTs are streamed.
+	// Defaults to the default network instance of the DUT.
+	NetworkInstances []string
+	// Tables are the AFT tables streamed in each network instance. Defaults to
+	// the IPv4 and IPv6 prefixes, next hop groups and next hops.
+	Tables []AFTTable
+}
+
+// aftPath returns the subscription path of a table of the AFT of a network instance.
+func aftPath(networkInstance string, table AFTTable) string {
+	return fmt.Sprintf("network-instances/network-instance[name=%s]/afts/%s", networkInstance, table)
+}
+
+func subscriptionPaths(networkInstances []string, tables []AFTTable) []string {
+	var paths []string
+	for _, ni := range networkInstances {
+		for _, table := range tables {
+			paths = append(paths, aftPath(ni, table))
+		}
 	}
+	return paths
 }
 
-// AFTData represents an AFT and provides methods for resolving routes.
+// AFTData represents the AFTs of the streamed network instances and provides methods for
+// resolving routes.
 type AFTData struct {
+	// NetworkInstanceAFT is the AFT of the default network instance of the DUT, or of the first
+	// streamed network instance if the default one is not streamed.
+	*NetworkInstanceAFT
+	// NetworkInstances contains a map of network instance names to their AFTs.
+	NetworkInstances map[string]*NetworkInstanceAFT
+	// networkInstance is the name of the network instance of NetworkInstanceAFT.
+	networkInstance string
+}
+
+// NetworkInstanceAFT represents the AFT of a network instance.
+type NetworkInstanceAFT struct {
 	// Prefixes contains a map of prefixes to their corresponding next hop group IDs.
 	Prefixes map[string]uint64
+	// PrefixNHGNetworkInstances contains a map of prefixes to the network instance of their next
+	// hop group, for the prefixes whose next hop group is in another network instance.
+	PrefixNHGNetworkInstances map[string]string
 	// NextHopGroups contains a map of next hop group IDs to their corresponding next hop group data.
 	NextHopGroups map[uint64]*aftNextHopGroup
 	// NextHops contains a map of next hop IDs to their corresponding next hop data.
 	NextHops map[uint64]*aftNextHop
+	// LabelEntries contains a map of MPLS labels to their corresponding label entry data.
+	LabelEntries map[uint64]*aftLabelEntry
+	// PolicyForwardingEntries contains a map of policy forwarding entry indices to their
+	// corresponding policy forwarding entry data.
+	PolicyForwardingEntries map[uint64]*aftPolicyForwardingEntry
 }
 
 // aftCache is the AFT streaming cache.
@@ -163,6 +215,31 @@ type aftNextHop struct {
 	IP string
 	// LSPName contains the LSP name of the next hop.
 	LSPName string
+	// NetworkInstance contains the network instance in which packets are looked up after this
+	// next hop, if it points to another network instance.
+	NetworkInstance string
+}
+
+// aftLabelEntry represents an AFT MPLS label entry.
+type aftLabelEntry struct {
+	// NHGID contains the next hop group ID of the label entry.
+	NHGID uint64
+	// NHGNetworkInstance contains the network instance of the next hop group, if it is not the
+	// network instance of the label entry.
+	NHGNetworkInstance string
+}
+
+// aftPolicyForwardingEntry represents an AFT policy forwarding entry.
+type aftPolicyForwardingEntry struct {
+	// IPPrefix contains the IP prefix that the entry matches, if any.
+	IPPrefix string
+	// MPLSLabel contains the MPLS label that the entry matches, if any.
+	MPLSLabel uint64
+	// NHGID contains the next hop group ID of the entry.
+	NHGID uint64
+	// NHGNetworkInstance contains the network instance of the next hop group, if it is not the
+	// network instance of the entry.
+	NHGNetworkInstance string
 }
 
 // generateCacheTraversalPaths converts a map of subscription paths to a map of cache traversal paths.
@@ -206,72 +283,105 @@ func generateCacheTraversalPaths(subscriptionPaths map[string][]string) (map[str
 }
 
 // ToAFT Creates AFT maps with cache information.
-func (ss *AFTStreamSession) ToAFT(t *testing.T, dut *ondatra.DUTDevice) (*AFTData, error) {
+func (ss *AFTStreamSession) ToAFT(t *testing.T, _ *ondatra.DUTDevice) (*AFTData, error) {
 	sessionPrefix := ss.sessionPrefix()
 	c := ss.Cache
-	a := newAFT()
-	prefixFunc := func(n *gnmipb.Notification) error {
-		p, nhg, err := parsePrefix(t, n, sessionPrefix)
-		if err != nil {
-			t.Logf("%s error in parsing prefix: %v", sessionPrefix, err)
-			return err
+	a := &AFTData{NetworkInstances: map[string]*NetworkInstanceAFT{}}
+	for _, ni := range ss.networkInstances {
+		niAFT := newNetworkInstanceAFT()
+		a.NetworkInstances[ni] = niAFT
+		prefixFunc := func(n *gnmipb.Notification) error {
+			p, nhg, nhgNI, err := parsePrefix(t, n, sessionPrefix)
+			if err != nil {
+				t.Logf("%s error in parsing prefix: %v", sessionPrefix, err)
+				return err
+			}
+			niAFT.Prefixes[p] = nhg
+			if nhgNI != "" && nhgNI != ni {
+				niAFT.PrefixNHGNetworkInstances[p] = nhgNI
+			}
+			return nil
 		}
-		a.Prefixes[p] = nhg
-		return nil
-	}
-	nhgFunc := func(n *gnmipb.Notification) error {
-		nhg, data, err := parseNHG(t, n)
-		switch {
-		case errors.Is(err, ErrNotExist) || errors.Is(err, ErrUnsupported):
-			t.Logf("%s error parsing NHG: %v", sessionPrefix, err)
-		case err != nil:
-			t.Logf("%s error in parsing NHG: %v", sessionPrefix, err)
-			return err
-		default:
-			a.NextHopGroups[nhg] = data
+		nhgFunc := func(n *gnmipb.Notification) error {
+			nhg, data, err := parseNHG(t, n)
+			switch {
+			case errors.Is(err, ErrNotExist) || errors.Is(err, ErrUnsupported):
+				t.Logf("%s error parsing NHG: %v", sessionPrefix, err)
+			case err != nil:
+				t.Logf("%s error in parsing NHG: %v", sessionPrefix, err)
+				return err
+			default:
+				niAFT.NextHopGroups[nhg] = data
+			}
+			return nil
 		}
-		return nil
-	}
-	nhFunc := func(n *gnmipb.Notification) error {
-		nh, data, err := parseNH(n)
-		switch {
-		case errors.Is(err, ErrNotExist):
-			t.Logf("%s error parsing NH: %v", sessionPrefix, err)
-		case err != nil:
-			return err
-		default:
-			a.NextHops[nh] = data
+		nhFunc := func(n *gnmipb.Notification) error {
+			nh, data, err := parseNH(n)
+			switch {
+			case errors.Is(err, ErrNotExist):
+				t.Logf("%s error parsing NH: %v", sessionPrefix, err)
+			case err != nil:
+				return err
+			default:
+				niAFT.NextHops[nh] = data
+			}
+			return nil
 		}
-		return nil
-	}
-	cacheTraversalPaths, err := generateCacheTraversalPaths(subscriptionPaths(dut))
-	if err != nil {
-		return nil, err
-	}
-	parsers := []struct {
-		paths []string
-		f     func(n *gnmipb.Notification) error
-	}{
-		{
-			paths: cacheTraversalPaths["prefix"],
-			f:     prefixFunc,
-		},
-		{
-			paths: cacheTraversalPaths["nhg"],
-			f:     nhgFunc,
-		},
-		{
-			paths: cacheTraversalPaths["nh"],
-			f:     nhFunc,
-		},
-	}
-	for _, p := range parsers {
-		for _, path := range p.paths {
-			if err := c.traverse(path, p.f); err != nil {
+		labelFunc := func(n *gnmipb.Notification) error {
+			label, data, err := parseLabelEntry(n)
+			switch {
+			case errors.Is(err, ErrNotExist) || errors.Is(err, ErrUnsupported):
+				t.Logf("%s error parsing label entry: %v", sessionPrefix, err)
+			case err != nil:
+				return err
+			default:
+				if data.NHGNetworkInstance == ni {
+					data.NHGNetworkInstance = ""
+				}
+				niAFT.LabelEntries[label] = data
+			}
+			return nil
+		}
+		pfFunc := func(n *gnmipb.Notification) error {
+			index, data, err := parsePolicyForwardingEntry(n)
+			switch {
+			case errors.Is(err, ErrNotExist):
+				t.Logf("%s error parsing policy forwarding entry: %v", sessionPrefix, err)
+			case err != nil:
+				return err
+			default:
+				if data.NHGNetworkInstance == ni {
+					data.NHGNetworkInstance = ""
+				}
+				niAFT.PolicyForwardingEntries[index] = data
+			}
+			return nil
+		}
+		parsers := map[AFTTable]func(n *gnmipb.Notification) error{
+			IPv4Table:             prefixFunc,
+			IPv6Table:             prefixFunc,
+			NextHopGroupTable:     nhgFunc,
+			NextHopTable:          nhFunc,
+			LabelTable:            labelFunc,
+			PolicyForwardingTable: pfFunc,
+		}
+		for _, table := range ss.tables {
+			cacheTraversalPaths, err := generateCacheTraversalPaths(map[string][]string{string(table): {aftPath(ni, table)}})
+			if err != nil {
 				return nil, err
 			}
+			for _, path := range cacheTraversalPaths[string(table)] {
+				if err := c.traverse(path, parsers[table]); err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
+	a.networkInstance = ss.networkInstances[0]
+	if slices.Contains(ss.networkInstances, ss.defaultNI) {
+		a.networkInstance = ss.defaultNI
+	}
+	a.NetworkInstanceAFT = a.NetworkInstances[a.networkInstance]
 	return a, nil
 }
 
@@ -312,7 +422,7 @@ func (a *AFTData) resolveRoute(prefix string) ([]*aftNextHop, error) {
 	return a.resolveRouteCBF(prefix, 0)
 }
 
-func (a *AFTData) isCNHG(nhgID uint64) (bool, error) {
+func (a *NetworkInstanceAFT) isCNHG(nhgID uint64) (bool, error) {
 	// Assume we've already checked the nhgID exists.
 	if len(a.NextHopGroups[nhgID].NHIDs) > 0 && len(a.NextHopGroups[nhgID].Conditionals) > 0 {
 		return false, fmt.Errorf("the NHG has both NHs and conditionals. not clear if CNHG or leaf NHG")
@@ -327,35 +437,90 @@ func (a *AFTData) isCNHG(nhgID uint64) (bool, error) {
 // ResolveRouteCBF gets the possible next hops for a specific route.
 // dscp is the DSCP bits.
 func (a *AFTData) resolveRouteCBF(prefix string, dscp uint8) ([]*aftNextHop, error) {
-	if _, ok := a.Prefixes[prefix]; !ok {
-		return nil, fmt.Errorf("missing prefix. want %s, %w", prefix, ErrNotExist)
+	return a.ResolveRouteInNetworkInstance(a.networkInstance, prefix, dscp)
+}
+
+// ResolveRouteInNetworkInstance gets the possible next hops for a route in a network instance.
+// dscp is the DSCP bits. It follows the next hop groups of prefixes into other network
+// instances, and resolves the IP address of a next hop that points to another network
+// instance by its longest matching prefix in that network instance.
+func (a *AFTData) ResolveRouteInNetworkInstance(networkInstance, prefix string, dscp uint8) ([]*aftNextHop, error) {
+	return a.resolveRouteIn(networkInstance, prefix, dscp, map[string]bool{})
+}
+
+// resolveRouteIn resolves a route in a network instance, where visited tracks the routes being
+// resolved in case of circular references across network instances.
+func (a *AFTData) resolveRouteIn(networkInstance, prefix string, dscp uint8, visited map[string]bool) ([]*aftNextHop, error) {
+	niAFT, ok := a.NetworkInstances[networkInstance]
+	if !ok {
+		return nil, fmt.Errorf("missing network instance %s for prefix %s, %w", networkInstance, prefix, ErrNotExist)
+	}
+	nhgID, ok := niAFT.Prefixes[prefix]
+	if !ok {
+		return nil, fmt.Errorf("missing prefix. want %s in network instance %s, %w", prefix, networkInstance, ErrNotExist)
+	}
+	route := networkInstance + " " + prefix
+	if visited[route] {
+		return nil, fmt.Errorf("circular reference for prefix %s in network instance %s", prefix, networkInstance)
+	}
+	visited[route] = true
+	defer delete(visited, route)
+	nhgNI := networkInstance
+	if ni, ok := niAFT.PrefixNHGNetworkInstances[prefix]; ok {
+		nhgNI = ni
+	}
+	return a.resolveNHG(nhgNI, nhgID, dscp, visited, fmt.Sprintf("prefix %s", prefix))
+}
+
+// ResolveLabel gets the possible next hops for an MPLS label entry in a network instance.
+func (a *AFTData) ResolveLabel(networkInstance string, label uint64) ([]*aftNextHop, error) {
+	niAFT, ok := a.NetworkInstances[networkInstance]
+	if !ok {
+		return nil, fmt.Errorf("missing network instance %s for label %d, %w", networkInstance, label, ErrNotExist)
+	}
+	entry, ok := niAFT.LabelEntries[label]
+	if !ok {
+		return nil, fmt.Errorf("missing label. want %d in network instance %s, %w", label, networkInstance, ErrNotExist)
+	}
+	nhgNI := networkInstance
+	if entry.NHGNetworkInstance != "" {
+		nhgNI = entry.NHGNetworkInstance
 	}
-	nhgID := a.Prefixes[prefix]
-	visited := map[uint64]bool{} // Track NHGs we've seen in case of circular references.
+	return a.resolveNHG(nhgNI, entry.NHGID, 0, map[string]bool{}, fmt.Sprintf("label %d", label))
+}
+
+// resolveNHG gets the possible next hops of a next hop group in a network instance, which is
+// referenced by the entry described by ref.
+func (a *AFTData) resolveNHG(networkInstance string, nhgID uint64, dscp uint8, visited map[string]bool, ref string) ([]*aftNextHop, error) {
+	niAFT, ok := a.NetworkInstances[networkInstance]
+	if !ok {
+		return nil, fmt.Errorf("missing reference for %s, network instance %s not streamed: %w", ref, networkInstance, ErrNotExist)
+	}
+	visitedNHGs := map[uint64]bool{} // Track NHGs we've seen in case of circular references.
 	for {
-		if _, ok := a.NextHopGroups[nhgID]; !ok {
-			return nil, fmt.Errorf("missing reference for prefix %s, NHG %d not found: %w", prefix, nhgID, ErrNotExist)
+		if _, ok := niAFT.NextHopGroups[nhgID]; !ok {
+			return nil, fmt.Errorf("missing reference for %s, NHG %d not found: %w", ref, nhgID, ErrNotExist)
 		}
-		isCNHG, err := a.isCNHG(nhgID)
+		isCNHG, err := niAFT.isCNHG(nhgID)
 		if err != nil {
-			return nil, fmt.Errorf("error in prefix %s, error reading NHG %d: %v", prefix, nhgID, err)
+			return nil, fmt.Errorf("error in %s, error reading NHG %d: %v", ref, nhgID, err)
 		}
 		if !isCNHG {
 			// This is a leaf, non-conditional NHG node. Terminate.
 			break
 		}
 		// We look up each ID in visited and add all IDs to visited. This should always terminate.
-		if _, ok := visited[nhgID]; ok {
-			return nil, fmt.Errorf("circular reference for prefix %s, NHG %d already seen", prefix, nhgID)
+		if _, ok := visitedNHGs[nhgID]; ok {
+			return nil, fmt.Errorf("circular reference for %s, NHG %d already seen", ref, nhgID)
 		}
-		visited[nhgID] = true
+		visitedNHGs[nhgID] = true
 		match := false
-		for _, c := range a.NextHopGroups[nhgID].Conditionals {
+		for _, c := range niAFT.NextHopGroups[nhgID].Conditionals {
 			for _, d := range c.DSCP {
 				if d == dscp {
 					if match {
 						// We already matched a different conditional. Undefined behavior.
-						return nil, fmt.Errorf("undefined behavior for prefix %s, multiple conditionals apply", prefix)
+						return nil, fmt.Errorf("undefined behavior for %s, multiple conditionals apply", ref)
 					}
 					match = true
 					nhgID = c.NHGID
@@ -368,15 +533,51 @@ func (a *AFTData) resolveRouteCBF(prefix string, dscp uint8) ([]*aftNextHop, err
 		}
 	}
 	var nhs []*aftNextHop
-	for _, nhID := range a.NextHopGroups[nhgID].NHIDs {
-		if _, ok := a.NextHops[nhID]; !ok {
-			return nil, fmt.Errorf("missing reference for prefix %s, NH %d not found, %w", prefix, nhID, ErrNotExist)
+	for _, nhID := range niAFT.NextHopGroups[nhgID].NHIDs {
+		nh, ok := niAFT.NextHops[nhID]
+		if !ok {
+			return nil, fmt.Errorf("missing reference for %s, NH %d not found, %w", ref, nhID, ErrNotExist)
+		}
+		if nh.NetworkInstance == "" || nh.NetworkInstance == networkInstance || nh.IP == "" || nh.IntfName != "" {
+			nhs = append(nhs, nh)
+			continue
 		}
-		nhs = append(nhs, a.NextHops[nhID])
+		// The next hop IP address is resolved in another network instance.
+		target, ok := a.NetworkInstances[nh.NetworkInstance]
+		if !ok {
+			return nil, fmt.Errorf("missing reference for %s, NH %d points to network instance %s which is not streamed: %w", ref, nhID, nh.NetworkInstance, ErrNotExist)
+		}
+		prefix, err := target.longestMatch(nh.IP)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving NH %d of %s in network instance %s: %w", nhID, ref, nh.NetworkInstance, err)
+		}
+		resolved, err := a.resolveRouteIn(nh.NetworkInstance, prefix, dscp, visited)
+		if err != nil {
+			return nil, err
+		}
+		nhs = append(nhs, resolved...)
 	}
 	return nhs, nil
 }
 
+// longestMatch returns the longest prefix that contains an IP address.
+func (a *NetworkInstanceAFT) longestMatch(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", err
+	}
+	for bits := addr.BitLen(); bits >= 0; bits-- {
+		p, err := addr.Prefix(bits)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := a.Prefixes[p.String()]; ok {
+			return p.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no prefix contains %s: %w", ip, ErrNotExist)
+}
+
 func (c *aftCache) addAFTNotification(n *gnmipb.SubscribeResponse) error {
 	if n.GetSyncResponse() {
 		// No-op for now.
@@ -412,11 +613,14 @@ func newAFTCache(target string) *aftCache {
 	}
 }
 
-func newAFT() *AFTData {
-	return &AFTData{
-		Prefixes:      map[string]uint64{},
-		NextHopGroups: map[uint64]*aftNextHopGroup{},
-		NextHops:      map[uint64]*aftNextHop{},
+func newNetworkInstanceAFT() *NetworkInstanceAFT {
+	return &NetworkInstanceAFT{
+		Prefixes:                  map[string]uint64{},
+		PrefixNHGNetworkInstances: map[string]string{},
+		NextHopGroups:             map[uint64]*aftNextHopGroup{},
+		NextHops:                  map[uint64]*aftNextHop{},
+		LabelEntries:              map[uint64]*aftLabelEntry{},
+		PolicyForwardingEntries:   map[uint64]*aftPolicyForwardingEntry{},
 	}
 }
 
@@ -431,12 +635,12 @@ type aftSubscriptionResponse struct {
 // This is somewhat bad practice. I was surprised that this function spawned a goroutine.
 // Functions should not return if they spawn goroutines. (Assume the caller will cancel the context
 // on return.)
-func aftSubscribe(ctx context.Context, t *testing.T, c gnmipb.GNMIClient, dut *ondatra.DUTDevice) <-chan *aftSubscriptionResponse {
+func aftSubscribe(ctx context.Context, t *testing.T, c gnmipb.GNMIClient, dut *ondatra.DUTDevice, paths []string) <-chan *aftSubscriptionResponse {
 	sub, err := c.Subscribe(ctx)
 	if err != nil {
 		t.Fatalf("error in Subscribe(): %v", err)
 	}
-	req, err := checkForRoutesRequest(dut)
+	req, err := checkForRoutesRequest(dut, paths)
 	if err != nil {
 		t.Fatalf("error preparing subscribe request: %v", err)
 	}
@@ -473,21 +677,52 @@ type AFTStreamSession struct {
 	notifications     []*gnmipb.SubscribeResponse
 	missingPrefixes   map[string]bool
 	failingNHPrefixes map[string]bool
+	// networkInstances and tables are the streamed AFTs.
+	networkInstances []string
+	tables           []AFTTable
+	// defaultNI is the default network instance of the DUT.
+	defaultNI string
 }
 
 func (ss *AFTStreamSession) sessionPrefix() string {
 	return fmt.Sprintf("[%s-%d]", ss.Cache.target, ss.start.UnixNano())
 }
 
-// NewAFTStreamSession constructs an AFTStreamSession. It subscribes to a given gNMI client.
+// NewAFTStreamSession constructs an AFTStreamSession. It subscribes to a given gNMI client for
+// the prefixes, next hop groups and next hops of the default network instance.
 func NewAFTStreamSession(ctx context.Context, t *testing.T, c gnmipb.GNMIClient, dut *ondatra.DUTDevice) *AFTStreamSession {
-	return &AFTStreamSession{
-		buffer:            aftSubscribe(ctx, t, c, dut),
-		Cache:             newAFTCache(dut.Name()),
+	return NewAFTStreamSessionWithOptions(ctx, t, c, dut, nil)
+}
+
+// NewAFTStreamSessionWithOptions constructs an AFTStreamSession. It subscribes to a given gNMI
+// client for the AFTs selected by opts, which may be nil to use the defaults.
+func NewAFTStreamSessionWithOptions(ctx context.Context, t *testing.T, c gnmipb.GNMIClient, dut *ondatra.DUTDevice, opts *SessionOptions) *AFTStreamSession {
+	ss := newAFTStreamSession(dut.Name(), deviations.DefaultNetworkInstance(dut), opts)
+	ss.buffer = aftSubscribe(ctx, t, c, dut, subscriptionPaths(ss.networkInstances, ss.tables))
+	return ss
+}
+
+// newAFTStreamSession constructs an AFTStreamSession without a subscription.
+func newAFTStreamSession(target, defaultNI string, opts *SessionOptions) *AFTStreamSession {
+	if opts == nil {
+		opts = &SessionOptions{}
+	}
+	ss := &AFTStreamSession{
+		Cache:             newAFTCache(target),
 		notifications:     []*gnmipb.SubscribeResponse{},
 		missingPrefixes:   make(map[string]bool),
 		failingNHPrefixes: make(map[string]bool),
+		networkInstances:  opts.NetworkInstances,
+		tables:            opts.Tables,
+		defaultNI:         defaultNI,
+	}
+	if len(ss.networkInstances) == 0 {
+		ss.networkInstances = []string{defaultNI}
+	}
+	if len(ss.tables) == 0 {
+		ss.tables = defaultTables
 	}
+	return ss
 }
 
 // NotificationHook is a function that will be called when each notification is received, before updating the AFT cache.
@@ -884,10 +1119,13 @@ func parseNH(n *gnmipb.Notification) (uint64, *aftNextHop, error) {
 		case strings.HasSuffix(path, "interface-ref/state/interface"):
 			nh.IntfName = u.Val.GetStringVal()
 			found = true
+		case strings.HasSuffix(path, "state/network-instance"):
+			nh.NetworkInstance = u.Val.GetStringVal()
+			found = true
 		}
 	}
 	if !found {
-		err = fmt.Errorf("ip-address, interface, nor lsp-name were found in notification %v. %w", n, ErrNotExist)
+		err = fmt.Errorf("ip-address, interface, lsp-name, nor network-instance were found in notification %v. %w", n, ErrNotExist)
 	}
 	return nhID, nh, err
 }
@@ -954,37 +1192,41 @@ func parseNHG(t *testing.T, n *gnmipb.Notification) (uint64, *aftNextHopGroup, e
 	return nhgID, nhg, err
 }
 
-// parsePrefix extracts the IP prefix and next-hop-group ID from an AFT prefix GNMI notification.
-func parsePrefix(t *testing.T, n *gnmipb.Notification, sessionPrefix string) (string, uint64, error) {
+// parsePrefix extracts the IP prefix, next-hop-group ID and next-hop-group network instance from
+// an AFT prefix GNMI notification. The network instance is empty if it is not in the notification.
+func parsePrefix(t *testing.T, n *gnmipb.Notification, sessionPrefix string) (string, uint64, string, error) {
 	// Normalizes paths for the "updates" in the gNMI notification.
 	updates := schema.NotificationToPoints(n)
 	if len(updates) == 0 {
 		t.Logf("no updates found in parsePrefix")
-		return "", 0, fmt.Errorf("missing updates")
+		return "", 0, "", fmt.Errorf("missing updates")
 	}
 	e := updates[0].Path.GetElem()
 	if len(e) < 5 {
-		return "", 0, fmt.Errorf("invalid prefix path in Notification: %v", n)
+		return "", 0, "", fmt.Errorf("invalid prefix path in Notification: %v", n)
 	}
 	prefix, ok := updates[0].Path.GetElem()[4].GetKey()["prefix"]
 	if !ok {
-		return "", 0, fmt.Errorf("invalid prefix path")
+		return "", 0, "", fmt.Errorf("invalid prefix path")
 	}
 	wantFields := map[string]bool{}
 	nhgID := uint64(0)
+	nhgNI := ""
 	for _, u := range updates {
 		path, err := ygot.PathToSchemaPath(u.Path)
 		if err != nil {
-			return "", 0, fmt.Errorf("error converting path to schema path: %v", err)
+			return "", 0, "", fmt.Errorf("error converting path to schema path: %v", err)
 		}
 		switch {
 		case path == prefixNHGPathV4 || path == prefixNHGPathV6:
 			wantFields[path] = true
 			nhgID = u.Val.GetUintVal()
+		case path == prefixNHGNIPathV4 || path == prefixNHGNIPathV6:
+			nhgNI = u.Val.GetStringVal()
 		case path == prefixPathV4 || path == prefixPathV6:
 			wantFields[path] = true
 			if u.Val.GetStringVal() != prefix {
-				return "", 0, fmt.Errorf("prefix mismatch")
+				return "", 0, "", fmt.Errorf("prefix mismatch")
 			}
 		// known unused paths
 		case slices.Contains(unusedPaths, path):
@@ -993,12 +1235,87 @@ func parsePrefix(t *testing.T, n *gnmipb.Notification, sessionPrefix string) (st
 		}
 	}
 	if len(wantFields) < 2 {
-		return "", 0, fmt.Errorf("missing required fields %v from the response %v", wantFields, n)
+		return "", 0, "", fmt.Errorf("missing required fields %v from the response %v", wantFields, n)
+	}
+	return prefix, nhgID, nhgNI, nil
+}
+
+// parseLabelEntry parses AFT MPLS label entry notification and returns the label and its
+// next-hop-group.
+func parseLabelEntry(n *gnmipb.Notification) (uint64, *aftLabelEntry, error) {
+	e := n.GetPrefix().GetElem()
+	if len(e) < 5 {
+		return 0, nil, fmt.Errorf("not enough elements in prefix.  Notification: %v", n)
 	}
-	return prefix, nhgID, nil
+	val, ok := e[4].GetKey()["label"]
+	if !ok {
+		return 0, nil, fmt.Errorf("\"label\" not a key in element.  Notification: %v", n)
+	}
+	label, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		// Reserved labels may be reported by name, e.g. IMPLICIT_NULL.
+		return 0, nil, fmt.Errorf("label %q is not numeric: %w", val, ErrUnsupported)
+	}
+	entry := &aftLabelEntry{}
+	found := false
+	for _, u := range schema.NotificationToPoints(n) {
+		path, err := ygot.PathToSchemaPath(u.Path)
+		switch {
+		case err != nil:
+			return 0, nil, err
+		case strings.HasSuffix(path, "state/next-hop-group"):
+			entry.NHGID = u.Val.GetUintVal()
+			found = true
+		case strings.HasSuffix(path, "state/next-hop-group-network-instance"):
+			entry.NHGNetworkInstance = u.Val.GetStringVal()
+		}
+	}
+	if !found {
+		err = fmt.Errorf("next-hop-group was not found in notification %v. %w", n, ErrNotExist)
+	}
+	return label, entry, err
 }
 
-func checkForRoutesRequest(dut *ondatra.DUTDevice) (*gnmipb.SubscribeRequest, error) {
+// parsePolicyForwardingEntry parses AFT policy forwarding entry notification and returns the
+// index, match fields and next-hop-group of the entry.
+func parsePolicyForwardingEntry(n *gnmipb.Notification) (uint64, *aftPolicyForwardingEntry, error) {
+	e := n.GetPrefix().GetElem()
+	if len(e) < 5 {
+		return 0, nil, fmt.Errorf("not enough elements in prefix.  Notification: %v", n)
+	}
+	val, ok := e[4].GetKey()["index"]
+	if !ok {
+		return 0, nil, fmt.Errorf("\"index\" not a key in element.  Notification: %v", n)
+	}
+	index, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+	entry := &aftPolicyForwardingEntry{}
+	found := false
+	for _, u := range schema.NotificationToPoints(n) {
+		path, err := ygot.PathToSchemaPath(u.Path)
+		switch {
+		case err != nil:
+			return 0, nil, err
+		case strings.HasSuffix(path, "state/ip-prefix"):
+			entry.IPPrefix = u.Val.GetStringVal()
+		case strings.HasSuffix(path, "state/mpls-label"):
+			entry.MPLSLabel = u.Val.GetUintVal()
+		case strings.HasSuffix(path, "state/next-hop-group"):
+			entry.NHGID = u.Val.GetUintVal()
+			found = true
+		case strings.HasSuffix(path, "state/next-hop-group-network-instance"):
+			entry.NHGNetworkInstance = u.Val.GetStringVal()
+		}
+	}
+	if !found {
+		err = fmt.Errorf("next-hop-group was not found in notification %v. %w", n, ErrNotExist)
+	}
+	return index, entry, err
+}
+
+func checkForRoutesRequest(dut *ondatra.DUTDevice, paths []string) (*gnmipb.SubscribeRequest, error) {
 	subReq := &gnmipb.SubscribeRequest_Subscribe{
 		Subscribe: &gnmipb.SubscriptionList{
 			Mode:     gnmipb.SubscriptionList_STREAM,
@@ -1006,14 +1323,12 @@ func checkForRoutesRequest(dut *ondatra.DUTDevice) (*gnmipb.SubscribeRequest, er
 			Encoding: gnmipb.Encoding_PROTO,
 		},
 	}
-	for _, paths := range subscriptionPaths(dut) {
-		for _, p := range paths {
-			pp, err := ygot.StringToPath(p, ygot.StructuredPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse path: %v", err)
-			}
-			subReq.Subscribe.Subscription = append(subReq.Subscribe.Subscription, &gnmipb.Subscription{Path: pp, Mode: gnmipb.SubscriptionMode_ON_CHANGE})
+	for _, p := range paths {
+		pp, err := ygot.StringToPath(p, ygot.StructuredPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse path: %v", err)
 		}
+		subReq.Subscribe.Subscription = append(subReq.Subscribe.Subscription, &gnmipb.Subscription{Path: pp, Mode: gnmipb.SubscriptionMode_ON_CHANGE})
 	}
 	return &gnmipb.SubscribeRequest{Request: subReq}, nil
 }
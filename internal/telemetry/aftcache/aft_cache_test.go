@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aftcache
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// leaf is a leaf of an AFT entry, relative to the entry.
+type leaf struct {
+	path string
+	val  any
+}
+
+// entryNotification returns an atomic notification of the leaves of an AFT entry.
+func entryNotification(t *testing.T, ts int64, entryPath string, leaves ...leaf) *gnmipb.SubscribeResponse {
+	t.Helper()
+	prefix, err := ygot.StringToStructuredPath(entryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &gnmipb.Notification{Timestamp: ts, Prefix: prefix, Atomic: true}
+	for _, l := range leaves {
+		p, err := ygot.StringToStructuredPath(l.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var val *gnmipb.TypedValue
+		switch v := l.val.(type) {
+		case string:
+			val = &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}
+		case uint64:
+			val = &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+		default:
+			t.Fatalf("unsupported leaf value %T", v)
+		}
+		n.Update = append(n.Update, &gnmipb.Update{Path: p, Val: val})
+	}
+	return &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+}
+
+func TestToAFTNetworkInstances(t *testing.T) {
+	ss := newAFTStreamSession("dut", "DEFAULT", &SessionOptions{
+		NetworkInstances: []string{"VRF-A", "DEFAULT", "VRF-B"},
+		Tables:           []AFTTable{IPv4Table, NextHopGroupTable, NextHopTable, LabelTable, PolicyForwardingTable},
+	})
+	afts := func(ni string) string {
+		return fmt.Sprintf("network-instances/network-instance[name=%s]/afts", ni)
+	}
+	nhg := func(ni string, id, nhID uint64) *gnmipb.SubscribeResponse {
+		return entryNotification(t, 1, fmt.Sprintf("%s/next-hop-groups/next-hop-group[id=%d]", afts(ni), id),
+			leaf{"state/id", id},
+			leaf{fmt.Sprintf("next-hops/next-hop[index=%d]/state/index", nhID), nhID},
+			leaf{fmt.Sprintf("next-hops/next-hop[index=%d]/state/weight", nhID), uint64(1)},
+		)
+	}
+	for _, n := range []*gnmipb.SubscribeResponse{
+		// A VRF prefix resolves to a next hop group in the default network instance.
+		entryNotification(t, 1, afts("VRF-A")+"/ipv4-unicast/ipv4-entry[prefix=198.51.100.0/24]",
+			leaf{"state/prefix", "198.51.100.0/24"},
+			leaf{"state/next-hop-group", uint64(1)},
+			leaf{"state/next-hop-group-network-instance", "DEFAULT"},
+		),
+		entryNotification(t, 1, afts("DEFAULT")+"/ipv4-unicast/ipv4-entry[prefix=192.0.2.0/24]",
+			leaf{"state/prefix", "192.0.2.0/24"},
+			leaf{"state/next-hop-group", uint64(1)},
+		),
+		entryNotification(t, 1, afts("DEFAULT")+"/mpls/label-entry[label=100]",
+			leaf{"state/label", uint64(100)},
+			leaf{"state/next-hop-group", uint64(1)},
+		),
+		entryNotification(t, 1, afts("DEFAULT")+"/policy-forwarding/policy-forwarding-entry[index=1]",
+			leaf{"state/index", uint64(1)},
+			leaf{"state/ip-prefix", "198.51.100.0/24"},
+			leaf{"state/next-hop-group", uint64(1)},
+			leaf{"state/next-hop-group-network-instance", "VRF-B"},
+		),
+		nhg("DEFAULT", 1, 1),
+		// The next hop of the default network instance is resolved in VRF-B.
+		entryNotification(t, 1, afts("DEFAULT")+"/next-hops/next-hop[index=1]",
+			leaf{"state/index", uint64(1)},
+			leaf{"state/ip-address", "203.0.113.1"},
+			leaf{"state/network-instance", "VRF-B"},
+		),
+		entryNotification(t, 1, afts("VRF-B")+"/ipv4-unicast/ipv4-entry[prefix=203.0.113.0/24]",
+			leaf{"state/prefix", "203.0.113.0/24"},
+			leaf{"state/next-hop-group", uint64(2)},
+		),
+		nhg("VRF-B", 2, 2),
+		entryNotification(t, 1, afts("VRF-B")+"/next-hops/next-hop[index=2]",
+			leaf{"state/index", uint64(2)},
+			leaf{"state/ip-address", "192.0.2.2"},
+			leaf{"interface-ref/state/interface", "port2"},
+		),
+	} {
+		if err := ss.Cache.addAFTNotification(n); err != nil {
+			t.Fatalf("addAFTNotification() got unexpected error: %v", err)
+		}
+	}
+
+	a, err := ss.ToAFT(t, nil)
+	if err != nil {
+		t.Fatalf("ToAFT() got unexpected error: %v", err)
+	}
+	if got, want := a.Prefixes, map[string]uint64{"192.0.2.0/24": 1}; !cmp.Equal(got, want) {
+		t.Errorf("ToAFT() got default network instance prefixes %v, want %v", got, want)
+	}
+	if got, want := a.NetworkInstances["VRF-A"].PrefixNHGNetworkInstances, map[string]string{"198.51.100.0/24": "DEFAULT"}; !cmp.Equal(got, want) {
+		t.Errorf("ToAFT() got VRF-A prefix NHG network instances %v, want %v", got, want)
+	}
+	if diff := cmp.Diff(map[uint64]*aftLabelEntry{100: {NHGID: 1}}, a.LabelEntries); diff != "" {
+		t.Errorf("ToAFT() got unexpected label entries diff (-want +got):\n%s", diff)
+	}
+	wantPF := map[uint64]*aftPolicyForwardingEntry{1: {IPPrefix: "198.51.100.0/24", NHGID: 1, NHGNetworkInstance: "VRF-B"}}
+	if diff := cmp.Diff(wantPF, a.PolicyForwardingEntries); diff != "" {
+		t.Errorf("ToAFT() got unexpected policy forwarding entries diff (-want +got):\n%s", diff)
+	}
+
+	want := []*aftNextHop{{IP: "192.0.2.2", IntfName: "port2"}}
+	got, err := a.ResolveRouteInNetworkInstance("VRF-A", "198.51.100.0/24", 0)
+	if err != nil {
+		t.Fatalf("ResolveRouteInNetworkInstance(VRF-A) got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveRouteInNetworkInstance(VRF-A) got unexpected diff (-want +got):\n%s", diff)
+	}
+	if got, err = a.resolveRoute("192.0.2.0/24"); err != nil {
+		t.Fatalf("resolveRoute() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resolveRoute() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if got, err = a.ResolveLabel("DEFAULT", 100); err != nil {
+		t.Fatalf("ResolveLabel() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveLabel() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Without VRF-B, the next hop of the default network instance cannot be resolved.
+	delete(a.NetworkInstances, "VRF-B")
+	if _, err := a.ResolveRouteInNetworkInstance("VRF-A", "198.51.100.0/24", 0); !errors.Is(err, ErrNotExist) {
+		t.Errorf("ResolveRouteInNetworkInstance(VRF-A) without VRF-B got error %v, want %v", err, ErrNotExist)
+	}
+}
@@ -0,0 +1,783 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gribi_programming.proto defines the gRIBI programming profiles that
+// gribi.LoadProfile loads from textproto or YAML files, so that tests program
+// many entries without building them in code.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: gribi_programming.proto
+
+package gribiprogramming
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A programming profile, which generates next hops, next-hop-groups and
+// prefix entries.  The entries are programmed in the order of the groups,
+// with all the next hops first, then the next-hop-groups and then the
+// prefix entries.
+type Profile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pools of addresses that the next hops and prefixes draw from.
+	IpPools []*IPPool `protobuf:"bytes,1,rep,name=ip_pools,json=ipPools,proto3" json:"ip_pools,omitempty"`
+	// Pools of next hop indices and next-hop-group IDs.
+	IdPools []*IDPool `protobuf:"bytes,2,rep,name=id_pools,json=idPools,proto3" json:"id_pools,omitempty"`
+	// Groups of entries, each of which generates next-hop-groups with their
+	// next hops and the prefixes that resolve to them.
+	Groups []*EntryGroup `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Number of entries sent in a batch, after which the results of the batch
+	// are awaited.  Defaults to 1000.
+	BatchSize     int32 `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_gribi_programming_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Profile) GetIpPools() []*IPPool {
+	if x != nil {
+		return x.IpPools
+	}
+	return nil
+}
+
+func (x *Profile) GetIdPools() []*IDPool {
+	if x != nil {
+		return x.IdPools
+	}
+	return nil
+}
+
+func (x *Profile) GetGroups() []*EntryGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Profile) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// A pool of IP addresses, which are drawn in order.
+type IPPool struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The first address, IPv4 or IPv6.
+	Start string `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// Step between the addresses.  Defaults to "0.0.0.1" for IPv4 and "::1"
+	// for IPv6.
+	Step string `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	// Number of addresses in the pool.
+	Count         int32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPPool) Reset() {
+	*x = IPPool{}
+	mi := &file_gribi_programming_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPPool) ProtoMessage() {}
+
+func (x *IPPool) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPPool.ProtoReflect.Descriptor instead.
+func (*IPPool) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IPPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IPPool) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *IPPool) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *IPPool) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// A pool of next hop indices and next-hop-group IDs, like tescale.IDPool.
+// The indices and IDs are drawn separately, starting at base + 1.
+type IDPool struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Base          uint64                 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IDPool) Reset() {
+	*x = IDPool{}
+	mi := &file_gribi_programming_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IDPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDPool) ProtoMessage() {}
+
+func (x *IDPool) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDPool.ProtoReflect.Descriptor instead.
+func (*IDPool) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IDPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IDPool) GetBase() uint64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+// A group of entries, which generates count next-hop-groups, the next hops
+// of each next-hop-group, and the prefixes that resolve to each of them.
+type EntryGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name by which other groups refer to this group.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of next-hop-groups generated.  Defaults to 1.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Network instance of the next hops and next-hop-groups.  Defaults to the
+	// default network instance of the DUT.
+	NetworkInstance string `protobuf:"bytes,3,opt,name=network_instance,json=networkInstance,proto3" json:"network_instance,omitempty"`
+	// ID pool of the next hops and next-hop-groups.
+	IdPool string `protobuf:"bytes,4,opt,name=id_pool,json=idPool,proto3" json:"id_pool,omitempty"`
+	// Next hops of each next-hop-group.
+	NextHops []*NextHop `protobuf:"bytes,5,rep,name=next_hops,json=nextHops,proto3" json:"next_hops,omitempty"`
+	// Name of a group of one next-hop-group in the same network instance,
+	// which is the backup next-hop-group of the next-hop-groups of this group.
+	Backup string `protobuf:"bytes,6,opt,name=backup,proto3" json:"backup,omitempty"`
+	// Prefixes that resolve to each next-hop-group.
+	Prefixes      *Prefixes `protobuf:"bytes,7,opt,name=prefixes,proto3" json:"prefixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryGroup) Reset() {
+	*x = EntryGroup{}
+	mi := &file_gribi_programming_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryGroup) ProtoMessage() {}
+
+func (x *EntryGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryGroup.ProtoReflect.Descriptor instead.
+func (*EntryGroup) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EntryGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EntryGroup) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *EntryGroup) GetNetworkInstance() string {
+	if x != nil {
+		return x.NetworkInstance
+	}
+	return ""
+}
+
+func (x *EntryGroup) GetIdPool() string {
+	if x != nil {
+		return x.IdPool
+	}
+	return ""
+}
+
+func (x *EntryGroup) GetNextHops() []*NextHop {
+	if x != nil {
+		return x.NextHops
+	}
+	return nil
+}
+
+func (x *EntryGroup) GetBackup() string {
+	if x != nil {
+		return x.Backup
+	}
+	return ""
+}
+
+func (x *EntryGroup) GetPrefixes() *Prefixes {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+// Next hops of a next-hop-group.
+type NextHop struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of next hops generated for each next-hop-group.  Defaults to 1.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Weight of the next hops in the next-hop-group.  Defaults to 1.
+	Weight uint64 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Types that are valid to be assigned to Address:
+	//
+	//	*NextHop_IpAddress
+	//	*NextHop_IpPool
+	Address    isNextHop_Address `protobuf_oneof:"address"`
+	Interface  string            `protobuf:"bytes,5,opt,name=interface,proto3" json:"interface,omitempty"`
+	MacAddress string            `protobuf:"bytes,6,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	// Network instance to look up the packets in, e.g. after they are
+	// decapsulated.
+	NextHopNetworkInstance string `protobuf:"bytes,7,opt,name=next_hop_network_instance,json=nextHopNetworkInstance,proto3" json:"next_hop_network_instance,omitempty"`
+	// Decapsulate IP-in-IP packets.
+	Decapsulate bool `protobuf:"varint,8,opt,name=decapsulate,proto3" json:"decapsulate,omitempty"`
+	// Encapsulate the packets in IP-in-IP.
+	Encapsulate   *IPinIP `protobuf:"bytes,9,opt,name=encapsulate,proto3" json:"encapsulate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextHop) Reset() {
+	*x = NextHop{}
+	mi := &file_gribi_programming_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextHop) ProtoMessage() {}
+
+func (x *NextHop) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextHop.ProtoReflect.Descriptor instead.
+func (*NextHop) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NextHop) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NextHop) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *NextHop) GetAddress() isNextHop_Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *NextHop) GetIpAddress() string {
+	if x != nil {
+		if x, ok := x.Address.(*NextHop_IpAddress); ok {
+			return x.IpAddress
+		}
+	}
+	return ""
+}
+
+func (x *NextHop) GetIpPool() string {
+	if x != nil {
+		if x, ok := x.Address.(*NextHop_IpPool); ok {
+			return x.IpPool
+		}
+	}
+	return ""
+}
+
+func (x *NextHop) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *NextHop) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+func (x *NextHop) GetNextHopNetworkInstance() string {
+	if x != nil {
+		return x.NextHopNetworkInstance
+	}
+	return ""
+}
+
+func (x *NextHop) GetDecapsulate() bool {
+	if x != nil {
+		return x.Decapsulate
+	}
+	return false
+}
+
+func (x *NextHop) GetEncapsulate() *IPinIP {
+	if x != nil {
+		return x.Encapsulate
+	}
+	return nil
+}
+
+type isNextHop_Address interface {
+	isNextHop_Address()
+}
+
+type NextHop_IpAddress struct {
+	// IP address of the next hops.
+	IpAddress string `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3,oneof"`
+}
+
+type NextHop_IpPool struct {
+	// Pool that the IP addresses of the next hops are drawn from.
+	IpPool string `protobuf:"bytes,4,opt,name=ip_pool,json=ipPool,proto3,oneof"`
+}
+
+func (*NextHop_IpAddress) isNextHop_Address() {}
+
+func (*NextHop_IpPool) isNextHop_Address() {}
+
+// IP-in-IP encapsulation.
+type IPinIP struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Src   string                 `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
+	// Types that are valid to be assigned to Dst:
+	//
+	//	*IPinIP_DstAddress
+	//	*IPinIP_DstPool
+	Dst           isIPinIP_Dst `protobuf_oneof:"dst"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPinIP) Reset() {
+	*x = IPinIP{}
+	mi := &file_gribi_programming_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPinIP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPinIP) ProtoMessage() {}
+
+func (x *IPinIP) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPinIP.ProtoReflect.Descriptor instead.
+func (*IPinIP) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IPinIP) GetSrc() string {
+	if x != nil {
+		return x.Src
+	}
+	return ""
+}
+
+func (x *IPinIP) GetDst() isIPinIP_Dst {
+	if x != nil {
+		return x.Dst
+	}
+	return nil
+}
+
+func (x *IPinIP) GetDstAddress() string {
+	if x != nil {
+		if x, ok := x.Dst.(*IPinIP_DstAddress); ok {
+			return x.DstAddress
+		}
+	}
+	return ""
+}
+
+func (x *IPinIP) GetDstPool() string {
+	if x != nil {
+		if x, ok := x.Dst.(*IPinIP_DstPool); ok {
+			return x.DstPool
+		}
+	}
+	return ""
+}
+
+type isIPinIP_Dst interface {
+	isIPinIP_Dst()
+}
+
+type IPinIP_DstAddress struct {
+	DstAddress string `protobuf:"bytes,2,opt,name=dst_address,json=dstAddress,proto3,oneof"`
+}
+
+type IPinIP_DstPool struct {
+	// Pool that the destinations are drawn from.
+	DstPool string `protobuf:"bytes,3,opt,name=dst_pool,json=dstPool,proto3,oneof"`
+}
+
+func (*IPinIP_DstAddress) isIPinIP_Dst() {}
+
+func (*IPinIP_DstPool) isIPinIP_Dst() {}
+
+// Prefixes that resolve to a next-hop-group.
+type Prefixes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of prefixes of each next-hop-group.  Defaults to 1.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Pool that the addresses of the prefixes are drawn from.
+	IpPool string `protobuf:"bytes,2,opt,name=ip_pool,json=ipPool,proto3" json:"ip_pool,omitempty"`
+	// Prefix length.  Defaults to 32 for IPv4 and 128 for IPv6.
+	PrefixLength int32 `protobuf:"varint,3,opt,name=prefix_length,json=prefixLength,proto3" json:"prefix_length,omitempty"`
+	// Network instance of the prefixes.  Defaults to the network instance of
+	// the next-hop-groups.
+	NetworkInstance string `protobuf:"bytes,4,opt,name=network_instance,json=networkInstance,proto3" json:"network_instance,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Prefixes) Reset() {
+	*x = Prefixes{}
+	mi := &file_gribi_programming_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prefixes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prefixes) ProtoMessage() {}
+
+func (x *Prefixes) ProtoReflect() protoreflect.Message {
+	mi := &file_gribi_programming_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prefixes.ProtoReflect.Descriptor instead.
+func (*Prefixes) Descriptor() ([]byte, []int) {
+	return file_gribi_programming_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Prefixes) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Prefixes) GetIpPool() string {
+	if x != nil {
+		return x.IpPool
+	}
+	return ""
+}
+
+func (x *Prefixes) GetPrefixLength() int32 {
+	if x != nil {
+		return x.PrefixLength
+	}
+	return 0
+}
+
+func (x *Prefixes) GetNetworkInstance() string {
+	if x != nil {
+		return x.NetworkInstance
+	}
+	return ""
+}
+
+var File_gribi_programming_proto protoreflect.FileDescriptor
+
+const file_gribi_programming_proto_rawDesc = "" +
+	"\n" +
+	"\x17gribi_programming.proto\x12\x1bopenconfig.gribiprogramming\"\xe9\x01\n" +
+	"\aProfile\x12>\n" +
+	"\bip_pools\x18\x01 \x03(\v2#.openconfig.gribiprogramming.IPPoolR\aipPools\x12>\n" +
+	"\bid_pools\x18\x02 \x03(\v2#.openconfig.gribiprogramming.IDPoolR\aidPools\x12?\n" +
+	"\x06groups\x18\x03 \x03(\v2'.openconfig.gribiprogramming.EntryGroupR\x06groups\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\"\\\n" +
+	"\x06IPPool\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"0\n" +
+	"\x06IDPool\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04base\x18\x02 \x01(\x04R\x04base\"\x98\x02\n" +
+	"\n" +
+	"EntryGroup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12)\n" +
+	"\x10network_instance\x18\x03 \x01(\tR\x0fnetworkInstance\x12\x17\n" +
+	"\aid_pool\x18\x04 \x01(\tR\x06idPool\x12A\n" +
+	"\tnext_hops\x18\x05 \x03(\v2$.openconfig.gribiprogramming.NextHopR\bnextHops\x12\x16\n" +
+	"\x06backup\x18\x06 \x01(\tR\x06backup\x12A\n" +
+	"\bprefixes\x18\a \x01(\v2%.openconfig.gribiprogramming.PrefixesR\bprefixes\"\xe1\x02\n" +
+	"\aNextHop\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x04R\x06weight\x12\x1f\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tH\x00R\tipAddress\x12\x19\n" +
+	"\aip_pool\x18\x04 \x01(\tH\x00R\x06ipPool\x12\x1c\n" +
+	"\tinterface\x18\x05 \x01(\tR\tinterface\x12\x1f\n" +
+	"\vmac_address\x18\x06 \x01(\tR\n" +
+	"macAddress\x129\n" +
+	"\x19next_hop_network_instance\x18\a \x01(\tR\x16nextHopNetworkInstance\x12 \n" +
+	"\vdecapsulate\x18\b \x01(\bR\vdecapsulate\x12E\n" +
+	"\vencapsulate\x18\t \x01(\v2#.openconfig.gribiprogramming.IPinIPR\vencapsulateB\t\n" +
+	"\aaddress\"a\n" +
+	"\x06IPinIP\x12\x10\n" +
+	"\x03src\x18\x01 \x01(\tR\x03src\x12!\n" +
+	"\vdst_address\x18\x02 \x01(\tH\x00R\n" +
+	"dstAddress\x12\x1b\n" +
+	"\bdst_pool\x18\x03 \x01(\tH\x00R\adstPoolB\x05\n" +
+	"\x03dst\"\x89\x01\n" +
+	"\bPrefixes\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x17\n" +
+	"\aip_pool\x18\x02 \x01(\tR\x06ipPool\x12#\n" +
+	"\rprefix_length\x18\x03 \x01(\x05R\fprefixLength\x12)\n" +
+	"\x10network_instance\x18\x04 \x01(\tR\x0fnetworkInstanceBYZWgithub.com/openconfig/featureprofiles/proto/gribi_programming_go_proto;gribiprogrammingb\x06proto3"
+
+var (
+	file_gribi_programming_proto_rawDescOnce sync.Once
+	file_gribi_programming_proto_rawDescData []byte
+)
+
+func file_gribi_programming_proto_rawDescGZIP() []byte {
+	file_gribi_programming_proto_rawDescOnce.Do(func() {
+		file_gribi_programming_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gribi_programming_proto_rawDesc), len(file_gribi_programming_proto_rawDesc)))
+	})
+	return file_gribi_programming_proto_rawDescData
+}
+
+var file_gribi_programming_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gribi_programming_proto_goTypes = []any{
+	(*Profile)(nil),    // 0: openconfig.gribiprogramming.Profile
+	(*IPPool)(nil),     // 1: openconfig.gribiprogramming.IPPool
+	(*IDPool)(nil),     // 2: openconfig.gribiprogramming.IDPool
+	(*EntryGroup)(nil), // 3: openconfig.gribiprogramming.EntryGroup
+	(*NextHop)(nil),    // 4: openconfig.gribiprogramming.NextHop
+	(*IPinIP)(nil),     // 5: openconfig.gribiprogramming.IPinIP
+	(*Prefixes)(nil),   // 6: openconfig.gribiprogramming.Prefixes
+}
+var file_gribi_programming_proto_depIdxs = []int32{
+	1, // 0: openconfig.gribiprogramming.Profile.ip_pools:type_name -> openconfig.gribiprogramming.IPPool
+	2, // 1: openconfig.gribiprogramming.Profile.id_pools:type_name -> openconfig.gribiprogramming.IDPool
+	3, // 2: openconfig.gribiprogramming.Profile.groups:type_name -> openconfig.gribiprogramming.EntryGroup
+	4, // 3: openconfig.gribiprogramming.EntryGroup.next_hops:type_name -> openconfig.gribiprogramming.NextHop
+	6, // 4: openconfig.gribiprogramming.EntryGroup.prefixes:type_name -> openconfig.gribiprogramming.Prefixes
+	5, // 5: openconfig.gribiprogramming.NextHop.encapsulate:type_name -> openconfig.gribiprogramming.IPinIP
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_gribi_programming_proto_init() }
+func file_gribi_programming_proto_init() {
+	if File_gribi_programming_proto != nil {
+		return
+	}
+	file_gribi_programming_proto_msgTypes[4].OneofWrappers = []any{
+		(*NextHop_IpAddress)(nil),
+		(*NextHop_IpPool)(nil),
+	}
+	file_gribi_programming_proto_msgTypes[5].OneofWrappers = []any{
+		(*IPinIP_DstAddress)(nil),
+		(*IPinIP_DstPool)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gribi_programming_proto_rawDesc), len(file_gribi_programming_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gribi_programming_proto_goTypes,
+		DependencyIndexes: file_gribi_programming_proto_depIdxs,
+		MessageInfos:      file_gribi_programming_proto_msgTypes,
+	}.Build()
+	File_gribi_programming_proto = out.File
+	file_gribi_programming_proto_goTypes = nil
+	file_gribi_programming_proto_depIdxs = nil
+}
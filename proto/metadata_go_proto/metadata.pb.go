@@ -201,8 +201,11 @@ type Metadata struct {
 	// Whether this test only checks paths for presence rather than semantic
 	// checks.
 	PathPresenceTest bool `protobuf:"varint,7,opt,name=path_presence_test,json=pathPresenceTest,proto3" json:"path_presence_test,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Location of core files on the DUTs of each vendor, overriding the default
+	// for the vendor.
+	CoreFiles     []*Metadata_CoreFiles `protobuf:"bytes,8,rep,name=core_files,json=coreFiles,proto3" json:"core_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Metadata) Reset() {
@@ -284,6 +287,13 @@ func (x *Metadata) GetPathPresenceTest() bool {
 	return false
 }
 
+func (x *Metadata) GetCoreFiles() []*Metadata_CoreFiles {
+	if x != nil {
+		return x.CoreFiles
+	}
+	return nil
+}
+
 type Metadata_Platform struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Vendor of the device.
@@ -3543,11 +3553,75 @@ func (x *Metadata_PlatformExceptions) GetDeviations() *Metadata_Deviations {
 	return nil
 }
 
+type Metadata_CoreFiles struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Vendor of the device.
+	Vendor proto.Device_Vendor `protobuf:"varint,1,opt,name=vendor,proto3,enum=ondatra.Device_Vendor" json:"vendor,omitempty"`
+	// Directory listed with gNOI File.Stat to find core files.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Regular expression matching the full path of the core files in the
+	// directory.  If not set, every file in the directory is a core file.
+	NameRegex     string `protobuf:"bytes,3,opt,name=name_regex,json=nameRegex,proto3" json:"name_regex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata_CoreFiles) Reset() {
+	*x = Metadata_CoreFiles{}
+	mi := &file_metadata_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata_CoreFiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata_CoreFiles) ProtoMessage() {}
+
+func (x *Metadata_CoreFiles) ProtoReflect() protoreflect.Message {
+	mi := &file_metadata_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata_CoreFiles.ProtoReflect.Descriptor instead.
+func (*Metadata_CoreFiles) Descriptor() ([]byte, []int) {
+	return file_metadata_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *Metadata_CoreFiles) GetVendor() proto.Device_Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return proto.Device_Vendor(0)
+}
+
+func (x *Metadata_CoreFiles) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Metadata_CoreFiles) GetNameRegex() string {
+	if x != nil {
+		return x.NameRegex
+	}
+	return ""
+}
+
 var File_metadata_proto protoreflect.FileDescriptor
 
 const file_metadata_proto_rawDesc = "" +
 	"\n" +
-	"\x0emetadata.proto\x12\x12openconfig.testing\x1a1github.com/openconfig/ondatra/proto/testbed.proto\"\xcd\xc1\x01\n" +
+	"\x0emetadata.proto\x12\x12openconfig.testing\x1a1github.com/openconfig/ondatra/proto/testbed.proto\"\x84\xc3\x01\n" +
 	"\bMetadata\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x17\n" +
 	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12 \n" +
@@ -3555,7 +3629,9 @@ const file_metadata_proto_rawDesc = "" +
 	"\atestbed\x18\x04 \x01(\x0e2$.openconfig.testing.Metadata.TestbedR\atestbed\x12`\n" +
 	"\x13platform_exceptions\x18\x05 \x03(\v2/.openconfig.testing.Metadata.PlatformExceptionsR\x12platformExceptions\x125\n" +
 	"\x04tags\x18\x06 \x03(\x0e2!.openconfig.testing.Metadata.TagsR\x04tags\x12,\n" +
-	"\x12path_presence_test\x18\a \x01(\bR\x10pathPresenceTest\x1a\xb8\x01\n" +
+	"\x12path_presence_test\x18\a \x01(\bR\x10pathPresenceTest\x12E\n" +
+	"\n" +
+	"core_files\x18\b \x03(\v2&.openconfig.testing.Metadata.CoreFilesR\tcoreFiles\x1a\xb8\x01\n" +
 	"\bPlatform\x12.\n" +
 	"\x06vendor\x18\x01 \x01(\x0e2\x16.ondatra.Device.VendorR\x06vendor\x120\n" +
 	"\x14hardware_model_regex\x18\x03 \x01(\tR\x12hardwareModelRegex\x124\n" +
@@ -3888,7 +3964,12 @@ const file_metadata_proto_rawDesc = "" +
 	"\bplatform\x18\x01 \x01(\v2%.openconfig.testing.Metadata.PlatformR\bplatform\x12G\n" +
 	"\n" +
 	"deviations\x18\x02 \x01(\v2'.openconfig.testing.Metadata.DeviationsR\n" +
-	"deviations\"\xc3\x03\n" +
+	"deviations\x1an\n" +
+	"\tCoreFiles\x12.\n" +
+	"\x06vendor\x18\x01 \x01(\x0e2\x16.ondatra.Device.VendorR\x06vendor\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"name_regex\x18\x03 \x01(\tR\tnameRegex\"\xc3\x03\n" +
 	"\aTestbed\x12\x17\n" +
 	"\x13TESTBED_UNSPECIFIED\x10\x00\x12\x0f\n" +
 	"\vTESTBED_DUT\x10\x01\x12\x1a\n" +
@@ -3927,7 +4008,7 @@ func file_metadata_proto_rawDescGZIP() []byte {
 }
 
 var file_metadata_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_metadata_proto_goTypes = []any{
 	(Metadata_Testbed)(0),               // 0: openconfig.testing.Metadata.Testbed
 	(Metadata_Tags)(0),                  // 1: openconfig.testing.Metadata.Tags
@@ -3935,20 +4016,23 @@ var file_metadata_proto_goTypes = []any{
 	(*Metadata_Platform)(nil),           // 3: openconfig.testing.Metadata.Platform
 	(*Metadata_Deviations)(nil),         // 4: openconfig.testing.Metadata.Deviations
 	(*Metadata_PlatformExceptions)(nil), // 5: openconfig.testing.Metadata.PlatformExceptions
-	(proto.Device_Vendor)(0),            // 6: ondatra.Device.Vendor
+	(*Metadata_CoreFiles)(nil),          // 6: openconfig.testing.Metadata.CoreFiles
+	(proto.Device_Vendor)(0),            // 7: ondatra.Device.Vendor
 }
 var file_metadata_proto_depIdxs = []int32{
 	0, // 0: openconfig.testing.Metadata.testbed:type_name -> openconfig.testing.Metadata.Testbed
 	5, // 1: openconfig.testing.Metadata.platform_exceptions:type_name -> openconfig.testing.Metadata.PlatformExceptions
 	1, // 2: openconfig.testing.Metadata.tags:type_name -> openconfig.testing.Metadata.Tags
-	6, // 3: openconfig.testing.Metadata.Platform.vendor:type_name -> ondatra.Device.Vendor
-	3, // 4: openconfig.testing.Metadata.PlatformExceptions.platform:type_name -> openconfig.testing.Metadata.Platform
-	4, // 5: openconfig.testing.Metadata.PlatformExceptions.deviations:type_name -> openconfig.testing.Metadata.Deviations
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	6, // 3: openconfig.testing.Metadata.core_files:type_name -> openconfig.testing.Metadata.CoreFiles
+	7, // 4: openconfig.testing.Metadata.Platform.vendor:type_name -> ondatra.Device.Vendor
+	3, // 5: openconfig.testing.Metadata.PlatformExceptions.platform:type_name -> openconfig.testing.Metadata.Platform
+	4, // 6: openconfig.testing.Metadata.PlatformExceptions.deviations:type_name -> openconfig.testing.Metadata.Deviations
+	7, // 7: openconfig.testing.Metadata.CoreFiles.vendor:type_name -> ondatra.Device.Vendor
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_metadata_proto_init() }
@@ -3962,7 +4046,7 @@ func file_metadata_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_metadata_proto_rawDesc), len(file_metadata_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,480 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// refurbish.proto defines the per-vendor rules that fptest.GetDeviceConfig
+// applies to the config it gets from a device, so that the config can be
+// pushed back to the device.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: refurbish.proto
+
+package refurbish
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	gnmi "github.com/openconfig/gnmi/proto/gnmi"
+	proto "github.com/openconfig/ondatra/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Condition selects when the rules apply.
+type VendorRules_Condition int32
+
+const (
+	// The rules always apply.
+	VendorRules_ALWAYS VendorRules_Condition = 0
+	// The rules only apply without -base_oc_config_is_present, i.e. when
+	// the device has no OpenConfig config loaded, so that the config has to
+	// be rebuilt from the state.
+	VendorRules_BASE_OC_CONFIG_ABSENT VendorRules_Condition = 1
+)
+
+// Enum value maps for VendorRules_Condition.
+var (
+	VendorRules_Condition_name = map[int32]string{
+		0: "ALWAYS",
+		1: "BASE_OC_CONFIG_ABSENT",
+	}
+	VendorRules_Condition_value = map[string]int32{
+		"ALWAYS":                0,
+		"BASE_OC_CONFIG_ABSENT": 1,
+	}
+)
+
+func (x VendorRules_Condition) Enum() *VendorRules_Condition {
+	p := new(VendorRules_Condition)
+	*p = x
+	return p
+}
+
+func (x VendorRules_Condition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VendorRules_Condition) Descriptor() protoreflect.EnumDescriptor {
+	return file_refurbish_proto_enumTypes[0].Descriptor()
+}
+
+func (VendorRules_Condition) Type() protoreflect.EnumType {
+	return &file_refurbish_proto_enumTypes[0]
+}
+
+func (x VendorRules_Condition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VendorRules_Condition.Descriptor instead.
+func (VendorRules_Condition) EnumDescriptor() ([]byte, []int) {
+	return file_refurbish_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// Rules are the refurbishment rules of all vendors.
+type Rules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VendorRules   []*VendorRules         `protobuf:"bytes,1,rep,name=vendor_rules,json=vendorRules,proto3" json:"vendor_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rules) Reset() {
+	*x = Rules{}
+	mi := &file_refurbish_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rules) ProtoMessage() {}
+
+func (x *Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_refurbish_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rules.ProtoReflect.Descriptor instead.
+func (*Rules) Descriptor() ([]byte, []int) {
+	return file_refurbish_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rules) GetVendorRules() []*VendorRules {
+	if x != nil {
+		return x.VendorRules
+	}
+	return nil
+}
+
+// VendorRules are the refurbishment rules of a vendor.
+type VendorRules struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Vendor    proto.Device_Vendor    `protobuf:"varint,1,opt,name=vendor,proto3,enum=ondatra.Device_Vendor" json:"vendor,omitempty"`
+	Condition VendorRules_Condition  `protobuf:"varint,2,opt,name=condition,proto3,enum=openconfig.refurbish.VendorRules_Condition" json:"condition,omitempty"`
+	// Lists whose entries are taken from the state of the device rather than
+	// from its config.
+	ConfigFromState []*StateList `protobuf:"bytes,3,rep,name=config_from_state,json=configFromState,proto3" json:"config_from_state,omitempty"`
+	// Rules applied in order to the config, after config_from_state.
+	Rules         []*Rule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VendorRules) Reset() {
+	*x = VendorRules{}
+	mi := &file_refurbish_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VendorRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VendorRules) ProtoMessage() {}
+
+func (x *VendorRules) ProtoReflect() protoreflect.Message {
+	mi := &file_refurbish_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VendorRules.ProtoReflect.Descriptor instead.
+func (*VendorRules) Descriptor() ([]byte, []int) {
+	return file_refurbish_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VendorRules) GetVendor() proto.Device_Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return proto.Device_Vendor(0)
+}
+
+func (x *VendorRules) GetCondition() VendorRules_Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return VendorRules_ALWAYS
+}
+
+func (x *VendorRules) GetConfigFromState() []*StateList {
+	if x != nil {
+		return x.ConfigFromState
+	}
+	return nil
+}
+
+func (x *VendorRules) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// StateList is a list whose entries are taken from the state of the device.
+// The config false leaves of the entries are pruned.
+type StateList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the list, e.g. "/interfaces/interface".
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Replace all the entries of the list in the config.  Otherwise, only the
+	// entries in the state replace those in the config, and the entries only
+	// in the config are kept.
+	Replace bool `protobuf:"varint,2,opt,name=replace,proto3" json:"replace,omitempty"`
+	// Rules applied in order to the entries taken from the state, before they
+	// replace those in the config.  The rules do not apply to the entries only
+	// in the config, but an entry that they drop is also dropped from the
+	// config.
+	Rules         []*Rule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateList) Reset() {
+	*x = StateList{}
+	mi := &file_refurbish_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateList) ProtoMessage() {}
+
+func (x *StateList) ProtoReflect() protoreflect.Message {
+	mi := &file_refurbish_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateList.ProtoReflect.Descriptor instead.
+func (*StateList) Descriptor() ([]byte, []int) {
+	return file_refurbish_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StateList) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *StateList) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+func (x *StateList) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Rule drops or rewrites the nodes matching a path.
+type Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// gNMI path of the nodes, in the string form used by ygot, e.g.
+	// "/interfaces/interface[name=*]/config/mtu".  A key value of "*" matches
+	// any key.  Paths use the config leaves rather than the state leaves.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*Rule_Drop
+	//	*Rule_Replace
+	Action isRule_Action `protobuf_oneof:"action"`
+	// Optional description of why the rule is needed.
+	Description   string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_refurbish_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_refurbish_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_refurbish_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Rule) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Rule) GetAction() isRule_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *Rule) GetDrop() bool {
+	if x != nil {
+		if x, ok := x.Action.(*Rule_Drop); ok {
+			return x.Drop
+		}
+	}
+	return false
+}
+
+func (x *Rule) GetReplace() *gnmi.TypedValue {
+	if x != nil {
+		if x, ok := x.Action.(*Rule_Replace); ok {
+			return x.Replace
+		}
+	}
+	return nil
+}
+
+func (x *Rule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type isRule_Action interface {
+	isRule_Action()
+}
+
+type Rule_Drop struct {
+	// Drop the matching nodes.
+	Drop bool `protobuf:"varint,2,opt,name=drop,proto3,oneof"`
+}
+
+type Rule_Replace struct {
+	// Replace the value of the matching leaves.
+	Replace *gnmi.TypedValue `protobuf:"bytes,3,opt,name=replace,proto3,oneof"`
+}
+
+func (*Rule_Drop) isRule_Action() {}
+
+func (*Rule_Replace) isRule_Action() {}
+
+var File_refurbish_proto protoreflect.FileDescriptor
+
+const file_refurbish_proto_rawDesc = "" +
+	"\n" +
+	"\x0frefurbish.proto\x12\x14openconfig.refurbish\x1a0github.com/openconfig/gnmi/proto/gnmi/gnmi.proto\x1a1github.com/openconfig/ondatra/proto/testbed.proto\"M\n" +
+	"\x05Rules\x12D\n" +
+	"\fvendor_rules\x18\x01 \x03(\v2!.openconfig.refurbish.VendorRulesR\vvendorRules\"\xbb\x02\n" +
+	"\vVendorRules\x12.\n" +
+	"\x06vendor\x18\x01 \x01(\x0e2\x16.ondatra.Device.VendorR\x06vendor\x12I\n" +
+	"\tcondition\x18\x02 \x01(\x0e2+.openconfig.refurbish.VendorRules.ConditionR\tcondition\x12K\n" +
+	"\x11config_from_state\x18\x03 \x03(\v2\x1f.openconfig.refurbish.StateListR\x0fconfigFromState\x120\n" +
+	"\x05rules\x18\x04 \x03(\v2\x1a.openconfig.refurbish.RuleR\x05rules\"2\n" +
+	"\tCondition\x12\n" +
+	"\n" +
+	"\x06ALWAYS\x10\x00\x12\x19\n" +
+	"\x15BASE_OC_CONFIG_ABSENT\x10\x01\"k\n" +
+	"\tStateList\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\areplace\x18\x02 \x01(\bR\areplace\x120\n" +
+	"\x05rules\x18\x03 \x03(\v2\x1a.openconfig.refurbish.RuleR\x05rules\"\x8a\x01\n" +
+	"\x04Rule\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x04drop\x18\x02 \x01(\bH\x00R\x04drop\x12,\n" +
+	"\areplace\x18\x03 \x01(\v2\x10.gnmi.TypedValueH\x00R\areplace\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescriptionB\b\n" +
+	"\x06actionBJZHgithub.com/openconfig/featureprofiles/proto/refurbish_go_proto;refurbishb\x06proto3"
+
+var (
+	file_refurbish_proto_rawDescOnce sync.Once
+	file_refurbish_proto_rawDescData []byte
+)
+
+func file_refurbish_proto_rawDescGZIP() []byte {
+	file_refurbish_proto_rawDescOnce.Do(func() {
+		file_refurbish_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_refurbish_proto_rawDesc), len(file_refurbish_proto_rawDesc)))
+	})
+	return file_refurbish_proto_rawDescData
+}
+
+var file_refurbish_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_refurbish_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_refurbish_proto_goTypes = []any{
+	(VendorRules_Condition)(0), // 0: openconfig.refurbish.VendorRules.Condition
+	(*Rules)(nil),              // 1: openconfig.refurbish.Rules
+	(*VendorRules)(nil),        // 2: openconfig.refurbish.VendorRules
+	(*StateList)(nil),          // 3: openconfig.refurbish.StateList
+	(*Rule)(nil),               // 4: openconfig.refurbish.Rule
+	(proto.Device_Vendor)(0),   // 5: ondatra.Device.Vendor
+	(*gnmi.TypedValue)(nil),    // 6: gnmi.TypedValue
+}
+var file_refurbish_proto_depIdxs = []int32{
+	2, // 0: openconfig.refurbish.Rules.vendor_rules:type_name -> openconfig.refurbish.VendorRules
+	5, // 1: openconfig.refurbish.VendorRules.vendor:type_name -> ondatra.Device.Vendor
+	0, // 2: openconfig.refurbish.VendorRules.condition:type_name -> openconfig.refurbish.VendorRules.Condition
+	3, // 3: openconfig.refurbish.VendorRules.config_from_state:type_name -> openconfig.refurbish.StateList
+	4, // 4: openconfig.refurbish.VendorRules.rules:type_name -> openconfig.refurbish.Rule
+	4, // 5: openconfig.refurbish.StateList.rules:type_name -> openconfig.refurbish.Rule
+	6, // 6: openconfig.refurbish.Rule.replace:type_name -> gnmi.TypedValue
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_refurbish_proto_init() }
+func file_refurbish_proto_init() {
+	if File_refurbish_proto != nil {
+		return
+	}
+	file_refurbish_proto_msgTypes[3].OneofWrappers = []any{
+		(*Rule_Drop)(nil),
+		(*Rule_Replace)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_refurbish_proto_rawDesc), len(file_refurbish_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_refurbish_proto_goTypes,
+		DependencyIndexes: file_refurbish_proto_depIdxs,
+		EnumInfos:         file_refurbish_proto_enumTypes,
+		MessageInfos:      file_refurbish_proto_msgTypes,
+	}.Build()
+	File_refurbish_proto = out.File
+	file_refurbish_proto_goTypes = nil
+	file_refurbish_proto_depIdxs = nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Program bindingcheck validates a static binding against a testbed before
+// running any test, and optionally probes the services of the DUTs.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openconfig/featureprofiles/topologies/binding"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+var (
+	dial    = flag.Bool("dial", false, "Also dial the gNMI, gNOI, gRIBI and SSH services of every DUT.")
+	timeout = flag.Duration("timeout", 10*time.Second, "Timeout of each dial.")
+)
+
+// readTextproto reads the textproto file named by the value of a flag.  The
+// -binding and -testbed flags are the ones the tests are run with, which are
+// defined by the binding and Ondatra.
+func readTextproto(flagName string, m proto.Message) error {
+	f := flag.Lookup(flagName)
+	if f == nil || f.Value.String() == "" {
+		return fmt.Errorf("-%s must be provided", flagName)
+	}
+	b, err := os.ReadFile(f.Value.String())
+	if err != nil {
+		return err
+	}
+	return prototext.Unmarshal(b, m)
+}
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+
+	b := &bindpb.Binding{}
+	if err := readTextproto("binding", b); err != nil {
+		glog.Exitf("Unable to read binding: %v", err)
+	}
+	tb := &opb.Testbed{}
+	if err := readTextproto("testbed", tb); err != nil {
+		glog.Exitf("Unable to read testbed: %v", err)
+	}
+
+	errs := binding.CheckBinding(ctx, b, tb)
+	for _, err := range errs {
+		fmt.Printf("FAIL: %v\n", err)
+	}
+	failed := len(errs) > 0
+	if !failed {
+		fmt.Println("PASS: binding matches the testbed")
+	}
+
+	if *dial && !failed {
+		results, err := binding.ProbeBinding(ctx, b, tb, *timeout)
+		if err != nil {
+			glog.Exitf("Unable to reserve the testbed: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DEVICE\tSERVICE\tTARGET\tRESULT")
+		for _, r := range results {
+			result := "PASS"
+			if r.Err != nil {
+				result = fmt.Sprintf("FAIL: %v", r.Err)
+				failed = true
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Device, r.Service, r.Target, result)
+		}
+		w.Flush()
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
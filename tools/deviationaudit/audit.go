@@ -0,0 +1,340 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	dpb "github.com/openconfig/featureprofiles/proto/deviations_go_proto"
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+)
+
+const (
+	deviationsPkg  = "github.com/openconfig/featureprofiles/internal/deviations"
+	deviationsDir  = "internal/deviations"
+	registryFile   = "internal/deviations/deviations.textproto"
+	metadataName   = "metadata.textproto"
+	dutDeviationFn = "dutDeviation"
+	ateDeviationFn = "ateDeviation"
+	protobufTagKey = "protobuf"
+)
+
+// audit holds everything collected from the tree before it is turned into
+// a report.  All paths are relative to the repository root and use forward
+// slashes.
+type audit struct {
+	// fields are the proto field names of Metadata.Deviations.
+	fields []string
+	// accessors maps a proto field name to the exported accessors in
+	// internal/deviations that read it.
+	accessors map[string][]string
+	// callSites maps an accessor to its call sites as "file:line".
+	callSites map[string][]string
+	// callerDirs maps an accessor to the directories of its callers.
+	callerDirs map[string]map[string]bool
+	// enabled maps a proto field name and a vendor to the test directories
+	// whose metadata.textproto enables the deviation for that vendor.
+	enabled map[string]map[string][]string
+	// testDirs are the directories that have a metadata.textproto.
+	testDirs map[string]bool
+	// issueURLs maps a registry deviation name and a vendor to the issue URLs
+	// of the registry entries for that vendor.  A vendor with a registry entry
+	// but no issue URL maps to an empty slice.
+	issueURLs map[string]map[string][]string
+}
+
+// read collects the deviation accessors, their call sites under feature/
+// and internal/, the deviations enabled by every metadata.textproto, and the
+// deviation registry from the repository at rootdir.
+func read(rootdir string) (*audit, error) {
+	a := &audit{
+		fields:     deviationFields(),
+		accessors:  make(map[string][]string),
+		callSites:  make(map[string][]string),
+		callerDirs: make(map[string]map[string]bool),
+		enabled:    make(map[string]map[string][]string),
+		testDirs:   make(map[string]bool),
+		issueURLs:  make(map[string]map[string][]string),
+	}
+	if err := a.readAccessors(rootdir); err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{"feature", "internal"} {
+		if err := a.readCallSites(rootdir, dir); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.readMetadata(rootdir); err != nil {
+		return nil, err
+	}
+	if err := a.readRegistry(rootdir); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// deviationFields returns the proto field names of Metadata.Deviations.
+func deviationFields() []string {
+	fds := (&mpb.Metadata_Deviations{}).ProtoReflect().Descriptor().Fields()
+	var fields []string
+	for i := 0; i < fds.Len(); i++ {
+		fields = append(fields, string(fds.Get(i).Name()))
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// getterFields maps the generated getter names of Metadata.Deviations, e.g.
+// GetOmitL2Mtu, to their proto field names, e.g. omit_l2_mtu.
+func getterFields() map[string]string {
+	getters := make(map[string]string)
+	t := reflect.TypeOf(mpb.Metadata_Deviations{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup(protobufTagKey)
+		if !ok {
+			continue
+		}
+		for _, part := range strings.Split(tag, ",") {
+			if name, ok := strings.CutPrefix(part, "name="); ok {
+				getters["Get"+f.Name] = name
+			}
+		}
+	}
+	return getters
+}
+
+// readAccessors finds the exported functions in internal/deviations that
+// read a field of the deviations with the getter passed to dutDeviation or
+// ateDeviation.
+func (a *audit) readAccessors(rootdir string) error {
+	getters := getterFields()
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(rootdir, deviationsDir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() || fn.Body == nil {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				for _, field := range deviationGetters(n, getters) {
+					if !slices.Contains(a.accessors[field], fn.Name.Name) {
+						a.accessors[field] = append(a.accessors[field], fn.Name.Name)
+					}
+				}
+				return true
+			})
+		}
+	}
+	for _, accessors := range a.accessors {
+		sort.Strings(accessors)
+	}
+	return nil
+}
+
+// deviationGetters returns the proto field names read by the getter if n is
+// a call of the form dutDeviation(dut, "Foo", getter), where getter is either
+// the method expression (*mpb.Metadata_Deviations).GetFoo or a function
+// calling devs.GetFoo().
+func deviationGetters(n ast.Node, getters map[string]string) []string {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) != 3 {
+		return nil
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || (ident.Name != dutDeviationFn && ident.Name != ateDeviationFn) {
+		return nil
+	}
+	var fields []string
+	ast.Inspect(call.Args[2], func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if field, ok := getters[sel.Sel.Name]; ok {
+				fields = append(fields, field)
+			}
+		}
+		return true
+	})
+	return fields
+}
+
+// readCallSites finds the references to internal/deviations accessors in
+// the Go files under dir, including test files.
+func (a *audit) readCallSites(rootdir, dir string) error {
+	fset := token.NewFileSet()
+	return filepath.WalkDir(filepath.Join(rootdir, dir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		rel, err := relPath(rootdir, path)
+		if err != nil {
+			return err
+		}
+		if filepath.ToSlash(filepath.Dir(rel)) == deviationsDir {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		name := importName(f)
+		if name == "" {
+			return nil
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == name {
+				accessor := sel.Sel.Name
+				pos := fset.Position(sel.Pos())
+				a.callSites[accessor] = append(a.callSites[accessor], fmt.Sprintf("%s:%d", rel, pos.Line))
+				if a.callerDirs[accessor] == nil {
+					a.callerDirs[accessor] = make(map[string]bool)
+				}
+				a.callerDirs[accessor][filepath.ToSlash(filepath.Dir(rel))] = true
+			}
+			return true
+		})
+		return nil
+	})
+}
+
+// importName returns the name under which f imports internal/deviations, or
+// "" if it does not.
+func importName(f *ast.File) string {
+	for _, imp := range f.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || path != deviationsPkg {
+			continue
+		}
+		if imp.Name == nil {
+			return filepath.Base(deviationsPkg)
+		}
+		if imp.Name.Name == "_" || imp.Name.Name == "." {
+			return ""
+		}
+		return imp.Name.Name
+	}
+	return ""
+}
+
+// readMetadata records the deviations enabled by the platform_exceptions of
+// every metadata.textproto under feature/.
+func (a *audit) readMetadata(rootdir string) error {
+	return filepath.WalkDir(filepath.Join(rootdir, "feature"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != metadataName {
+			return nil
+		}
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		md := &mpb.Metadata{}
+		if err := prototext.Unmarshal(bytes, md); err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		rel, err := relPath(rootdir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		a.testDirs[rel] = true
+		for _, pe := range md.GetPlatformExceptions() {
+			vendor := pe.GetPlatform().GetVendor().String()
+			pe.GetDeviations().ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+				field := string(fd.Name())
+				if a.enabled[field] == nil {
+					a.enabled[field] = make(map[string][]string)
+				}
+				if !slices.Contains(a.enabled[field][vendor], rel) {
+					a.enabled[field][vendor] = append(a.enabled[field][vendor], rel)
+				}
+				return true
+			})
+		}
+		return nil
+	})
+}
+
+// readRegistry records the issue URLs of the deviation registry.  A missing
+// registry is not an error since not every tree has one.
+func (a *audit) readRegistry(rootdir string) error {
+	bytes, err := os.ReadFile(filepath.Join(rootdir, registryFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	reg := &dpb.DeviationRegistry{}
+	if err := prototext.Unmarshal(bytes, reg); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", registryFile, err)
+	}
+	for _, dev := range reg.GetDeviations() {
+		if a.issueURLs[dev.GetName()] == nil {
+			a.issueURLs[dev.GetName()] = make(map[string][]string)
+		}
+		urls := a.issueURLs[dev.GetName()]
+		for _, pd := range dev.GetPlatforms() {
+			vendor := pd.GetPlatform().GetVendor().String()
+			if urls[vendor] == nil {
+				urls[vendor] = []string{}
+			}
+			if url := pd.GetIssueUrl(); url != "" && !slices.Contains(urls[vendor], url) {
+				urls[vendor] = append(urls[vendor], url)
+			}
+		}
+	}
+	return nil
+}
+
+func relPath(rootdir, path string) (string, error) {
+	rel, err := filepath.Rel(rootdir, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
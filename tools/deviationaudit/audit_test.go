@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testTree = map[string]string{
+	"internal/deviations/deviations.go": `package deviations
+
+func dutDeviation(dut any, accessor string, get any) any { return nil }
+
+// OmitL2MTU is an accessor.
+func OmitL2MTU(dut any) bool {
+	return dutDeviation(dut, "OmitL2MTU", (*mpb.Metadata_Deviations).GetOmitL2Mtu)
+}
+
+// DefaultNetworkInstance is an accessor with a fallback.
+func DefaultNetworkInstance(dut any) string {
+	return dutDeviation(dut, "DefaultNetworkInstance", func(devs *mpb.Metadata_Deviations) string {
+		if dni := devs.GetDefaultNetworkInstance(); dni != "" {
+			return dni
+		}
+		return "DEFAULT"
+	})
+}
+`,
+	"internal/deviations/deviations.textproto": `
+deviations: {
+  name: "default_network_instance"
+  platforms: {
+    issue_url: "https://example.com/1"
+    platform: { vendor: ARISTA }
+  }
+  platforms: {
+    platform: { vendor: NOKIA }
+  }
+}
+`,
+	"internal/helper/helper.go": `package helper
+
+import dev "github.com/openconfig/featureprofiles/internal/deviations"
+
+func Name(dut any) string {
+	return dev.DefaultNetworkInstance(dut)
+}
+`,
+	"feature/foo/otg_tests/bar_test/bar_test.go": `package bar_test
+
+import "github.com/openconfig/featureprofiles/internal/deviations"
+
+func TestBar(t *testing.T) {
+	if deviations.OmitL2MTU(dut) {
+		return
+	}
+}
+`,
+	"feature/foo/otg_tests/bar_test/metadata.textproto": `
+platform_exceptions: {
+  platform: { vendor: ARISTA }
+  deviations: {
+    omit_l2_mtu: true
+    default_network_instance: "default"
+  }
+}
+`,
+	"feature/foo/otg_tests/baz_test/baz_test.go": `package baz_test
+`,
+	"feature/foo/otg_tests/baz_test/metadata.textproto": `
+platform_exceptions: {
+  platform: { vendor: CISCO }
+  deviations: {
+    omit_l2_mtu: true
+    interface_enabled: true
+  }
+}
+`,
+}
+
+func writeTree(t *testing.T, tree map[string]string) string {
+	t.Helper()
+	rootdir := t.TempDir()
+	for name, content := range tree {
+		path := filepath.Join(rootdir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rootdir
+}
+
+func TestRead(t *testing.T) {
+	a, err := read(writeTree(t, testTree))
+	if err != nil {
+		t.Fatalf("read() got unexpected error: %v", err)
+	}
+
+	wantAccessors := map[string][]string{
+		"default_network_instance": {"DefaultNetworkInstance"},
+		"omit_l2_mtu":              {"OmitL2MTU"},
+	}
+	if diff := cmp.Diff(wantAccessors, a.accessors); diff != "" {
+		t.Errorf("accessors -want,+got:\n%s", diff)
+	}
+
+	wantCallSites := map[string][]string{
+		"DefaultNetworkInstance": {"internal/helper/helper.go:6"},
+		"OmitL2MTU":              {"feature/foo/otg_tests/bar_test/bar_test.go:6"},
+	}
+	if diff := cmp.Diff(wantCallSites, a.callSites); diff != "" {
+		t.Errorf("callSites -want,+got:\n%s", diff)
+	}
+
+	wantEnabled := map[string]map[string][]string{
+		"default_network_instance": {"ARISTA": {"feature/foo/otg_tests/bar_test"}},
+		"interface_enabled":        {"CISCO": {"feature/foo/otg_tests/baz_test"}},
+		"omit_l2_mtu": {
+			"ARISTA": {"feature/foo/otg_tests/bar_test"},
+			"CISCO":  {"feature/foo/otg_tests/baz_test"},
+		},
+	}
+	if diff := cmp.Diff(wantEnabled, a.enabled); diff != "" {
+		t.Errorf("enabled -want,+got:\n%s", diff)
+	}
+
+	wantIssueURLs := map[string]map[string][]string{
+		"default_network_instance": {
+			"ARISTA": {"https://example.com/1"},
+			"NOKIA":  {},
+		},
+	}
+	if diff := cmp.Diff(wantIssueURLs, a.issueURLs); diff != "" {
+		t.Errorf("issueURLs -want,+got:\n%s", diff)
+	}
+}
+
+func TestReadInvalidMetadata(t *testing.T) {
+	rootdir := writeTree(t, map[string]string{
+		"feature/foo/tests/bar_test/metadata.textproto": `platform_exceptions: { no_such_field: true }`,
+	})
+	if _, err := read(rootdir); err == nil {
+		t.Errorf("read() got no error, want error")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Program deviationaudit reports how the deviations in internal/deviations are
+// used across the featureprofiles tree.
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"github.com/openconfig/featureprofiles/tools/internal/fpciutil"
+)
+
+var (
+	dir    = flag.String("dir", "", "Root of the featureprofiles repository; if not specified, uses the parent of the ancestor 'feature' directory.")
+	format = flag.String("format", "csv", "Report format, one of: csv, json")
+)
+
+func main() {
+	flag.Parse()
+
+	rootdir := *dir
+	if rootdir == "" {
+		featuredir, err := fpciutil.FeatureDir()
+		if err != nil {
+			glog.Exitf("Unable to locate feature root: %v", err)
+		}
+		rootdir = filepath.Dir(featuredir)
+	}
+
+	a, err := read(rootdir)
+	if err != nil {
+		glog.Exitf("Unable to audit deviations: %v", err)
+	}
+	rows := a.report()
+
+	switch *format {
+	case "csv":
+		err = writeCSV(os.Stdout, rows)
+	case "json":
+		err = writeJSON(os.Stdout, rows)
+	default:
+		glog.Exitf("Unknown report format: %s", *format)
+	}
+	if err != nil {
+		glog.Exitf("Error writing %s: %v", *format, err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// row is the report of one deviation for one vendor.  A deviation that no
+// vendor enables is reported once with an empty vendor.
+type row struct {
+	Deviation string   `json:"deviation"`
+	Vendor    string   `json:"vendor,omitempty"`
+	Accessors []string `json:"accessors,omitempty"`
+	CallSites []string `json:"call_sites,omitempty"`
+	// EnabledIn are the tests whose metadata enable the deviation for the
+	// vendor.
+	EnabledIn []string `json:"enabled_in,omitempty"`
+	// UncalledIn are the tests in EnabledIn that never call the deviation,
+	// neither directly nor through a shared helper package.
+	UncalledIn []string `json:"uncalled_in,omitempty"`
+	IssueURLs  []string `json:"issue_urls,omitempty"`
+	// Dead is set if the deviation has no call sites at all.
+	Dead bool `json:"dead"`
+	// MissingIssueURL is set if the deviation is enabled for the vendor in a
+	// test or in the registry, but the registry has no issue URL for it.
+	MissingIssueURL bool `json:"missing_issue_url"`
+}
+
+// report returns one row per deviation and vendor, sorted by deviation and
+// then by vendor.
+func (a *audit) report() []row {
+	names := map[string]bool{}
+	for _, f := range a.fields {
+		names[f] = true
+	}
+	for name := range a.issueURLs {
+		names[name] = true
+	}
+
+	var rows []row
+	for _, name := range sortedKeys(names) {
+		var callSites []string
+		callerDirs := map[string]bool{}
+		sharedCaller := false
+		for _, accessor := range a.accessors[name] {
+			callSites = append(callSites, a.callSites[accessor]...)
+			for dir := range a.callerDirs[accessor] {
+				callerDirs[dir] = true
+				if !a.testDirs[dir] {
+					sharedCaller = true
+				}
+			}
+		}
+		sort.Strings(callSites)
+
+		vendors := map[string]bool{}
+		for vendor := range a.enabled[name] {
+			vendors[vendor] = true
+		}
+		for vendor := range a.issueURLs[name] {
+			vendors[vendor] = true
+		}
+		if len(vendors) == 0 {
+			vendors[""] = true
+		}
+
+		for _, vendor := range sortedKeys(vendors) {
+			enabledIn := append([]string(nil), a.enabled[name][vendor]...)
+			sort.Strings(enabledIn)
+			var uncalledIn []string
+			if !sharedCaller {
+				for _, dir := range enabledIn {
+					if !callerDirs[dir] {
+						uncalledIn = append(uncalledIn, dir)
+					}
+				}
+			}
+			urls, inRegistry := a.issueURLs[name][vendor]
+			rows = append(rows, row{
+				Deviation:       name,
+				Vendor:          vendor,
+				Accessors:       a.accessors[name],
+				CallSites:       callSites,
+				EnabledIn:       enabledIn,
+				UncalledIn:      uncalledIn,
+				IssueURLs:       urls,
+				Dead:            len(callSites) == 0,
+				MissingIssueURL: vendor != "" && (len(enabledIn) > 0 || inRegistry) && len(urls) == 0,
+			})
+		}
+	}
+	return rows
+}
+
+// writeCSV writes the report as CSV.  Lists of tests and call sites are
+// summarized by their count; use JSON for the details.
+func writeCSV(w io.Writer, rows []row) error {
+	cw := csv.NewWriter(w)
+	heading := []string{"Deviation", "Vendor", "Accessors", "Call Sites", "Enabled Tests", "Uncalled Tests", "Issue URLs", "Dead", "Missing Issue URL"}
+	if err := cw.Write(heading); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		record := []string{
+			r.Deviation,
+			r.Vendor,
+			strings.Join(r.Accessors, " "),
+			strconv.Itoa(len(r.CallSites)),
+			strconv.Itoa(len(r.EnabledIn)),
+			strconv.Itoa(len(r.UncalledIn)),
+			strings.Join(r.IssueURLs, " "),
+			strconv.FormatBool(r.Dead),
+			strconv.FormatBool(r.MissingIssueURL),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJSON writes the report as a JSON array of rows.
+func writeJSON(w io.Writer, rows []row) error {
+	if rows == nil {
+		rows = []row{}
+	}
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testAudit(t *testing.T) *audit {
+	t.Helper()
+	a, err := read(writeTree(t, testTree))
+	if err != nil {
+		t.Fatalf("read() got unexpected error: %v", err)
+	}
+	// Only report the deviations used by the test tree.
+	a.fields = []string{"default_network_instance", "interface_enabled", "omit_l2_mtu"}
+	return a
+}
+
+func TestReport(t *testing.T) {
+	want := []row{{
+		Deviation: "default_network_instance",
+		Vendor:    "ARISTA",
+		Accessors: []string{"DefaultNetworkInstance"},
+		CallSites: []string{"internal/helper/helper.go:6"},
+		EnabledIn: []string{"feature/foo/otg_tests/bar_test"},
+		IssueURLs: []string{"https://example.com/1"},
+	}, {
+		Deviation:       "default_network_instance",
+		Vendor:          "NOKIA",
+		Accessors:       []string{"DefaultNetworkInstance"},
+		CallSites:       []string{"internal/helper/helper.go:6"},
+		IssueURLs:       []string{},
+		MissingIssueURL: true,
+	}, {
+		Deviation:       "interface_enabled",
+		Vendor:          "CISCO",
+		EnabledIn:       []string{"feature/foo/otg_tests/baz_test"},
+		UncalledIn:      []string{"feature/foo/otg_tests/baz_test"},
+		Dead:            true,
+		MissingIssueURL: true,
+	}, {
+		Deviation:       "omit_l2_mtu",
+		Vendor:          "ARISTA",
+		Accessors:       []string{"OmitL2MTU"},
+		CallSites:       []string{"feature/foo/otg_tests/bar_test/bar_test.go:6"},
+		EnabledIn:       []string{"feature/foo/otg_tests/bar_test"},
+		MissingIssueURL: true,
+	}, {
+		Deviation:       "omit_l2_mtu",
+		Vendor:          "CISCO",
+		Accessors:       []string{"OmitL2MTU"},
+		CallSites:       []string{"feature/foo/otg_tests/bar_test/bar_test.go:6"},
+		EnabledIn:       []string{"feature/foo/otg_tests/baz_test"},
+		UncalledIn:      []string{"feature/foo/otg_tests/baz_test"},
+		MissingIssueURL: true,
+	}}
+	got := testAudit(t).report()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("report -want,+got:\n%s", diff)
+	}
+}
+
+func TestReportUnused(t *testing.T) {
+	a := testAudit(t)
+	a.fields = []string{"omit_l2_mtu", "unused_deviation"}
+	got := a.report()
+	want := row{Deviation: "unused_deviation", Dead: true}
+	if diff := cmp.Diff(want, got[len(got)-1]); diff != "" {
+		t.Errorf("report -want,+got:\n%s", diff)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	const want = `Deviation,Vendor,Accessors,Call Sites,Enabled Tests,Uncalled Tests,Issue URLs,Dead,Missing Issue URL
+default_network_instance,ARISTA,DefaultNetworkInstance,1,1,0,https://example.com/1,false,false
+default_network_instance,NOKIA,DefaultNetworkInstance,1,0,0,,false,true
+interface_enabled,CISCO,,0,1,1,,true,true
+omit_l2_mtu,ARISTA,OmitL2MTU,1,1,0,,false,true
+omit_l2_mtu,CISCO,OmitL2MTU,1,1,1,,false,true
+`
+	var buf strings.Builder
+	if err := writeCSV(&buf, testAudit(t).report()); err != nil {
+		t.Fatal("Could not write CSV:", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("writeCSV -want,+got:\n%s", diff)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rows := testAudit(t).report()
+	var buf strings.Builder
+	if err := writeJSON(&buf, rows); err != nil {
+		t.Fatal("Could not write JSON:", err)
+	}
+	var got []row
+	if err := json.Unmarshal([]byte(buf.String()), &got); err != nil {
+		t.Fatalf("Could not parse JSON: %v", err)
+	}
+	// Empty lists are omitted from the JSON.
+	rows[1].IssueURLs = nil
+	if diff := cmp.Diff(rows, got); diff != "" {
+		t.Errorf("writeJSON round trip -want,+got:\n%s", diff)
+	}
+}
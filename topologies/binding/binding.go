@@ -20,13 +20,20 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/golang/glog"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/deviations"
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnoigo"
@@ -41,9 +48,12 @@ import (
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/knownhosts"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -68,23 +78,55 @@ type staticDUT struct {
 	*binding.AbstractDUT
 	r   resolver
 	dev *bindpb.Device
+	// checkpoint restores the config of the DUT on release, if the binding
+	// asks for a checkpoint.
+	checkpoint *gpb.SetRequest
 }
 
 // RPCUsername returns the username for RPC connections to the DUT.
 func (d *staticDUT) RPCUsername() string {
-	// Return the device-specific username, or the global username.
-	opts := merge(d.r.Options, d.dev.Options)
-	return opts.GetUsername()
+	username, _ := d.rpcUserPass()
+	return username
 }
 
 // RPCPassword returns the password for RPC connections to the DUT.
 func (d *staticDUT) RPCPassword() string {
-	// Return the device-specific password, or the global password.
+	_, password := d.rpcUserPass()
+	return password
+}
+
+func (d *staticDUT) rpcUserPass() (string, string) {
+	// Return the device-specific credentials, or the global credentials.
 	opts := merge(d.r.Options, d.dev.Options)
-	return opts.GetPassword()
+	username, password, err := userPass(context.Background(), opts)
+	if err != nil {
+		glog.Errorf("Cannot resolve the RPC credentials of %s: %v", d.Name(), err)
+	}
+	return username, password
 }
 
-var _ introspect.Introspector = (*staticDUT)(nil)
+// CoreFiles returns the location of core files on the DUT from the binding,
+// or nil if the binding does not specify one.
+func (d *staticDUT) CoreFiles() *bindpb.CoreFiles {
+	return d.dev.GetCoreFiles()
+}
+
+var (
+	_ introspect.Introspector = (*staticDUT)(nil)
+	_ ProfileDUT              = (*staticDUT)(nil)
+)
+
+// ProfileDUT is a DUT whose services can be dialed with the named dial
+// profiles of the binding, so that a test can open sessions as different
+// clients, e.g. a primary and a backup gRIBI client:
+//
+//	pd, ok := dut.RawAPIs().BindingDUT().(binding.ProfileDUT)
+//	backup, err := pd.DialGRIBIProfile(ctx, "backup")
+type ProfileDUT interface {
+	DialGNMIProfile(ctx context.Context, profile string, opts ...grpc.DialOption) (gpb.GNMIClient, error)
+	DialGRIBIProfile(ctx context.Context, profile string, opts ...grpc.DialOption) (grpb.GRIBIClient, error)
+	DialP4RTProfile(ctx context.Context, profile string, opts ...grpc.DialOption) (p4pb.P4RuntimeClient, error)
+}
 
 type staticATE struct {
 	*binding.AbstractATE
@@ -116,6 +158,9 @@ func (b *staticBind) Reserve(ctx context.Context, tb *opb.Testbed, runTime, wait
 		if err := b.reset(ctx); err != nil {
 			return nil, err
 		}
+		if err := b.takeCheckpoints(ctx); err != nil {
+			return nil, err
+		}
 	}
 	if err := b.reserveIxSessions(ctx); err != nil {
 		return nil, err
@@ -127,11 +172,15 @@ func (b *staticBind) Release(ctx context.Context) error {
 	if b.resv == nil {
 		return errors.New("no reservation")
 	}
-	if err := b.releaseIxSessions(ctx); err != nil {
-		return err
-	}
+	// Every step is run even if an earlier one fails, so that a device that
+	// cannot be restored does not leak the Ixia sessions or the recordings.
+	err := errors.Join(
+		b.restoreCheckpoints(ctx),
+		b.releaseIxSessions(ctx),
+		closeRecorders(),
+	)
 	b.resv = nil
-	return nil
+	return err
 }
 
 func (b *staticBind) FetchReservation(_ context.Context, id string) (*binding.Reservation, error) {
@@ -150,13 +199,54 @@ func (b *staticBind) reset(ctx context.Context) error {
 	return nil
 }
 
+func (b *staticBind) takeCheckpoints(ctx context.Context) error {
+	for _, dut := range b.resv.DUTs {
+		if sdut, ok := dut.(*staticDUT); ok {
+			if err := sdut.takeCheckpoint(ctx); err != nil {
+				return fmt.Errorf("could not checkpoint device %s: %w", sdut.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// restoreCheckpoints restores the checkpoints of every DUT, even if some of
+// them fail.
+func (b *staticBind) restoreCheckpoints(ctx context.Context) error {
+	var errs []error
+	for _, dut := range b.resv.DUTs {
+		if sdut, ok := dut.(*staticDUT); ok {
+			if err := sdut.restoreCheckpoint(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("could not restore device %s: %w", sdut.Name(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (d *staticDUT) Dialer(svc introspect.Service) (*introspect.Dialer, error) {
+	return d.profileDialer(svc, "")
+}
+
+// profileDialer returns the dialer of a service with the named dial profile
+// of the binding, or with the options of the service if the profile is empty.
+func (d *staticDUT) profileDialer(svc introspect.Service, profile string) (*introspect.Dialer, error) {
 	params, ok := dutSvcParams[svc]
 	if !ok {
 		return nil, fmt.Errorf("no known DUT service %v", svc)
 	}
-	bopts := d.r.grpc(d.dev, params)
-	return makeDialer(params, bopts)
+	bopts, err := withProfile(d.r.grpc(d.dev, params), profile)
+	if err != nil {
+		return nil, fmt.Errorf("%v of DUT %s: %w", svc, d.Name(), err)
+	}
+	dialer, err := makeDialer(params, bopts)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordDialer(dialer, d, svc); err != nil {
+		return nil, err
+	}
+	return dialer, nil
 }
 
 func (d *staticDUT) reset(ctx context.Context) error {
@@ -172,11 +262,24 @@ func (d *staticDUT) reset(ctx context.Context) error {
 }
 
 func (d *staticDUT) DialGNMI(ctx context.Context, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
-	conn, err := dialConn(ctx, d, introspect.GNMI, opts)
+	return d.DialGNMIProfile(ctx, "", opts...)
+}
+
+// DialGNMIProfile dials gNMI with the named dial profile of the binding.
+func (d *staticDUT) DialGNMIProfile(ctx context.Context, profile string, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
+	conn, err := d.dialProfile(ctx, introspect.GNMI, profile, opts)
 	if err != nil {
 		return nil, err
 	}
-	return gpb.NewGNMIClient(conn), nil
+	c := gpb.NewGNMIClient(conn)
+	if !*ocValues {
+		return c, nil
+	}
+	reg, err := deviations.RegistryFor(d.Vendor(), d.HardwareModel(), d.SoftwareVersion())
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up deviation registry for DUT %q: %w", d.Name(), err)
+	}
+	return reg.RewriteGNMI(c), nil
 }
 
 func (d *staticDUT) DialGNPSI(ctx context.Context, opts ...grpc.DialOption) (gnpsipb.GNPSIClient, error) {
@@ -204,7 +307,12 @@ func (d *staticDUT) DialGNSI(ctx context.Context, opts ...grpc.DialOption) (bind
 }
 
 func (d *staticDUT) DialGRIBI(ctx context.Context, opts ...grpc.DialOption) (grpb.GRIBIClient, error) {
-	conn, err := dialConn(ctx, d, introspect.GRIBI, opts)
+	return d.DialGRIBIProfile(ctx, "", opts...)
+}
+
+// DialGRIBIProfile dials gRIBI with the named dial profile of the binding.
+func (d *staticDUT) DialGRIBIProfile(ctx context.Context, profile string, opts ...grpc.DialOption) (grpb.GRIBIClient, error) {
+	conn, err := d.dialProfile(ctx, introspect.GRIBI, profile, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -212,13 +320,26 @@ func (d *staticDUT) DialGRIBI(ctx context.Context, opts ...grpc.DialOption) (grp
 }
 
 func (d *staticDUT) DialP4RT(ctx context.Context, opts ...grpc.DialOption) (p4pb.P4RuntimeClient, error) {
-	conn, err := dialConn(ctx, d, introspect.P4RT, opts)
+	return d.DialP4RTProfile(ctx, "", opts...)
+}
+
+// DialP4RTProfile dials P4RT with the named dial profile of the binding.
+func (d *staticDUT) DialP4RTProfile(ctx context.Context, profile string, opts ...grpc.DialOption) (p4pb.P4RuntimeClient, error) {
+	conn, err := d.dialProfile(ctx, introspect.P4RT, profile, opts)
 	if err != nil {
 		return nil, err
 	}
 	return p4pb.NewP4RuntimeClient(conn), nil
 }
 
+func (d *staticDUT) dialProfile(ctx context.Context, svc introspect.Service, profile string, opts []grpc.DialOption) (*grpc.ClientConn, error) {
+	dialer, err := d.profileDialer(svc, profile)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.Dial(ctx, opts...)
+}
+
 func (d *staticDUT) DialGRPCWithPort(ctx context.Context, port int, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	params := &svcParams{
 		port: port,
@@ -231,26 +352,33 @@ func (d *staticDUT) DialGRPCWithPort(ctx context.Context, port int, opts ...grpc
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gRPC dialer: %w", err)
 	}
+	if err := recordDialer(dialer, d, introspect.Service(fmt.Sprintf("port%d", port))); err != nil {
+		return nil, err
+	}
 	return dialer.Dial(ctx, opts...)
 }
 
-func (d *staticDUT) DialCLI(context.Context) (binding.CLIClient, error) {
+func (d *staticDUT) DialCLI(ctx context.Context) (binding.CLIClient, error) {
 	sshOpts := d.r.ssh(d.dev)
+	username, password, err := userPass(ctx, sshOpts)
+	if err != nil {
+		return nil, err
+	}
 	config := &ssh.ClientConfig{
-		User: sshOpts.Username,
+		User: username,
 		Auth: []ssh.AuthMethod{
-			ssh.Password(sshOpts.Password),
-			ssh.KeyboardInteractive(sshInteractive(sshOpts.Password)),
+			ssh.Password(password),
+			ssh.KeyboardInteractive(sshInteractive(password)),
 		},
 	}
-	sc, err := createSSHClient(config, sshOpts)
+	sc, err := createSSHClient(ctx, config, sshOpts)
 	if err != nil {
 		return nil, err
 	}
 	return newCLI(sc)
 }
 
-func (d *staticDUT) DialSSH(_ context.Context, sshAuth binding.SSHAuth) (binding.SSHClient, error) {
+func (d *staticDUT) DialSSH(ctx context.Context, sshAuth binding.SSHAuth) (binding.SSHClient, error) {
 	var config *ssh.ClientConfig
 	var hk []byte
 	hkCallback := func(hostname string, remote net.Addr, key ssh.PublicKey) error {
@@ -302,14 +430,14 @@ func (d *staticDUT) DialSSH(_ context.Context, sshAuth binding.SSHAuth) (binding
 	default:
 		return nil, fmt.Errorf("ssh auth type %T not supported yet", auth)
 	}
-	sc, err := createSSHClient(config, d.r.ssh(d.dev))
+	sc, err := createSSHClient(ctx, config, d.r.ssh(d.dev))
 	if err != nil {
 		return nil, err
 	}
 	return newSSH(sc, hk)
 }
 
-func createSSHClient(config *ssh.ClientConfig, sshOpts *bindpb.Options) (*ssh.Client, error) {
+func createSSHClient(ctx context.Context, config *ssh.ClientConfig, sshOpts *bindpb.Options) (*ssh.Client, error) {
 	if sshOpts.SkipVerify {
 		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
 	} else {
@@ -319,7 +447,19 @@ func createSSHClient(config *ssh.ClientConfig, sshOpts *bindpb.Options) (*ssh.Cl
 		}
 		config.HostKeyCallback = cb
 	}
-	return ssh.Dial("tcp", sshOpts.Target, config)
+	if !tunneled(sshOpts) {
+		return ssh.Dial("tcp", sshOpts.Target, config)
+	}
+	conn, err := dialTunnel(ctx, sshOpts, sshOpts.Target)
+	if err != nil {
+		return nil, err
+	}
+	sc, chans, reqs, err := ssh.NewClientConn(conn, sshOpts.Target, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(sc, chans, reqs), nil
 }
 
 // For every question asked in an interactive login ssh session, set the answer to user password.
@@ -339,7 +479,14 @@ func (a *staticATE) Dialer(svc introspect.Service) (*introspect.Dialer, error) {
 		return nil, fmt.Errorf("no known ATE service %v", svc)
 	}
 	bopts := a.r.grpc(a.dev, params)
-	return makeDialer(params, bopts)
+	dialer, err := makeDialer(params, bopts)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordDialer(dialer, a, svc); err != nil {
+		return nil, err
+	}
+	return dialer, nil
 }
 
 func (a *staticATE) DialGNMI(ctx context.Context, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
@@ -354,6 +501,9 @@ func (a *staticATE) DialOTG(ctx context.Context, opts ...grpc.DialOption) (gosna
 	if a.dev.Otg == nil {
 		return nil, fmt.Errorf("otg must be configured in ATE binding to run OTG test")
 	}
+	if bopts := a.r.grpc(a.dev, ateSvcParams[introspect.OTG]); bopts.GetHealthCheck() != nil || len(bopts.GetFailoverTargets()) > 0 {
+		return a.dialOTGFailover(ctx, bopts, opts)
+	}
 	conn, err := dialConn(ctx, a, introspect.OTG, opts)
 	if err != nil {
 		return nil, err
@@ -538,9 +688,16 @@ func newIxWebClient(ctx context.Context, opts *bindpb.Options) (*ixweb.IxWeb, er
 	if opts.SkipVerify {
 		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
+	if tunneled(opts) {
+		tr.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+			return dialTunnel(ctx, opts, addr)
+		}
+	}
 	hc := &http.Client{Transport: tr}
-	username := opts.GetUsername()
-	password := opts.GetPassword()
+	username, password, err := userPass(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
 	if username == "" && password == "" {
 		username = "admin"
 		password = "admin"
@@ -595,26 +752,256 @@ func dialOpts(bopts *bindpb.Options) ([]grpc.DialOption, error) {
 		tlsConfig := credentials.NewTLS(tls)
 		opts = append(opts, grpc.WithTransportCredentials(tlsConfig))
 	}
-	if bopts.Username != "" {
-		c := &creds{bopts.Username, bopts.Password, !bopts.Insecure}
+	if bopts.Username != "" || bopts.Credentials != nil {
+		c := &creds{bopts.Username, bopts.Password, bopts.Credentials, !bopts.Insecure}
 		opts = append(opts, grpc.WithPerRPCCredentials(c))
 	}
 	if bopts.MaxRecvMsgSize != 0 {
 		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(bopts.MaxRecvMsgSize))))
 	}
+	if tunneled(bopts) {
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialTunnel(ctx, bopts, addr)
+		}))
+	}
+	if bopts.WaitForReady {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	}
+	if ka := bopts.GetKeepalive(); ka != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(ka.GetTime()) * time.Second,
+			Timeout:             time.Duration(ka.GetTimeout()) * time.Second,
+			PermitWithoutStream: ka.GetPermitWithoutStream(),
+		}))
+	}
+	retryOpts, err := retryCallOpts(bopts.GetRetry())
+	if err != nil {
+		return nil, err
+	}
+	var timeoutOpts []grpc.DialOption
 	if bopts.Timeout != 0 {
 		timeout := time.Duration(bopts.Timeout) * time.Second
-		retryOpt := grpc_retry.WithPerRetryTimeout(timeout)
-		opts = append(opts,
-			grpc.WithChainUnaryInterceptor(grpc_retry.UnaryClientInterceptor(retryOpt)),
-			grpc.WithChainStreamInterceptor(grpc_retry.StreamClientInterceptor(retryOpt)),
+		retryOpts = append(retryOpts, grpc_retry.WithPerRetryTimeout(timeout))
+		timeoutOpts = []grpc.DialOption{
 			grpcutil.WithUnaryDefaultTimeout(timeout),
 			grpcutil.WithStreamDefaultTimeout(timeout),
+		}
+	}
+	if len(retryOpts) > 0 {
+		streamRetry, err := retryStreamInterceptor(bopts.GetRetry(), retryOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(grpc_retry.UnaryClientInterceptor(retryOpts...)),
+			grpc.WithChainStreamInterceptor(streamRetry),
 		)
 	}
+	opts = append(opts, timeoutOpts...)
 	return opts, nil
 }
 
+// retryCallOpts returns the options of the retry interceptors for a retry
+// policy, or none if the calls are not retried.
+func retryCallOpts(rp *bindpb.RetryPolicy) ([]grpc_retry.CallOption, error) {
+	if rp.GetMaxAttempts() <= 1 {
+		return nil, nil
+	}
+	opts := []grpc_retry.CallOption{
+		grpc_retry.WithMax(uint(rp.GetMaxAttempts())),
+		grpc_retry.WithBackoff(retryBackoff(rp)),
+	}
+	if len(rp.GetCodes()) > 0 {
+		codes, err := retryCodes(rp)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc_retry.WithCodes(codes...))
+	}
+	return opts, nil
+}
+
+// retryCodes parses the status codes of a retry policy by their names.
+func retryCodes(rp *bindpb.RetryPolicy) ([]codes.Code, error) {
+	var cs []codes.Code
+	for _, name := range rp.GetCodes() {
+		var c codes.Code
+		if err := c.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+			return nil, fmt.Errorf("invalid retry code %q", name)
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// retryBackoff returns the exponential backoff of a retry policy.  The
+// interceptors call it with the number of the attempt, starting at 1 for
+// the first retry.
+func retryBackoff(rp *bindpb.RetryPolicy) grpc_retry.BackoffFunc {
+	initial := 50 * time.Millisecond
+	if rp.GetInitialBackoffMs() > 0 {
+		initial = time.Duration(rp.GetInitialBackoffMs()) * time.Millisecond
+	}
+	multiplier := 1.0
+	if rp.GetBackoffMultiplier() > 0 {
+		multiplier = float64(rp.GetBackoffMultiplier())
+	}
+	maxBackoff := time.Duration(rp.GetMaxBackoffMs()) * time.Millisecond
+	return func(attempt uint) time.Duration {
+		backoff := time.Duration(float64(initial) * math.Pow(multiplier, float64(attempt-1)))
+		if maxBackoff > 0 && backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		return backoff
+	}
+}
+
+// retryStreamInterceptor retries the streaming calls of the retry policy.
+// The grpc_retry interceptor fails the client streaming calls instead, so
+// they are re-established by a replayStream.  The per-retry timeout of the
+// options is not applied to the client streaming calls, since it would end
+// long-lived streams such as gNMI Subscribe.
+func retryStreamInterceptor(rp *bindpb.RetryPolicy, opts ...grpc_retry.CallOption) (grpc.StreamClientInterceptor, error) {
+	retry := grpc_retry.StreamClientInterceptor(opts...)
+	retryable, err := retryCodes(rp)
+	if err != nil {
+		return nil, err
+	}
+	if len(retryable) == 0 {
+		retryable = grpc_retry.DefaultRetriableCodes
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !desc.ClientStreams {
+			return retry(ctx, desc, cc, method, streamer, callOpts...)
+		}
+		s := &replayStream{
+			ctx:       ctx,
+			codes:     retryable,
+			attempts:  uint(rp.GetMaxAttempts()),
+			backoff:   retryBackoff(rp),
+			newStream: func() (grpc.ClientStream, error) { return streamer(ctx, desc, cc, method, callOpts...) },
+		}
+		if err := s.reopen(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}, nil
+}
+
+// replayStream is a client streaming call that is re-established when it
+// fails with a retryable code before its first response, replaying the
+// messages sent so far.  The call is not retried after the first response,
+// since the server may have acted on the messages by then.
+type replayStream struct {
+	ctx       context.Context
+	codes     []codes.Code
+	attempts  uint
+	backoff   grpc_retry.BackoffFunc
+	newStream func() (grpc.ClientStream, error)
+
+	mu       sync.Mutex
+	cs       grpc.ClientStream
+	attempt  uint
+	sent     []any
+	closed   bool
+	received bool
+}
+
+// reopen establishes the call, retrying while the attempts last, and replays
+// the messages sent so far.  A replayed message that fails to send with
+// io.EOF means the new attempt has already ended, and its status is left to
+// RecvMsg, which may retry it.  It must be called with s.mu held or before
+// the stream is returned.
+func (s *replayStream) reopen() error {
+	for {
+		s.attempt++
+		if s.attempt > 1 {
+			select {
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			case <-time.After(s.backoff(s.attempt - 1)):
+			}
+		}
+		cs, err := s.newStream()
+		if err != nil {
+			if s.retryable(err) {
+				continue
+			}
+			return err
+		}
+		s.cs = cs
+		for i, m := range s.sent {
+			if err := cs.SendMsg(m); err != nil {
+				return fmt.Errorf("replaying message %d of %d: %w", i+1, len(s.sent), err)
+			}
+		}
+		if s.closed {
+			cs.CloseSend()
+		}
+		return nil
+	}
+}
+
+// retryable returns whether the error of an attempt should be retried.
+func (s *replayStream) retryable(err error) bool {
+	return !s.received && s.attempt < s.attempts && slices.Contains(s.codes, status.Code(err))
+}
+
+func (s *replayStream) stream() grpc.ClientStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cs
+}
+
+func (s *replayStream) Header() (metadata.MD, error) { return s.stream().Header() }
+
+func (s *replayStream) Trailer() metadata.MD { return s.stream().Trailer() }
+
+func (s *replayStream) Context() context.Context { return s.stream().Context() }
+
+func (s *replayStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.cs.CloseSend()
+}
+
+func (s *replayStream) SendMsg(m any) error {
+	s.mu.Lock()
+	if !s.received {
+		s.sent = append(s.sent, m)
+	}
+	cs := s.cs
+	s.mu.Unlock()
+	err := cs.SendMsg(m)
+	if errors.Is(err, io.EOF) {
+		// The status of the call is returned by RecvMsg, which may retry it.
+		return nil
+	}
+	return err
+}
+
+func (s *replayStream) RecvMsg(m any) error {
+	for {
+		cs := s.stream()
+		err := cs.RecvMsg(m)
+		s.mu.Lock()
+		if err == nil {
+			s.received = true
+			s.sent = nil
+		}
+		if err == nil || !s.retryable(err) || cs != s.cs {
+			s.mu.Unlock()
+			return err
+		}
+		err = s.reopen()
+		s.mu.Unlock()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+}
+
 func makeDialer(params *svcParams, bopts *bindpb.Options) (*introspect.Dialer, error) {
 	opts, err := dialOpts(bopts)
 	if err != nil {
@@ -628,6 +1015,11 @@ func makeDialer(params *svcParams, bopts *bindpb.Options) (*introspect.Dialer, e
 				_, cancelFunc = context.WithTimeout(ctx, time.Duration(bopts.Timeout)*time.Second)
 				defer cancelFunc()
 			}
+			if tunneled(bopts) || len(bopts.GetFailoverTargets()) > 0 {
+				// Resolve the target at the end of the tunnel, or when one of the
+				// failover targets is dialed, not locally.
+				target = "passthrough:///" + target
+			}
 			return grpcDialContextFn(target, opts...)
 		},
 		DialTarget: bopts.Target,
@@ -656,9 +1048,12 @@ func loadCertificates(bopts *bindpb.Options) (*x509.CertPool, tls.Certificate, e
 }
 
 // creds implements the grpc.PerRPCCredentials interface, to be used
-// as a grpc.DialOption in dialGRPC.
+// as a grpc.DialOption in dialGRPC.  The credentials, if any, are resolved
+// on every call, so that rotated credentials are picked up once the cached
+// ones expire.
 type creds struct {
 	username, password string
+	credentials        *bindpb.Credentials
 	secure             bool
 }
 
@@ -673,6 +1068,18 @@ func (c *creds) GetRequestMetadata(ctx context.Context, _ ...string) (map[string
 			password = md.Get("password")[0]
 		}
 	}
+	if (username == "" || password == "") && c.credentials != nil {
+		s, err := resolveCredentials(ctx, c.credentials)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve credentials: %w", err)
+		}
+		if username == "" {
+			username = s.Username
+		}
+		if password == "" {
+			password = s.Password
+		}
+	}
 	if username == "" {
 		username = c.username
 	}
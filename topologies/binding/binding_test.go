@@ -16,7 +16,10 @@ package binding
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -24,11 +27,18 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/ondatra/binding"
 	"github.com/openconfig/ondatra/binding/introspect"
 	opb "github.com/openconfig/ondatra/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 func TestReserveRelease(t *testing.T) {
@@ -53,6 +63,46 @@ func TestReserveRelease(t *testing.T) {
 	}
 }
 
+// closeTracker records whether it is closed.
+type closeTracker struct {
+	io.Writer
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestReleaseAfterRestoreFailure(t *testing.T) {
+	origDialFn := grpcDialContextFn
+	defer func() { grpcDialContextFn = origDialFn }()
+	grpcDialContextFn = func(string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return nil, errors.New("unreachable")
+	}
+	w := &closeTracker{Writer: io.Discard}
+	recordersMu.Lock()
+	recorders["grpc.dut.gnmi.binlog"] = &recorder{w: w}
+	recordersMu.Unlock()
+
+	dut := &staticDUT{
+		AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: "dut"}},
+		r:           resolver{&bindpb.Binding{}},
+		dev:         &bindpb.Device{Name: "dut"},
+		checkpoint:  &gpb.SetRequest{},
+	}
+	b := &staticBind{resv: &binding.Reservation{DUTs: map[string]binding.DUT{"dut": dut}}}
+	if err := b.Release(context.Background()); err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Errorf("Release() got error %v, want the restore error", err)
+	}
+	if !w.closed {
+		t.Error("Release() did not close the recorders after the restore error")
+	}
+	if b.resv != nil {
+		t.Error("Release() did not clear the reservation after the restore error")
+	}
+}
+
 func TestStaticReservation(t *testing.T) {
 	tb := &opb.Testbed{
 		Duts: []*opb.Device{{
@@ -302,3 +352,278 @@ func TestDialer(t *testing.T) {
 		t.Errorf("Dialer() got Target %v, want %v", dialer.DialTarget, wantTarget)
 	}
 }
+
+func TestDialGNMIProfile(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotUsername string
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("username")) > 0 {
+			gotUsername = md.Get("username")[0]
+		}
+		return handler(ctx, req)
+	}))
+	gpb.RegisterGNMIServer(srv, &flakyGNMI{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+	origDialFn := grpcDialContextFn
+	defer func() { grpcDialContextFn = origDialFn }()
+	grpcDialContextFn = grpc.NewClient
+
+	d := &staticDUT{
+		AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: "dut"}},
+		r: resolver{&bindpb.Binding{Options: &bindpb.Options{
+			Target:   lis.Addr().String(),
+			Insecure: true,
+			Username: "primary",
+			Password: "primary.password",
+			Profiles: map[string]*bindpb.Options{
+				"backup": {Username: "backup", Password: "backup.password"},
+			},
+		}}},
+		dev: &bindpb.Device{Name: "dut"},
+	}
+
+	tests := []struct {
+		profile      string
+		wantUsername string
+		wantErr      string
+	}{{
+		profile:      "",
+		wantUsername: "primary",
+	}, {
+		profile:      "backup",
+		wantUsername: "backup",
+	}, {
+		profile: "unknown",
+		wantErr: `gNMI of DUT dut: no dial profile "unknown"`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.profile, func(t *testing.T) {
+			ctx := context.Background()
+			gotUsername = ""
+			c, err := d.DialGNMIProfile(ctx, test.profile)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("DialGNMIProfile() got unexpected error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if _, err := c.Capabilities(ctx, &gpb.CapabilityRequest{}); err != nil {
+				t.Fatalf("Capabilities() got unexpected error: %v", err)
+			}
+			if gotUsername != test.wantUsername {
+				t.Errorf("Capabilities() sent username %q, want %q", gotUsername, test.wantUsername)
+			}
+		})
+	}
+}
+
+// flakyGNMI fails the first Capabilities and Subscribe calls with
+// UNAVAILABLE.
+type flakyGNMI struct {
+	gpb.UnimplementedGNMIServer
+	failures, calls, subscribes int
+}
+
+func (s *flakyGNMI) Capabilities(context.Context, *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, status.Error(codes.Unavailable, "flaky")
+	}
+	return &gpb.CapabilityResponse{}, nil
+}
+
+func (s *flakyGNMI) Subscribe(stream gpb.GNMI_SubscribeServer) error {
+	s.subscribes++
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if s.subscribes <= s.failures {
+		return status.Error(codes.Unavailable, "flaky")
+	}
+	return stream.Send(&gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true}})
+}
+
+func TestDialOptsRetry(t *testing.T) {
+	tests := []struct {
+		desc      string
+		retry     *bindpb.RetryPolicy
+		wantCode  codes.Code
+		wantCalls int
+	}{{
+		desc:      "no retry",
+		wantCode:  codes.Unavailable,
+		wantCalls: 1,
+	}, {
+		desc:      "retry",
+		retry:     &bindpb.RetryPolicy{MaxAttempts: 3, InitialBackoffMs: 1},
+		wantCode:  codes.OK,
+		wantCalls: 3,
+	}, {
+		desc:      "too few attempts",
+		retry:     &bindpb.RetryPolicy{MaxAttempts: 2, InitialBackoffMs: 1},
+		wantCode:  codes.Unavailable,
+		wantCalls: 2,
+	}, {
+		desc:      "other codes",
+		retry:     &bindpb.RetryPolicy{MaxAttempts: 3, Codes: []string{"INTERNAL"}},
+		wantCode:  codes.Unavailable,
+		wantCalls: 1,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &flakyGNMI{failures: 2}
+			lis := bufconn.Listen(1 << 20)
+			srv := grpc.NewServer()
+			gpb.RegisterGNMIServer(srv, fake)
+			go srv.Serve(lis)
+			defer srv.Stop()
+
+			opts, err := dialOpts(&bindpb.Options{Insecure: true, WaitForReady: true, Retry: test.retry})
+			if err != nil {
+				t.Fatalf("dialOpts() got unexpected error: %v", err)
+			}
+			opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}))
+			conn, err := grpc.NewClient("passthrough:///flaky", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			c := gpb.NewGNMIClient(conn)
+
+			_, err = c.Capabilities(ctx, &gpb.CapabilityRequest{})
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("Capabilities() got code %v, want %v", got, test.wantCode)
+			}
+			if fake.calls != test.wantCalls {
+				t.Errorf("Capabilities() got %d calls, want %d", fake.calls, test.wantCalls)
+			}
+			// A bidirectional stream is re-established and the request
+			// replayed while no response was received.
+			stream, err := c.Subscribe(ctx)
+			if err != nil {
+				t.Fatalf("Subscribe() got unexpected error: %v", err)
+			}
+			if err := stream.Send(&gpb.SubscribeRequest{}); err != nil {
+				t.Fatalf("Send() got unexpected error: %v", err)
+			}
+			_, err = stream.Recv()
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("Recv() got code %v, want %v", got, test.wantCode)
+			}
+			if fake.subscribes != test.wantCalls {
+				t.Errorf("Subscribe() got %d calls, want %d", fake.subscribes, test.wantCalls)
+			}
+			if test.wantCode == codes.OK {
+				if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+					t.Errorf("Recv() after the response got error %v, want EOF", err)
+				}
+			}
+		})
+	}
+}
+
+// failingAttempt is an attempt of a client streaming call, which fails to
+// send with sendErr and to receive with recvErr.
+type failingAttempt struct {
+	grpc.ClientStream
+	sendErr, recvErr error
+}
+
+func (s *failingAttempt) SendMsg(any) error { return s.sendErr }
+
+func (s *failingAttempt) RecvMsg(any) error { return s.recvErr }
+
+func TestReplayStreamSendError(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "flaky")
+	tests := []struct {
+		desc     string
+		attempts []*failingAttempt
+		wantErr  string
+	}{{
+		desc: "replay fails",
+		attempts: []*failingAttempt{
+			{recvErr: unavailable},
+			{sendErr: errors.New("cannot encode")},
+		},
+		wantErr: "replaying message 1 of 1: cannot encode",
+	}, {
+		desc: "replayed attempt ended",
+		attempts: []*failingAttempt{
+			{recvErr: unavailable},
+			{sendErr: io.EOF, recvErr: unavailable},
+			{},
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var opened int
+			s := &replayStream{
+				ctx:      context.Background(),
+				codes:    []codes.Code{codes.Unavailable},
+				attempts: uint(len(test.attempts)),
+				backoff:  func(uint) time.Duration { return 0 },
+				newStream: func() (grpc.ClientStream, error) {
+					opened++
+					return test.attempts[opened-1], nil
+				},
+			}
+			if err := s.reopen(); err != nil {
+				t.Fatalf("reopen() got unexpected error: %v", err)
+			}
+			if err := s.SendMsg(&gpb.SubscribeRequest{}); err != nil {
+				t.Fatalf("SendMsg() got unexpected error: %v", err)
+			}
+			err := s.RecvMsg(&gpb.SubscribeResponse{})
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("RecvMsg() got unexpected error: %s", diff)
+			}
+			if opened != len(test.attempts) {
+				t.Errorf("RecvMsg() opened %d attempts, want %d", opened, len(test.attempts))
+			}
+		})
+	}
+}
+
+func TestDialOptsInvalidRetryCode(t *testing.T) {
+	_, err := dialOpts(&bindpb.Options{Retry: &bindpb.RetryPolicy{MaxAttempts: 2, Codes: []string{"FLAKY"}}})
+	if err == nil || !strings.Contains(err.Error(), `"FLAKY"`) {
+		t.Errorf("dialOpts() got error %v, want invalid retry code", err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		desc  string
+		retry *bindpb.RetryPolicy
+		want  []time.Duration
+	}{{
+		desc:  "default",
+		retry: &bindpb.RetryPolicy{},
+		want:  []time.Duration{50 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond},
+	}, {
+		desc:  "exponential",
+		retry: &bindpb.RetryPolicy{InitialBackoffMs: 100, BackoffMultiplier: 2, MaxBackoffMs: 300},
+		want:  []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			backoff := retryBackoff(test.retry)
+			var got []time.Duration
+			for attempt := uint(1); attempt <= uint(len(test.want)); attempt++ {
+				got = append(got, backoff(attempt))
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("retryBackoff() -want,+got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/openconfig/featureprofiles/internal/devconfig"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/ocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// maxDrift is the maximum number of drifted paths reported.
+const maxDrift = 20
+
+// ocRootPath is the root of the OpenConfig config.
+var ocRootPath = &gpb.Path{Origin: "openconfig"}
+
+// takeCheckpoint takes the checkpoint of the DUT, or restores it from the
+// checkpoint file if there is one.
+func (d *staticDUT) takeCheckpoint(ctx context.Context) error {
+	cp := d.dev.GetConfig().GetCheckpoint()
+	if cp == nil {
+		return nil
+	}
+	c, err := d.DialGNMI(ctx)
+	if err != nil {
+		return err
+	}
+	if file := cp.GetFile(); file != "" {
+		req, err := readGNMI(file)
+		switch {
+		case err == nil:
+			d.checkpoint = req
+			return restoreCheckpoint(ctx, c, req)
+		case !errors.Is(err, fs.ErrNotExist):
+			return fmt.Errorf("cannot read checkpoint file: %w", err)
+		}
+	}
+	req, err := getCheckpoint(ctx, c, cp.GetCli())
+	if err != nil {
+		return fmt.Errorf("cannot take checkpoint: %w", err)
+	}
+	d.checkpoint = req
+	if file := cp.GetFile(); file != "" {
+		// The config may hold secrets, so only the user may read the file.
+		if err := os.WriteFile(file, []byte(prototext.Format(req)), 0600); err != nil {
+			return fmt.Errorf("cannot write checkpoint file: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreCheckpoint restores the checkpoint of the DUT, if it took one.
+func (d *staticDUT) restoreCheckpoint(ctx context.Context) error {
+	if d.checkpoint == nil {
+		return nil
+	}
+	c, err := d.DialGNMI(ctx)
+	if err != nil {
+		return err
+	}
+	return restoreCheckpoint(ctx, c, d.checkpoint)
+}
+
+// getCheckpoint returns a SetRequest that replaces the config of the device
+// with its running config.
+func getCheckpoint(ctx context.Context, c gpb.GNMIClient, cli bool) (*gpb.SetRequest, error) {
+	root, err := getOCConfig(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ygot.Marshal7951(root, &ygot.RFC7951JSONConfig{AppendModuleName: true, PreferShadowPath: true})
+	if err != nil {
+		return nil, err
+	}
+	req := &gpb.SetRequest{
+		Replace: []*gpb.Update{{
+			Path: ocRootPath,
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: b}},
+		}},
+	}
+	if cli {
+		config, err := devconfig.GetCLI(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get CLI config: %w", err)
+		}
+		req.Replace = append(req.Replace, devconfig.CLIUpdate(config))
+	}
+	return req, nil
+}
+
+// restoreCheckpoint replaces the config of the device with the checkpoint,
+// and returns an error if the OpenConfig config then lacks or changes any of
+// its values.
+func restoreCheckpoint(ctx context.Context, c gpb.GNMIClient, req *gpb.SetRequest) error {
+	if _, err := c.Set(ctx, req); err != nil {
+		return fmt.Errorf("cannot restore checkpoint: %w", err)
+	}
+	want, err := checkpointConfig(req)
+	if err != nil {
+		return err
+	}
+	got, err := getOCConfig(ctx, c)
+	if err != nil {
+		return fmt.Errorf("cannot verify restored checkpoint: %w", err)
+	}
+	drift, err := configDrift(want, got)
+	if err != nil {
+		return fmt.Errorf("cannot verify restored checkpoint: %w", err)
+	}
+	if len(drift) > 0 {
+		return fmt.Errorf("config drifted from the checkpoint after restoring it:\n%s", strings.Join(drift, "\n"))
+	}
+	return nil
+}
+
+// checkpointConfig returns the OpenConfig config of a checkpoint.
+func checkpointConfig(req *gpb.SetRequest) (*oc.Root, error) {
+	for _, u := range req.GetReplace() {
+		if origin := u.GetPath().GetOrigin(); (origin != "" && origin != "openconfig") || len(u.GetPath().GetElem()) > 0 {
+			continue
+		}
+		root := &oc.Root{}
+		if err := oc.Unmarshal(u.GetVal().GetJsonIetfVal(), root, &ytypes.PreferShadowPath{}, &ytypes.IgnoreExtraFields{}); err != nil {
+			return nil, fmt.Errorf("cannot parse checkpoint: %w", err)
+		}
+		return root, nil
+	}
+	return nil, errors.New("checkpoint has no OpenConfig config")
+}
+
+func getOCConfig(ctx context.Context, c gpb.GNMIClient) (*oc.Root, error) {
+	yc, err := ygnmi.NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+	return ygnmi.Get(ctx, yc, ocpath.Root().Config())
+}
+
+// configDrift returns the changes of the config from the checkpoint, up to
+// maxDrift of them.
+func configDrift(want, got *oc.Root) ([]string, error) {
+	changes, err := devconfig.Changes(want, got)
+	if err != nil {
+		return nil, err
+	}
+	var drift []string
+	for _, c := range changes {
+		drift = append(drift, devconfig.FormatChange(c))
+	}
+	if n := len(drift); n > maxDrift {
+		drift = append(drift[:maxDrift], fmt.Sprintf("... and %d more", n-maxDrift))
+	}
+	return drift, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/fakedevice"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newCheckpointDUT returns a static DUT of a fake device, which keeps its
+// checkpoint in the file.
+func newCheckpointDUT(t *testing.T, dev *fakedevice.Device, file string) *staticDUT {
+	t.Helper()
+	origDialFn := grpcDialContextFn
+	t.Cleanup(func() { grpcDialContextFn = origDialFn })
+	grpcDialContextFn = func(_ string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return dev.Dial(context.Background(), opts...)
+	}
+	return &staticDUT{
+		AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: dev.Name()}},
+		r:           resolver{&bindpb.Binding{Options: &bindpb.Options{Insecure: true}}},
+		dev: &bindpb.Device{
+			Name:   dev.Name(),
+			Config: &bindpb.Configs{Checkpoint: &bindpb.Checkpoint{File: file}},
+		},
+	}
+}
+
+func setHostname(t *testing.T, dev *fakedevice.Device, hostname string) {
+	t.Helper()
+	if err := dev.UpdateDatastore(func(root *oc.Root) error {
+		root.GetOrCreateSystem().Hostname = ygot.String(hostname)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func hostname(t *testing.T, dev *fakedevice.Device) string {
+	t.Helper()
+	root, err := dev.Datastore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root.GetSystem().GetHostname()
+}
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	dev, err := fakedevice.NewDUT("dut", []string{"port1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+	file := filepath.Join(t.TempDir(), "dut.checkpoint")
+
+	d := newCheckpointDUT(t, dev, file)
+	if err := d.takeCheckpoint(ctx); err != nil {
+		t.Fatalf("takeCheckpoint() got unexpected error: %v", err)
+	}
+	if _, err := readGNMI(file); err != nil {
+		t.Fatalf("takeCheckpoint() did not write the checkpoint file: %v", err)
+	}
+
+	setHostname(t, dev, "changed")
+	if err := d.restoreCheckpoint(ctx); err != nil {
+		t.Fatalf("restoreCheckpoint() got unexpected error: %v", err)
+	}
+	if got, want := hostname(t, dev), "dut"; got != want {
+		t.Errorf("restoreCheckpoint() got hostname %q, want %q", got, want)
+	}
+
+	// The next reservation restores the checkpoint from the file.
+	setHostname(t, dev, "changed")
+	d = newCheckpointDUT(t, dev, file)
+	if err := d.takeCheckpoint(ctx); err != nil {
+		t.Fatalf("takeCheckpoint() from the file got unexpected error: %v", err)
+	}
+	if got, want := hostname(t, dev), "dut"; got != want {
+		t.Errorf("takeCheckpoint() from the file got hostname %q, want %q", got, want)
+	}
+}
+
+// driftingGNMI changes the hostname of the device after every Set.
+type driftingGNMI struct {
+	gpb.GNMIClient
+	t   *testing.T
+	dev *fakedevice.Device
+}
+
+func (c *driftingGNMI) Set(ctx context.Context, req *gpb.SetRequest, opts ...grpc.CallOption) (*gpb.SetResponse, error) {
+	resp, err := c.GNMIClient.Set(ctx, req, opts...)
+	setHostname(c.t, c.dev, "drifted")
+	return resp, err
+}
+
+func TestRestoreCheckpointDrift(t *testing.T) {
+	ctx := context.Background()
+	dev, err := fakedevice.NewDUT("dut", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+	conn, err := dev.Dial(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := gpb.NewGNMIClient(conn)
+
+	req, err := getCheckpoint(ctx, c, false)
+	if err != nil {
+		t.Fatalf("getCheckpoint() got unexpected error: %v", err)
+	}
+	err = restoreCheckpoint(ctx, &driftingGNMI{GNMIClient: c, t: t, dev: dev}, req)
+	if err == nil || !strings.Contains(err.Error(), "/system/state/hostname: got") {
+		t.Errorf("restoreCheckpoint() got error %v, want drift of the hostname", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+)
+
+var (
+	// Stub out for unit tests.
+	execCommandFn = exec.CommandContext
+	timeNowFn     = time.Now
+)
+
+const defaultCredentialsCacheTime = 5 * time.Minute
+
+// secret is a username and password resolved from bindpb.Credentials.  It is
+// also the JSON format of a credentials file and of the credential helper
+// output.
+type secret struct {
+	Username string    `json:"username"`
+	Password string    `json:"password"`
+	Expiry   time.Time `json:"expiry"`
+}
+
+type cachedSecret struct {
+	secret *secret
+	expiry time.Time
+}
+
+// secrets caches the resolved credentials by their serialized proto.
+var (
+	secretsMu sync.Mutex
+	secrets   = make(map[string]*cachedSecret)
+)
+
+// userPass returns the username and password of the options, which are
+// resolved from the credentials if the options have them.
+func userPass(ctx context.Context, bopts *bindpb.Options) (string, string, error) {
+	if bopts.GetCredentials() == nil {
+		return bopts.GetUsername(), bopts.GetPassword(), nil
+	}
+	s, err := resolveCredentials(ctx, bopts.GetCredentials())
+	if err != nil {
+		return "", "", err
+	}
+	return s.Username, s.Password, nil
+}
+
+// resolveCredentials returns the username and password the credentials
+// refer to, from the cache unless the cached secret has expired.
+func resolveCredentials(ctx context.Context, c *bindpb.Credentials) (*secret, error) {
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	// The lock is held while fetching, so that concurrent dials run a
+	// credential helper only once.
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	now := timeNowFn()
+	if cached, ok := secrets[string(key)]; ok && now.Before(cached.expiry) {
+		return cached.secret, nil
+	}
+	s, err := fetchSecret(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	ttl := defaultCredentialsCacheTime
+	if c.GetCacheSeconds() > 0 {
+		ttl = time.Duration(c.GetCacheSeconds()) * time.Second
+	}
+	expiry := now.Add(ttl)
+	if !s.Expiry.IsZero() && s.Expiry.Before(expiry) {
+		expiry = s.Expiry
+	}
+	secrets[string(key)] = &cachedSecret{secret: s, expiry: expiry}
+	return s, nil
+}
+
+func fetchSecret(ctx context.Context, c *bindpb.Credentials) (*secret, error) {
+	switch src := c.GetSource().(type) {
+	case *bindpb.Credentials_Env:
+		s := &secret{}
+		for _, v := range []struct {
+			name string
+			val  *string
+		}{
+			{src.Env.GetUsername(), &s.Username},
+			{src.Env.GetPassword(), &s.Password},
+		} {
+			if v.name == "" {
+				continue
+			}
+			val, ok := os.LookupEnv(v.name)
+			if !ok {
+				return nil, fmt.Errorf("credentials environment variable %s is not set", v.name)
+			}
+			*v.val = val
+		}
+		return s, nil
+	case *bindpb.Credentials_File:
+		b, err := os.ReadFile(src.File)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read credentials file: %w", err)
+		}
+		return parseSecret(b, src.File)
+	case *bindpb.Credentials_Exec:
+		cmd := execCommandFn(ctx, src.Exec.GetCommand(), src.Exec.GetArgs()...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("credential helper %s failed: %w: %s", src.Exec.GetCommand(), err, strings.TrimSpace(stderr.String()))
+		}
+		return parseSecret(out, src.Exec.GetCommand())
+	default:
+		return nil, errors.New("credentials have no source")
+	}
+}
+
+// parseSecret parses the JSON credentials from the source.  The error does
+// not quote the JSON, which holds the password.
+func parseSecret(b []byte, source string) (*secret, error) {
+	s := &secret{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("cannot parse credentials from %s as JSON", source)
+	}
+	return s, nil
+}
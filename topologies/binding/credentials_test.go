@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/grpc/metadata"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+)
+
+func resetSecrets() {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets = make(map[string]*cachedSecret)
+}
+
+func TestUserPass(t *testing.T) {
+	t.Setenv("TEST_BINDING_USER", "alice")
+	t.Setenv("TEST_BINDING_PASS", "bob")
+	file := filepath.Join(t.TempDir(), "creds.json")
+	if err := os.WriteFile(file, []byte(`{"username": "carol", "password": "dave"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(badFile, []byte(`password=secret`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	execCommandFn = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "echo", `{"username": "`+name+`", "password": "`+strings.Join(args, ",")+`"}`)
+	}
+	defer func() { execCommandFn = exec.CommandContext }()
+
+	tests := []struct {
+		desc         string
+		opts         *bindpb.Options
+		wantUsername string
+		wantPassword string
+		wantErr      string
+	}{{
+		desc:         "plaintext",
+		opts:         &bindpb.Options{Username: "user", Password: "pass"},
+		wantUsername: "user",
+		wantPassword: "pass",
+	}, {
+		desc: "env",
+		opts: &bindpb.Options{
+			Username: "ignored",
+			Credentials: &bindpb.Credentials{Source: &bindpb.Credentials_Env{Env: &bindpb.EnvCredentials{
+				Username: "TEST_BINDING_USER",
+				Password: "TEST_BINDING_PASS",
+			}}},
+		},
+		wantUsername: "alice",
+		wantPassword: "bob",
+	}, {
+		desc: "env unset",
+		opts: &bindpb.Options{
+			Credentials: &bindpb.Credentials{Source: &bindpb.Credentials_Env{Env: &bindpb.EnvCredentials{
+				Password: "TEST_BINDING_UNSET",
+			}}},
+		},
+		wantErr: "TEST_BINDING_UNSET is not set",
+	}, {
+		desc: "file",
+		opts: &bindpb.Options{
+			Credentials: &bindpb.Credentials{Source: &bindpb.Credentials_File{File: file}},
+		},
+		wantUsername: "carol",
+		wantPassword: "dave",
+	}, {
+		desc: "file not JSON",
+		opts: &bindpb.Options{
+			Credentials: &bindpb.Credentials{Source: &bindpb.Credentials_File{File: badFile}},
+		},
+		wantErr: "cannot parse credentials",
+	}, {
+		desc: "exec",
+		opts: &bindpb.Options{
+			Credentials: &bindpb.Credentials{Source: &bindpb.Credentials_Exec{Exec: &bindpb.ExecCredentials{
+				Command: "helper",
+				Args:    []string{"get", "dut"},
+			}}},
+		},
+		wantUsername: "helper",
+		wantPassword: "get,dut",
+	}, {
+		desc:    "no source",
+		opts:    &bindpb.Options{Credentials: &bindpb.Credentials{}},
+		wantErr: "no source",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resetSecrets()
+			username, password, err := userPass(context.Background(), test.opts)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("userPass() got unexpected error: %s", diff)
+			}
+			if username != test.wantUsername || password != test.wantPassword {
+				t.Errorf("userPass() got %q, %q, want %q, %q", username, password, test.wantUsername, test.wantPassword)
+			}
+			if err != nil && strings.Contains(err.Error(), "secret") {
+				t.Errorf("userPass() error %q leaks the secret", err)
+			}
+		})
+	}
+}
+
+func TestResolveCredentialsCache(t *testing.T) {
+	resetSecrets()
+	defer resetSecrets()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNowFn = func() time.Time { return now }
+	defer func() { timeNowFn = time.Now }()
+	file := filepath.Join(t.TempDir(), "creds.json")
+	writeCreds := func(password string, expiry time.Time) {
+		t.Helper()
+		b := `{"username": "admin", "password": "` + password + `", "expiry": "` + expiry.Format(time.RFC3339) + `"}`
+		if err := os.WriteFile(file, []byte(b), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &bindpb.Credentials{Source: &bindpb.Credentials_File{File: file}, CacheSeconds: 60}
+	password := func() string {
+		t.Helper()
+		s, err := resolveCredentials(context.Background(), c)
+		if err != nil {
+			t.Fatalf("resolveCredentials() got unexpected error: %v", err)
+		}
+		return s.Password
+	}
+
+	writeCreds("first", now.Add(time.Hour))
+	if got, want := password(), "first"; got != want {
+		t.Errorf("resolveCredentials() got password %q, want %q", got, want)
+	}
+	// The rotated password is only picked up once the cache expires.
+	writeCreds("second", now.Add(90*time.Second))
+	now = now.Add(30 * time.Second)
+	if got, want := password(), "first"; got != want {
+		t.Errorf("resolveCredentials() before the cache expiry got password %q, want %q", got, want)
+	}
+	now = now.Add(time.Minute)
+	if got, want := password(), "second"; got != want {
+		t.Errorf("resolveCredentials() after the cache expiry got password %q, want %q", got, want)
+	}
+	// The credentials expire before the cache would.
+	writeCreds("third", now.Add(time.Hour))
+	now = now.Add(30 * time.Second)
+	if got, want := password(), "third"; got != want {
+		t.Errorf("resolveCredentials() after the credentials expiry got password %q, want %q", got, want)
+	}
+}
+
+func TestCredsGetRequestMetadata(t *testing.T) {
+	resetSecrets()
+	defer resetSecrets()
+	t.Setenv("TEST_BINDING_USER", "alice")
+	t.Setenv("TEST_BINDING_PASS", "bob")
+	c := &creds{
+		username: "plain",
+		credentials: &bindpb.Credentials{Source: &bindpb.Credentials_Env{Env: &bindpb.EnvCredentials{
+			Username: "TEST_BINDING_USER",
+			Password: "TEST_BINDING_PASS",
+		}}},
+	}
+
+	got, err := c.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"username": "alice", "password": "bob"}, got); diff != "" {
+		t.Errorf("GetRequestMetadata() -want,+got:\n%s", diff)
+	}
+
+	// Metadata of the call take precedence.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "username", "eve")
+	got, err = c.GetRequestMetadata(ctx)
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"username": "eve", "password": "bob"}, got); diff != "" {
+		t.Errorf("GetRequestMetadata() with metadata -want,+got:\n%s", diff)
+	}
+}
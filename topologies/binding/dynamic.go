@@ -18,7 +18,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 
+	"github.com/golang/glog"
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	"github.com/openconfig/ondatra/binding"
 	"github.com/openconfig/ondatra/binding/portgraph"
@@ -35,8 +40,15 @@ func dynamicReservation(ctx context.Context, tb *opb.Testbed, r resolver) (*bind
 	if err != nil {
 		return nil, fmt.Errorf("could not solve for specified testbed: %w", err)
 	}
-	assign, err := portgraph.Solve(ctx, abstractGraph, superGraph)
+	assign, err := solve(ctx, abstractGraph, superGraph)
 	if err != nil {
+		var solveErr *portgraph.SolveError
+		if errors.As(err, &solveErr) && solveErr.Unwrap() == nil {
+			glog.V(1).Infof("Solve report:\n%s", solveErr.Report())
+		}
+		if diag := diagnoseDynamic(tb, r.Binding); diag != "" {
+			err = fmt.Errorf("%w\n%s", err, diag)
+		}
 		return nil, fmt.Errorf("could not solve for specified testbed: %w", err)
 	}
 	res, err := assignmentToReservation(assign, r, tb, absNode2Dev, conNode2Dev, absPort2BindPort, conPort2BindPort)
@@ -78,6 +90,9 @@ func protoToConcreteGraph(bpb *bindpb.Binding) (*portgraph.ConcreteGraph, map[*p
 			Ports: ports,
 			Attrs: map[string]string{portgraph.RoleAttr: devRole},
 		}
+		if v := dev.GetVendor(); v != opb.Device_VENDOR_UNSPECIFIED {
+			node.Attrs[portgraph.VendorAttr] = v.String()
+		}
 		if name := dev.GetName(); name != "" {
 			node.Attrs[portgraph.NameAttr] = name
 		}
@@ -112,6 +127,73 @@ func protoToConcreteGraph(bpb *bindpb.Binding) (*portgraph.ConcreteGraph, map[*p
 	return cg, conNode2Dev, conPort2BindPort, nil
 }
 
+// solve solves for the testbed, preferring an assignment in which the ports
+// of every link have the same speed.  If there is none, the links between
+// ports of different speeds are pruned one at a time while the testbed can
+// still be solved, so that the assignment uses as few of them as it can.
+// The result is minimal in that no further link can be pruned, but another
+// assignment may use fewer links between ports of different speeds.
+func solve(ctx context.Context, abstractGraph *portgraph.AbstractGraph, superGraph *portgraph.ConcreteGraph) (*portgraph.Assignment, error) {
+	var sameSpeed, mismatched []*portgraph.ConcreteEdge
+	for _, e := range superGraph.Edges {
+		srcSpeed, srcOK := e.Src.Attrs[portgraph.SpeedAttr]
+		dstSpeed, dstOK := e.Dst.Attrs[portgraph.SpeedAttr]
+		if !srcOK || !dstOK || srcSpeed == dstSpeed {
+			sameSpeed = append(sameSpeed, e)
+		} else {
+			mismatched = append(mismatched, e)
+		}
+	}
+	if len(mismatched) == 0 {
+		return portgraph.Solve(ctx, abstractGraph, superGraph)
+	}
+	solveWith := func(mismatched []*portgraph.ConcreteEdge) (*portgraph.Assignment, error) {
+		return portgraph.Solve(ctx, abstractGraph, &portgraph.ConcreteGraph{
+			Desc:  superGraph.Desc,
+			Nodes: superGraph.Nodes,
+			Edges: append(slices.Clip(sameSpeed), mismatched...),
+		})
+	}
+	if assign, err := solveWith(nil); err == nil {
+		return assign, nil
+	}
+	assign, err := solveWith(mismatched)
+	if err != nil {
+		return nil, err
+	}
+	// Every assignment found below only uses the mismatched links that the
+	// previous one used, less one, so the number of them decreases.
+	used := usedEdges(assign, mismatched)
+	for i := 0; i < len(used); {
+		pruned := slices.Delete(slices.Clone(used), i, i+1)
+		if a, err := solveWith(pruned); err == nil {
+			assign, used = a, usedEdges(a, pruned)
+			i = 0
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		i++
+	}
+	return assign, nil
+}
+
+// usedEdges returns the edges whose ports are both assigned.
+func usedEdges(assign *portgraph.Assignment, edges []*portgraph.ConcreteEdge) []*portgraph.ConcreteEdge {
+	assigned := make(map[*portgraph.ConcretePort]bool)
+	for _, cp := range assign.Port2Port {
+		assigned[cp] = true
+	}
+	var used []*portgraph.ConcreteEdge
+	for _, e := range edges {
+		if assigned[e.Src] && assigned[e.Dst] {
+			used = append(used, e)
+		}
+	}
+	return used
+}
+
 func assignmentToReservation(
 	assign *portgraph.Assignment,
 	r resolver,
@@ -177,3 +259,212 @@ func dynDims(td *opb.Device, bd *bindpb.Device, tbPort2BindPort map[*opb.Port]*b
 	}
 	return dims
 }
+
+// diagnoseDynamic explains why the binding cannot satisfy the testbed: which
+// testbed devices no binding device matches and why, and which testbed links
+// no binding link between matching devices can satisfy.
+func diagnoseDynamic(tb *opb.Testbed, b *bindpb.Binding) string {
+	var lines []string
+	candidates := make(map[*opb.Device][]*bindpb.Device)
+	diagnose := func(tds []*opb.Device, bds []*bindpb.Device, role string) {
+		if len(tds) > len(bds) {
+			lines = append(lines, fmt.Sprintf("testbed has %d %ss, but binding has %d", len(tds), role, len(bds)))
+		}
+		for _, td := range tds {
+			var reasons []string
+			for _, bd := range bds {
+				if mismatches := deviceMismatches(td, bd); len(mismatches) > 0 {
+					reasons = append(reasons, fmt.Sprintf("  %s: %s", bd.GetName(), strings.Join(mismatches, "; ")))
+					continue
+				}
+				candidates[td] = append(candidates[td], bd)
+			}
+			if len(candidates[td]) == 0 && len(bds) > 0 {
+				lines = append(lines, fmt.Sprintf("no binding %s matches testbed %s %q:", role, role, td.GetId()))
+				lines = append(lines, reasons...)
+			}
+		}
+	}
+	diagnose(tb.GetDuts(), b.GetDuts(), "DUT")
+	diagnose(tb.GetAtes(), b.GetAtes(), "ATE")
+	return strings.Join(append(lines, diagnoseLinks(tb, b, candidates)...), "\n")
+}
+
+// deviceMismatches returns why the binding device cannot be assigned to the
+// testbed device.
+func deviceMismatches(td *opb.Device, bd *bindpb.Device) []string {
+	var mismatches []string
+	if v := td.GetVendor(); v != opb.Device_VENDOR_UNSPECIFIED && v != bd.GetVendor() {
+		mismatches = append(mismatches, fmt.Sprintf("vendor is %s, want %s", bd.GetVendor(), v))
+	}
+	switch v := td.GetHardwareModelValue().(type) {
+	case *opb.Device_HardwareModel:
+		if !matchValue(bd.GetHardwareModel(), v.HardwareModel, "") {
+			mismatches = append(mismatches, fmt.Sprintf("hardware model is %q, want %q", bd.GetHardwareModel(), v.HardwareModel))
+		}
+	case *opb.Device_HardwareModelRegex:
+		if !matchValue(bd.GetHardwareModel(), "", v.HardwareModelRegex) {
+			mismatches = append(mismatches, fmt.Sprintf("hardware model is %q, want match of %q", bd.GetHardwareModel(), v.HardwareModelRegex))
+		}
+	}
+	switch v := td.GetSoftwareVersionValue().(type) {
+	case *opb.Device_SoftwareVersion:
+		if !matchValue(bd.GetSoftwareVersion(), v.SoftwareVersion, "") {
+			mismatches = append(mismatches, fmt.Sprintf("software version is %q, want %q", bd.GetSoftwareVersion(), v.SoftwareVersion))
+		}
+	case *opb.Device_SoftwareVersionRegex:
+		if !matchValue(bd.GetSoftwareVersion(), "", v.SoftwareVersionRegex) {
+			mismatches = append(mismatches, fmt.Sprintf("software version is %q, want match of %q", bd.GetSoftwareVersion(), v.SoftwareVersionRegex))
+		}
+	}
+	var dims []string
+	for k := range td.GetExtraDimensions() {
+		dims = append(dims, k)
+	}
+	sort.Strings(dims)
+	for _, k := range dims {
+		mismatches = append(mismatches, fmt.Sprintf("binding has no dimension %q", k))
+	}
+
+	if n, want := len(bd.GetPorts()), len(td.GetPorts()); n < want {
+		mismatches = append(mismatches, fmt.Sprintf("has %d ports, want %d", n, want))
+	}
+	for _, tp := range td.GetPorts() {
+		var reasons []string
+		for _, bp := range bd.GetPorts() {
+			r := portMismatches(tp, bp)
+			if len(r) == 0 {
+				reasons = nil
+				break
+			}
+			reasons = append(reasons, r...)
+		}
+		if len(reasons) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("no port matches %s (%s)", tp.GetId(), strings.Join(dedup(reasons), ", ")))
+		}
+	}
+	return mismatches
+}
+
+// portMismatches returns why the binding port cannot be assigned to the
+// testbed port.
+func portMismatches(tp *opb.Port, bp *bindpb.Port) []string {
+	var mismatches []string
+	if s := tp.GetSpeed(); s != opb.Port_SPEED_UNSPECIFIED && s != bp.GetSpeed() {
+		mismatches = append(mismatches, "want speed "+s.String())
+	}
+	var pmd string
+	if p := bp.GetPmd(); p != opb.Port_PMD_UNSPECIFIED {
+		pmd = p.String()
+	}
+	switch v := tp.GetPmdValue().(type) {
+	case *opb.Port_Pmd_:
+		if v.Pmd != opb.Port_PMD_UNSPECIFIED && !matchValue(pmd, v.Pmd.String(), "") {
+			mismatches = append(mismatches, "want PMD "+v.Pmd.String())
+		}
+	case *opb.Port_PmdRegex:
+		if v.PmdRegex != "" && !matchValue(pmd, "", v.PmdRegex) {
+			mismatches = append(mismatches, fmt.Sprintf("want PMD matching %q", v.PmdRegex))
+		}
+	}
+	if tp.GetCardModel() != "" || tp.GetCardModelRegex() != "" {
+		mismatches = append(mismatches, "binding has no card models")
+	}
+	return mismatches
+}
+
+// matchValue returns whether an attribute is set and is equal to want, or
+// matches the wantRegex if want is empty.  Unset attributes never match, as
+// in the solver.
+func matchValue(got, want, wantRegex string) bool {
+	if got == "" {
+		return want == "" && wantRegex == ""
+	}
+	if want != "" {
+		return got == want
+	}
+	if wantRegex == "" {
+		return true
+	}
+	ok, err := regexp.MatchString(wantRegex, got)
+	return err == nil && ok
+}
+
+// diagnoseLinks returns the testbed links that no binding link between
+// devices matching their ends can satisfy.
+func diagnoseLinks(tb *opb.Testbed, b *bindpb.Binding, candidates map[*opb.Device][]*bindpb.Device) []string {
+	type tbEnd struct {
+		dev  *opb.Device
+		port *opb.Port
+	}
+	tbPorts := make(map[string]tbEnd)
+	for _, td := range slices.Concat(tb.GetDuts(), tb.GetAtes()) {
+		for _, tp := range td.GetPorts() {
+			tbPorts[td.GetId()+":"+tp.GetId()] = tbEnd{td, tp}
+		}
+	}
+	type bindEnd struct {
+		dev  *bindpb.Device
+		port *bindpb.Port
+	}
+	bindPorts := make(map[string]bindEnd)
+	for _, bd := range slices.Concat(b.GetDuts(), b.GetAtes()) {
+		for _, bp := range bd.GetPorts() {
+			bindPorts[bd.GetName()+":"+bp.GetName()] = bindEnd{bd, bp}
+		}
+	}
+	type devPair struct{ a, b *bindpb.Device }
+	bindLinks := make(map[devPair][][2]*bindpb.Port)
+	for _, l := range b.GetLinks() {
+		ea, okA := bindPorts[l.GetA()]
+		eb, okB := bindPorts[l.GetB()]
+		if !okA || !okB {
+			continue
+		}
+		bindLinks[devPair{ea.dev, eb.dev}] = append(bindLinks[devPair{ea.dev, eb.dev}], [2]*bindpb.Port{ea.port, eb.port})
+		bindLinks[devPair{eb.dev, ea.dev}] = append(bindLinks[devPair{eb.dev, ea.dev}], [2]*bindpb.Port{eb.port, ea.port})
+	}
+
+	var lines []string
+	for _, l := range tb.GetLinks() {
+		ea, okA := tbPorts[l.GetA()]
+		eb, okB := tbPorts[l.GetB()]
+		if !okA || !okB || len(candidates[ea.dev]) == 0 || len(candidates[eb.dev]) == 0 {
+			continue
+		}
+		var linked, fits bool
+		for _, ca := range candidates[ea.dev] {
+			for _, cb := range candidates[eb.dev] {
+				if ca == cb {
+					continue
+				}
+				for _, bl := range bindLinks[devPair{ca, cb}] {
+					linked = true
+					if len(portMismatches(ea.port, bl[0])) == 0 && len(portMismatches(eb.port, bl[1])) == 0 {
+						fits = true
+					}
+				}
+			}
+		}
+		switch {
+		case !linked:
+			lines = append(lines, fmt.Sprintf("testbed link %s -> %s: no binding link between the devices matching its ends", l.GetA(), l.GetB()))
+		case !fits:
+			lines = append(lines, fmt.Sprintf("testbed link %s -> %s: no binding link between the devices matching its ends has ports of the wanted speed and PMD", l.GetA(), l.GetB()))
+		}
+	}
+	return lines
+}
+
+// dedup returns the strings without duplicates, in their first order.
+func dedup(ss []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -16,6 +16,8 @@ package binding
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -214,3 +216,216 @@ func TestDynamicReservationError(t *testing.T) {
 		t.Fatalf("dynamicReservation() got unexpected success: %v", got)
 	}
 }
+
+func TestDynamicReservationPool(t *testing.T) {
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{
+			Id:     "dut",
+			Vendor: opb.Device_ARISTA,
+			Ports:  []*opb.Port{{Id: "port1"}},
+		}},
+		Ates: []*opb.Device{{
+			Id:    "ate",
+			Ports: []*opb.Port{{Id: "port1"}},
+		}},
+		Links: []*opb.Link{{A: "dut:port1", B: "ate:port1"}},
+	}
+
+	b := &bindpb.Binding{
+		Dynamic: true,
+		Duts: []*bindpb.Device{{
+			Name:   "cisco.name",
+			Vendor: opb.Device_CISCO,
+			Ports:  []*bindpb.Port{{Name: "Ethernet1", Speed: opb.Port_S_100GB}},
+		}, {
+			Name:   "arista.name",
+			Vendor: opb.Device_ARISTA,
+			Ports: []*bindpb.Port{
+				{Name: "Ethernet1", Speed: opb.Port_S_100GB},
+				{Name: "Ethernet2", Speed: opb.Port_S_100GB},
+			},
+		}},
+		Ates: []*bindpb.Device{{
+			Name: "ate.name",
+			Ports: []*bindpb.Port{
+				{Name: "1/1", Speed: opb.Port_S_100GB},
+				{Name: "1/2", Speed: opb.Port_S_400GB},
+				{Name: "1/3", Speed: opb.Port_S_100GB},
+			},
+		}},
+		Links: []*bindpb.Link{
+			{A: "cisco.name:Ethernet1", B: "ate.name:1/1"},
+			// The link with a speed mismatch is not preferred.
+			{A: "arista.name:Ethernet1", B: "ate.name:1/2"},
+			{A: "arista.name:Ethernet2", B: "ate.name:1/3"},
+		},
+	}
+
+	got, err := dynamicReservation(context.Background(), tb, resolver{b})
+	if err != nil {
+		t.Fatalf("dynamicReservation() got unexpected error: %v", err)
+	}
+	if got, want := got.DUTs["dut"].Name(), "arista.name"; got != want {
+		t.Errorf("dynamicReservation() got DUT %q, want %q", got, want)
+	}
+	if got, want := got.DUTs["dut"].Ports()["port1"].Name, "Ethernet2"; got != want {
+		t.Errorf("dynamicReservation() got DUT port %q, want %q", got, want)
+	}
+	if got, want := got.ATEs["ate"].Ports()["port1"].Name, "1/3"; got != want {
+		t.Errorf("dynamicReservation() got ATE port %q, want %q", got, want)
+	}
+}
+
+func TestDynamicReservationFewestMismatches(t *testing.T) {
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{
+			Id:    "dut",
+			Ports: []*opb.Port{{Id: "port1"}, {Id: "port2"}},
+		}},
+		Ates: []*opb.Device{{
+			Id:    "ate",
+			Ports: []*opb.Port{{Id: "port1"}, {Id: "port2"}},
+		}},
+		Links: []*opb.Link{
+			{A: "dut:port1", B: "ate:port1"},
+			{A: "dut:port2", B: "ate:port2"},
+		},
+	}
+
+	b := &bindpb.Binding{
+		Dynamic: true,
+		Duts: []*bindpb.Device{{
+			Name: "dut.name",
+			Ports: []*bindpb.Port{
+				{Name: "Ethernet1", Speed: opb.Port_S_100GB},
+				{Name: "Ethernet2", Speed: opb.Port_S_400GB},
+				{Name: "Ethernet3", Speed: opb.Port_S_100GB},
+			},
+		}},
+		Ates: []*bindpb.Device{{
+			Name: "ate.name",
+			Ports: []*bindpb.Port{
+				{Name: "1/1", Speed: opb.Port_S_400GB},
+				{Name: "1/2", Speed: opb.Port_S_100GB},
+				{Name: "1/3", Speed: opb.Port_S_100GB},
+			},
+		}},
+		// Only one link has matching speeds, so one link with a speed
+		// mismatch is needed, but not two.
+		Links: []*bindpb.Link{
+			{A: "dut.name:Ethernet1", B: "ate.name:1/1"},
+			{A: "dut.name:Ethernet2", B: "ate.name:1/2"},
+			{A: "dut.name:Ethernet3", B: "ate.name:1/3"},
+		},
+	}
+
+	got, err := dynamicReservation(context.Background(), tb, resolver{b})
+	if err != nil {
+		t.Fatalf("dynamicReservation() got unexpected error: %v", err)
+	}
+	var ports []string
+	for _, p := range got.DUTs["dut"].Ports() {
+		ports = append(ports, p.Name)
+	}
+	slices.Sort(ports)
+	if !slices.Contains(ports, "Ethernet3") {
+		t.Errorf("dynamicReservation() got DUT ports %v, want Ethernet3 and one link with a speed mismatch", ports)
+	}
+}
+
+func TestDiagnoseDynamic(t *testing.T) {
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{
+			Id:                 "dut",
+			Vendor:             opb.Device_ARISTA,
+			HardwareModelValue: &opb.Device_HardwareModelRegex{HardwareModelRegex: "^7280"},
+			Ports: []*opb.Port{{
+				Id:    "port1",
+				Speed: opb.Port_S_400GB,
+			}},
+		}},
+		Ates: []*opb.Device{{
+			Id: "ate",
+			Ports: []*opb.Port{{
+				Id:       "port1",
+				PmdValue: &opb.Port_Pmd_{Pmd: opb.Port_PMD_100GBASE_CR4},
+			}},
+		}},
+		Links: []*opb.Link{{A: "dut:port1", B: "ate:port1"}},
+	}
+
+	tests := []struct {
+		desc string
+		b    *bindpb.Binding
+		want string
+	}{{
+		desc: "devices",
+		b: &bindpb.Binding{
+			Duts: []*bindpb.Device{{
+				Name:   "cisco.name",
+				Vendor: opb.Device_CISCO,
+				Ports:  []*bindpb.Port{{Name: "Ethernet1", Speed: opb.Port_S_400GB}},
+			}, {
+				Name:          "arista.name",
+				Vendor:        opb.Device_ARISTA,
+				HardwareModel: "7050",
+				Ports:         []*bindpb.Port{{Name: "Ethernet1", Speed: opb.Port_S_100GB}},
+			}},
+		},
+		want: `no binding DUT matches testbed DUT "dut":
+  cisco.name: vendor is CISCO, want ARISTA; hardware model is "", want match of "^7280"
+  arista.name: hardware model is "7050", want match of "^7280"; no port matches port1 (want speed S_400GB)
+testbed has 1 ATEs, but binding has 0`,
+	}, {
+		desc: "missing link",
+		b: &bindpb.Binding{
+			Duts: []*bindpb.Device{{
+				Name:          "dut.name",
+				Vendor:        opb.Device_ARISTA,
+				HardwareModel: "7280R3",
+				Ports:         []*bindpb.Port{{Name: "Ethernet1", Speed: opb.Port_S_400GB}},
+			}},
+			Ates: []*bindpb.Device{{
+				Name:  "ate.name",
+				Ports: []*bindpb.Port{{Name: "1/1", Pmd: opb.Port_PMD_100GBASE_CR4}},
+			}},
+		},
+		want: `testbed link dut:port1 -> ate:port1: no binding link between the devices matching its ends`,
+	}, {
+		desc: "link ports",
+		b: &bindpb.Binding{
+			Duts: []*bindpb.Device{{
+				Name:          "dut.name",
+				Vendor:        opb.Device_ARISTA,
+				HardwareModel: "7280R3",
+				Ports: []*bindpb.Port{
+					{Name: "Ethernet1", Speed: opb.Port_S_400GB},
+					{Name: "Ethernet2", Speed: opb.Port_S_100GB},
+				},
+			}},
+			Ates: []*bindpb.Device{{
+				Name: "ate.name",
+				Ports: []*bindpb.Port{
+					{Name: "1/1", Pmd: opb.Port_PMD_100GBASE_CR4},
+					{Name: "1/2"},
+				},
+			}},
+			Links: []*bindpb.Link{
+				{A: "dut.name:Ethernet1", B: "ate.name:1/2"},
+				{A: "ate.name:1/1", B: "dut.name:Ethernet2"},
+			},
+		},
+		want: `testbed link dut:port1 -> ate:port1: no binding link between the devices matching its ends has ports of the wanted speed and PMD`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, diagnoseDynamic(tb, test.b)); diff != "" {
+				t.Errorf("diagnoseDynamic() got unexpected diff (-want, +got):\n%s", diff)
+			}
+			if _, err := dynamicReservation(context.Background(), tb, resolver{test.b}); err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("dynamicReservation() got error %v, want it to contain %q", err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/fakedevice"
+	"github.com/openconfig/gnoigo"
+	"github.com/openconfig/ondatra/binding"
+	"google.golang.org/grpc"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+	opb "github.com/openconfig/ondatra/proto"
+	p4pb "github.com/p4lang/p4runtime/go/p4/v1"
+)
+
+const fakeResvID = "FAKE"
+
+// fakeBind implements the binding.Binding interface by starting in-process
+// fake devices for the testbed, so that tests can run without hardware.
+// It implements every method of the interface rather than embedding it, so
+// that no call can reach a nil binding.
+type fakeBind struct {
+	resv *binding.Reservation
+}
+
+var _ binding.Binding = (*fakeBind)(nil)
+
+type fakeDUT struct {
+	*binding.AbstractDUT
+	dev *fakedevice.Device
+}
+
+var _ ProfileDUT = (*fakeDUT)(nil)
+
+type fakeATE struct {
+	*binding.AbstractATE
+	dev *fakedevice.Device
+}
+
+// fakeDevices are the devices of the current fake reservation by name.
+var (
+	fakeDevicesMu sync.Mutex
+	fakeDevices   = make(map[string]*fakedevice.Device)
+)
+
+// FakeDevice returns the fake device with the given name, when the devices
+// are reserved with -fake, so that a test can inspect or change the
+// datastore of the device:
+//
+//	dev, ok := binding.FakeDevice(dut.Name())
+func FakeDevice(name string) (*fakedevice.Device, bool) {
+	fakeDevicesMu.Lock()
+	defer fakeDevicesMu.Unlock()
+	dev, ok := fakeDevices[name]
+	return dev, ok
+}
+
+func (b *fakeBind) Reserve(_ context.Context, tb *opb.Testbed, _, _ time.Duration, _ map[string]string) (*binding.Reservation, error) {
+	if b.resv != nil {
+		return nil, fmt.Errorf("only one reservation is allowed")
+	}
+	resv := &binding.Reservation{
+		ID:   fakeResvID,
+		DUTs: make(map[string]binding.DUT),
+		ATEs: make(map[string]binding.ATE),
+	}
+	for _, td := range tb.GetDuts() {
+		dims := fakeDims(td)
+		var ports []string
+		for _, p := range dims.Ports {
+			ports = append(ports, p.Name)
+		}
+		dev, err := fakedevice.NewDUT(dims.Name, ports)
+		if err != nil {
+			closeFakeDevices()
+			return nil, err
+		}
+		addFakeDevice(dev)
+		resv.DUTs[td.GetId()] = &fakeDUT{AbstractDUT: &binding.AbstractDUT{Dims: dims}, dev: dev}
+	}
+	for _, ta := range tb.GetAtes() {
+		dims := fakeDims(ta)
+		dev := fakedevice.NewATE(dims.Name)
+		addFakeDevice(dev)
+		resv.ATEs[ta.GetId()] = &fakeATE{AbstractATE: &binding.AbstractATE{Dims: dims}, dev: dev}
+	}
+	b.resv = resv
+	return resv, nil
+}
+
+// fakeDims returns the dimensions of a fake device.  The device and its
+// ports are named after their IDs in the testbed, and the vendor defaults
+// to OPENCONFIG.
+func fakeDims(td *opb.Device) *binding.Dims {
+	dims := &binding.Dims{
+		Name:            td.GetId(),
+		Vendor:          td.GetVendor(),
+		HardwareModel:   td.GetHardwareModel(),
+		SoftwareVersion: td.GetSoftwareVersion(),
+		Ports:           make(map[string]*binding.Port),
+	}
+	if dims.Vendor == opb.Device_VENDOR_UNSPECIFIED {
+		dims.Vendor = opb.Device_OPENCONFIG
+	}
+	if dims.HardwareModel == "" {
+		dims.HardwareModel = "fake"
+	}
+	if dims.SoftwareVersion == "" {
+		dims.SoftwareVersion = "fake"
+	}
+	for _, tp := range td.GetPorts() {
+		dims.Ports[tp.GetId()] = &binding.Port{
+			Name:  tp.GetId(),
+			Speed: tp.GetSpeed(),
+			PMD:   tp.GetPmd(),
+		}
+	}
+	return dims
+}
+
+func (b *fakeBind) Release(context.Context) error {
+	if b.resv == nil {
+		return errors.New("no reservation")
+	}
+	closeFakeDevices()
+	b.resv = nil
+	return nil
+}
+
+func addFakeDevice(dev *fakedevice.Device) {
+	fakeDevicesMu.Lock()
+	defer fakeDevicesMu.Unlock()
+	fakeDevices[dev.Name()] = dev
+}
+
+func closeFakeDevices() {
+	fakeDevicesMu.Lock()
+	defer fakeDevicesMu.Unlock()
+	for name, dev := range fakeDevices {
+		dev.Close()
+		delete(fakeDevices, name)
+	}
+}
+
+func (b *fakeBind) FetchReservation(context.Context, string) (*binding.Reservation, error) {
+	return nil, errors.New("fake binding does not support fetching an existing reservation")
+}
+
+func (d *fakeDUT) DialGNMI(ctx context.Context, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
+	conn, err := d.dev.Dial(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gpb.NewGNMIClient(conn), nil
+}
+
+func (d *fakeDUT) DialGNOI(ctx context.Context, opts ...grpc.DialOption) (gnoigo.Clients, error) {
+	conn, err := d.dev.Dial(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gnoigo.NewClients(conn), nil
+}
+
+func (d *fakeDUT) DialGRIBI(ctx context.Context, opts ...grpc.DialOption) (grpb.GRIBIClient, error) {
+	conn, err := d.dev.Dial(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return grpb.NewGRIBIClient(conn), nil
+}
+
+// The fake devices do not authenticate their clients, so the dial profiles
+// of the binding do not apply and are ignored.
+
+func (d *fakeDUT) DialGNMIProfile(ctx context.Context, _ string, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
+	return d.DialGNMI(ctx, opts...)
+}
+
+func (d *fakeDUT) DialGRIBIProfile(ctx context.Context, _ string, opts ...grpc.DialOption) (grpb.GRIBIClient, error) {
+	return d.DialGRIBI(ctx, opts...)
+}
+
+func (d *fakeDUT) DialP4RTProfile(ctx context.Context, _ string, opts ...grpc.DialOption) (p4pb.P4RuntimeClient, error) {
+	return d.DialP4RT(ctx, opts...)
+}
+
+func (a *fakeATE) DialOTG(ctx context.Context, opts ...grpc.DialOption) (gosnappi.Api, error) {
+	conn, err := a.dev.Dial(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	api := gosnappiNewAPIFn()
+	api.NewGrpcTransport().SetClientConnection(conn)
+	return api, nil
+}
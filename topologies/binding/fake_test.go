@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/gnmi/oc/ocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+func TestFakeBind(t *testing.T) {
+	ctx := context.Background()
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{
+			Id:    "dut",
+			Ports: []*opb.Port{{Id: "port1", Speed: opb.Port_S_100GB}, {Id: "port2"}},
+		}},
+		Ates: []*opb.Device{{
+			Id:    "ate",
+			Ports: []*opb.Port{{Id: "port1"}},
+		}},
+	}
+
+	b := &fakeBind{}
+	resv, err := b.Reserve(ctx, tb, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("Reserve() got unexpected error: %v", err)
+	}
+	if _, err := b.Reserve(ctx, tb, 0, 0, nil); err == nil {
+		t.Errorf("Reserve() twice unexpectedly succeeded")
+	}
+
+	dut := resv.DUTs["dut"]
+	wantDims := &binding.Dims{
+		Name:            "dut",
+		Vendor:          opb.Device_OPENCONFIG,
+		HardwareModel:   "fake",
+		SoftwareVersion: "fake",
+		Ports: map[string]*binding.Port{
+			"port1": {Name: "port1", Speed: opb.Port_S_100GB},
+			"port2": {Name: "port2"},
+		},
+	}
+	if diff := cmp.Diff(wantDims, dut.(*fakeDUT).Dims); diff != "" {
+		t.Errorf("Reserve() DUT dims -want,+got:\n%s", diff)
+	}
+
+	gnmiClient, err := dut.DialGNMI(ctx)
+	if err != nil {
+		t.Fatalf("DialGNMI() got unexpected error: %v", err)
+	}
+	c, err := ygnmi.NewClient(gnmiClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := ygnmi.GetAll(ctx, c, ocpath.Root().InterfaceAny().Name().State())
+	if err != nil {
+		t.Fatalf("GetAll() got unexpected error: %v", err)
+	}
+	if len(names) != 2 {
+		t.Errorf("GetAll() got interfaces %v, want the 2 DUT ports", names)
+	}
+	if _, err := dut.DialGRIBI(ctx); err != nil {
+		t.Errorf("DialGRIBI() got unexpected error: %v", err)
+	}
+	if _, err := resv.ATEs["ate"].DialOTG(ctx); err != nil {
+		t.Errorf("DialOTG() got unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"dut", "ate"} {
+		if _, ok := FakeDevice(name); !ok {
+			t.Errorf("FakeDevice(%q) not found", name)
+		}
+	}
+	if err := b.Release(ctx); err != nil {
+		t.Fatalf("Release() got unexpected error: %v", err)
+	}
+	if _, ok := FakeDevice("dut"); ok {
+		t.Errorf("FakeDevice(%q) found after Release()", "dut")
+	}
+	if err := b.Release(ctx); err == nil {
+		t.Errorf("Release() twice unexpectedly succeeded")
+	}
+	if _, err := b.FetchReservation(ctx, fakeResvID); err == nil {
+		t.Errorf("FetchReservation() unexpectedly succeeded")
+	}
+}
@@ -26,6 +26,8 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/openconfig/featureprofiles/internal/core"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/health"
 	"github.com/openconfig/featureprofiles/internal/rundata"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/binding"
@@ -45,12 +47,14 @@ var (
 	pushConfig   = flag.Bool("push-config", true, "push device reset config supplied to static binding")
 	kneTopo      = flag.String("kne-topo", "", "KNE topology file")
 	kneSkipReset = flag.Bool("kne-skip-reset", false, "skip the initial config reset phase when using KNE")
+	fake         = flag.Bool("fake", false, "reserve in-process fake devices, to run tests without hardware")
+	ocValues     = flag.Bool("oc-values", false, "rewrite OpenConfig values in DUT gNMI requests and responses using the value deviations in the deviation registry (static binding only)")
 	credFlags    = knecreds.DefineFlags()
 )
 
 // New creates a new binding that could be either a vendor plugin, a
-// binding configuration file, or a KNE configuration file.  This
-// depends on the command line flags given.
+// binding configuration file, in-process fake devices, or a KNE
+// configuration file.  This depends on the command line flags given.
 //
 // The vendor plugin should be a "package main" with a New function
 // that will receive the value of the --plugin-args flag as a string.
@@ -75,6 +79,10 @@ func New() (binding.Binding, error) {
 	}
 	// Register core file handler for DUTs.
 	core.Register()
+	// Register the health sweep of DUTs, which runs with -health_sweep.
+	health.Register()
+	// Report the deviations exercised by the tests.
+	deviations.RegisterExercised()
 	return &rundataBind{Binding: b}, nil
 }
 
@@ -85,6 +93,9 @@ func newBind() (binding.Binding, error) {
 	if *bindingFile != "" {
 		return staticBinding(*bindingFile)
 	}
+	if *fake {
+		return &fakeBind{}, nil
+	}
 	if *kneTopo != "" {
 		cred, err := credFlags.Parse()
 		if err != nil {
@@ -104,7 +115,7 @@ func newBind() (binding.Binding, error) {
 		}
 		return knebind.New(cfg)
 	}
-	return nil, errors.New("one of -plugin, -binding, or -kne-topo must be provided")
+	return nil, errors.New("one of -plugin, -binding, -fake, or -kne-topo must be provided")
 }
 
 // NewFunc describes the type of the New function that a vendor
@@ -139,8 +150,8 @@ func staticBinding(bindingFile string) (binding.Binding, error) {
 		return nil, fmt.Errorf("unable to parse binding file: %w", err)
 	}
 	for _, ate := range b.Ates {
-		if ate.Otg != nil && ate.Ixnetwork != nil {
-			return nil, fmt.Errorf("otg and ixnetwork are mutually exclusive, please configure one of them in ate %s binding", ate.Name)
+		if err := checkATE(ate); err != nil {
+			return nil, err
 		}
 	}
 	return &staticBind{
@@ -150,9 +161,18 @@ func staticBinding(bindingFile string) (binding.Binding, error) {
 	}, nil
 }
 
+// checkATE checks the services configured for an ATE binding.
+func checkATE(ate *bindpb.Device) error {
+	if ate.Otg != nil && ate.Ixnetwork != nil {
+		return fmt.Errorf("otg and ixnetwork are mutually exclusive, please configure one of them in ate %s binding", ate.Name)
+	}
+	return nil
+}
+
 // rundataBind wraps an Ondatra binding to report rundata.
 type rundataBind struct {
 	binding.Binding
+	resv *binding.Reservation
 }
 
 func (b *rundataBind) Reserve(ctx context.Context, tb *opb.Testbed, runTime, waitTime time.Duration, partial map[string]string) (*binding.Reservation, error) {
@@ -161,6 +181,7 @@ func (b *rundataBind) Reserve(ctx context.Context, tb *opb.Testbed, runTime, wai
 		return nil, err
 	}
 	b.addResvProperties(ctx, resv)
+	b.resv = resv
 	return resv, nil
 }
 
@@ -170,6 +191,7 @@ func (b *rundataBind) FetchReservation(ctx context.Context, id string) (*binding
 		return nil, err
 	}
 	b.addResvProperties(ctx, resv)
+	b.resv = resv
 	return resv, nil
 }
 
@@ -183,5 +205,8 @@ func (b *rundataBind) Release(ctx context.Context) error {
 	for k, v := range rundata.Timing(ctx) {
 		ondatra.Report().AddSuiteProperty(k, v)
 	}
+	for k, v := range rundata.Endpoints(ctx, b.resv) {
+		ondatra.Report().AddSuiteProperty(k, v)
+	}
 	return b.Binding.Release(ctx)
 }
@@ -17,6 +17,7 @@ package binding
 import (
 	"flag"
 	"fmt"
+	"slices"
 
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	"github.com/openconfig/ondatra/binding/introspect"
@@ -84,11 +85,51 @@ func merge(bopts ...*bindpb.Options) *bindpb.Options {
 	for _, bopt := range bopts {
 		if bopt != nil {
 			proto.Merge(result, bopt)
+			// The credentials are replaced as a whole, rather than merged, so
+			// that the args of a credential helper are not appended.  A
+			// username or password replaces the inherited credentials, which
+			// would otherwise take precedence over them.
+			switch {
+			case bopt.Credentials != nil:
+				result.Credentials = proto.Clone(bopt.Credentials).(*bindpb.Credentials)
+			case bopt.GetUsername() != "" || bopt.GetPassword() != "":
+				result.Credentials = nil
+			}
+			// Likewise, the retry codes, the jump hosts and the failover targets
+			// are replaced rather than appended.
+			if codes := bopt.GetRetry().GetCodes(); len(codes) > 0 {
+				result.Retry.Codes = slices.Clone(codes)
+			}
+			if jumps := bopt.GetJumpHosts(); len(jumps) > 0 {
+				result.JumpHosts = nil
+				for _, jh := range jumps {
+					result.JumpHosts = append(result.JumpHosts, proto.Clone(jh).(*bindpb.Options))
+				}
+			}
+			if targets := bopt.GetFailoverTargets(); len(targets) > 0 {
+				result.FailoverTargets = slices.Clone(targets)
+			}
 		}
 	}
 	return result
 }
 
+// withProfile returns the options merged with their named dial profile, or
+// the options themselves if the profile is empty.  A profile with a username
+// or password does not use the credentials of the options.
+func withProfile(bopts *bindpb.Options, profile string) (*bindpb.Options, error) {
+	if profile == "" {
+		return bopts, nil
+	}
+	p, ok := bopts.GetProfiles()[profile]
+	if !ok {
+		return nil, fmt.Errorf("no dial profile %q", profile)
+	}
+	result := merge(bopts, p)
+	result.Profiles = nil
+	return result, nil
+}
+
 type resolver struct {
 	*bindpb.Binding
 }
@@ -15,10 +15,12 @@
 package binding
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
@@ -84,6 +86,64 @@ func TestMerge(t *testing.T) {
 			Username:   "username2",
 			Password:   "password3",
 		},
+	}, {
+		name: "CredentialsOverride",
+		args: []*bindpb.Options{{
+			Credentials: &bindpb.Credentials{
+				Source:       &bindpb.Credentials_Exec{Exec: &bindpb.ExecCredentials{Command: "helper", Args: []string{"global"}}},
+				CacheSeconds: 60,
+			},
+		}, {
+			Credentials: &bindpb.Credentials{
+				Source: &bindpb.Credentials_Exec{Exec: &bindpb.ExecCredentials{Command: "helper", Args: []string{"dut"}}},
+			},
+		}},
+		want: &bindpb.Options{
+			Credentials: &bindpb.Credentials{
+				Source: &bindpb.Credentials_Exec{Exec: &bindpb.ExecCredentials{Command: "helper", Args: []string{"dut"}}},
+			},
+		},
+	}, {
+		name: "UsernameOverridesCredentials",
+		args: []*bindpb.Options{{
+			Credentials: &bindpb.Credentials{
+				Source: &bindpb.Credentials_Exec{Exec: &bindpb.ExecCredentials{Command: "helper", Args: []string{"global"}}},
+			},
+		}, {
+			Username: "username",
+			Password: "password",
+		}},
+		want: &bindpb.Options{
+			Username: "username",
+			Password: "password",
+		},
+	}, {
+		name: "RetryOverride",
+		args: []*bindpb.Options{{
+			Retry:     &bindpb.RetryPolicy{MaxAttempts: 3, Codes: []string{"UNAVAILABLE"}},
+			Keepalive: &bindpb.Keepalive{Time: 60},
+		}, {
+			Retry:        &bindpb.RetryPolicy{Codes: []string{"UNAVAILABLE", "INTERNAL"}},
+			Keepalive:    &bindpb.Keepalive{Timeout: 10},
+			WaitForReady: true,
+		}},
+		want: &bindpb.Options{
+			Retry:        &bindpb.RetryPolicy{MaxAttempts: 3, Codes: []string{"UNAVAILABLE", "INTERNAL"}},
+			Keepalive:    &bindpb.Keepalive{Time: 60, Timeout: 10},
+			WaitForReady: true,
+		},
+	}, {
+		name: "JumpHostsOverride",
+		args: []*bindpb.Options{{
+			JumpHosts: []*bindpb.Options{{Target: "bastion1:22"}, {Target: "bastion2:22"}},
+			Proxy:     "socks5://proxy:1080",
+		}, {
+			JumpHosts: []*bindpb.Options{{Target: "bastion3:22"}},
+		}},
+		want: &bindpb.Options{
+			JumpHosts: []*bindpb.Options{{Target: "bastion3:22"}},
+			Proxy:     "socks5://proxy:1080",
+		},
 	}}
 
 	for _, c := range cases {
@@ -272,3 +332,96 @@ func TestResolver_Options(t *testing.T) {
 		})
 	}
 }
+
+func TestWithProfile(t *testing.T) {
+	r := resolver{&bindpb.Binding{
+		Options: &bindpb.Options{
+			Username: "global.username",
+			Password: "global.password",
+			Profiles: map[string]*bindpb.Options{
+				"backup":   {Username: "global.backup"},
+				"readonly": {Username: "global.readonly", Password: "global.readonly.password"},
+			},
+		},
+	}}
+	dev := &bindpb.Device{
+		Name: "dut.name",
+		Gribi: &bindpb.Options{
+			Profiles: map[string]*bindpb.Options{
+				"backup": {Username: "gribi.backup", CertFile: "backup.pem"},
+			},
+		},
+	}
+	bopts := r.grpc(dev, dutSvcParams[introspect.GRIBI])
+	target := "dut.name:" + strconv.Itoa(*gribiPort)
+
+	cases := []struct {
+		profile string
+		want    *bindpb.Options
+		wantErr string
+	}{{
+		profile: "",
+		want:    bopts,
+	}, {
+		profile: "backup",
+		want: &bindpb.Options{
+			Target:   target,
+			Username: "gribi.backup",
+			Password: "global.password",
+			CertFile: "backup.pem",
+		},
+	}, {
+		profile: "readonly",
+		want: &bindpb.Options{
+			Target:   target,
+			Username: "global.readonly",
+			Password: "global.readonly.password",
+		},
+	}, {
+		profile: "unknown",
+		wantErr: `no dial profile "unknown"`,
+	}}
+
+	for _, c := range cases {
+		t.Run(c.profile, func(t *testing.T) {
+			got, err := withProfile(bopts, c.profile)
+			if diff := errdiff.Substring(err, c.wantErr); diff != "" {
+				t.Fatalf("withProfile() got unexpected error: %s", diff)
+			}
+			if diff := cmp.Diff(c.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("withProfile() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithProfileOverCredentials(t *testing.T) {
+	bopts := &bindpb.Options{
+		Target: "dut.name:9340",
+		Credentials: &bindpb.Credentials{
+			Source: &bindpb.Credentials_Env{Env: &bindpb.EnvCredentials{Username: "USER", Password: "PASS"}},
+		},
+		Profiles: map[string]*bindpb.Options{
+			"readonly": {Username: "readonly", Password: "readonly.password"},
+		},
+	}
+	got, err := withProfile(bopts, "readonly")
+	if err != nil {
+		t.Fatalf("withProfile() got unexpected error: %v", err)
+	}
+	want := &bindpb.Options{
+		Target:   "dut.name:9340",
+		Username: "readonly",
+		Password: "readonly.password",
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("withProfile() got unexpected diff (-want +got):\n%s", diff)
+	}
+	username, password, err := userPass(context.Background(), got)
+	if err != nil {
+		t.Fatalf("userPass() got unexpected error: %v", err)
+	}
+	if username != "readonly" || password != "readonly.password" {
+		t.Errorf("userPass() got %q, %q, want the username and password of the profile", username, password)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra/binding/introspect"
+	"google.golang.org/grpc"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+)
+
+const (
+	defaultOTGProbeTimeout   = 10 * time.Second
+	defaultOTGProbeAttempts  = 3
+	defaultOTGInitialBackoff = time.Second
+)
+
+// otgFailovers are the failovers of the OTG controllers of the ATEs.
+var (
+	otgFailoversMu sync.Mutex
+	otgFailovers   = make(map[*staticATE]*otgFailover)
+)
+
+// otgFailover dials the first target of an OTG controller that can be
+// dialed, starting at the current one.  As the gRPC connection dials it
+// again when the connection is lost, the connection fails over to a standby
+// controller when the current one goes away.
+type otgFailover struct {
+	bopts   *bindpb.Options
+	targets []string
+
+	mu      sync.Mutex
+	current int
+}
+
+func newOTGFailover(bopts *bindpb.Options) *otgFailover {
+	return &otgFailover{
+		bopts:   bopts,
+		targets: append([]string{bopts.GetTarget()}, bopts.GetFailoverTargets()...),
+	}
+}
+
+// dial is the context dialer of the gRPC connection, which ignores the
+// address of the connection.
+func (f *otgFailover) dial(ctx context.Context, _ string) (net.Conn, error) {
+	f.mu.Lock()
+	start := f.current
+	f.mu.Unlock()
+	var errs []error
+	for i := range f.targets {
+		n := (start + i) % len(f.targets)
+		conn, err := f.dialTarget(ctx, f.targets[n])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		f.mu.Lock()
+		if f.current != n {
+			glog.Warningf("OTG controller %s is unreachable, failing over to %s", f.targets[f.current], f.targets[n])
+		}
+		f.current = n
+		f.mu.Unlock()
+		return conn, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (f *otgFailover) dialTarget(ctx context.Context, target string) (net.Conn, error) {
+	if tunneled(f.bopts) {
+		return dialTunnel(ctx, f.bopts, target)
+	}
+	return (&net.Dialer{}).DialContext(ctx, "tcp", target)
+}
+
+// target returns the current target.
+func (f *otgFailover) target() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.targets[f.current]
+}
+
+// skip moves on from the current target, after it failed its health check.
+func (f *otgFailover) skip() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = (f.current + 1) % len(f.targets)
+}
+
+// OTGEndpoint returns the target of the OTG controller that the ATE is
+// connected to, or an empty string if the ATE was not dialed with a health
+// check.
+func (a *staticATE) OTGEndpoint() string {
+	otgFailoversMu.Lock()
+	f, ok := otgFailovers[a]
+	otgFailoversMu.Unlock()
+	if !ok {
+		return ""
+	}
+	return f.target()
+}
+
+// dialOTGFailover dials the first healthy OTG controller among the target and
+// the failover targets.  The controllers are probed with a GetVersion, and
+// are tried again with backoff until one of them is healthy.
+func (a *staticATE) dialOTGFailover(ctx context.Context, bopts *bindpb.Options, opts []grpc.DialOption) (gosnappi.Api, error) {
+	dialer, err := a.Dialer(introspect.OTG)
+	if err != nil {
+		return nil, err
+	}
+	f := newOTGFailover(bopts)
+	dialer.DialOpts = append(dialer.DialOpts, grpc.WithContextDialer(f.dial))
+	otgFailoversMu.Lock()
+	otgFailovers[a] = f
+	otgFailoversMu.Unlock()
+
+	hc := bopts.GetHealthCheck()
+	probeTimeout := defaultOTGProbeTimeout
+	if hc.GetTimeout() > 0 {
+		probeTimeout = time.Duration(hc.GetTimeout()) * time.Second
+	}
+	attempts := defaultOTGProbeAttempts
+	if hc.GetMaxAttempts() > 0 {
+		attempts = int(hc.GetMaxAttempts())
+	}
+	initialBackoffMs := int32(defaultOTGInitialBackoff / time.Millisecond)
+	if hc.GetInitialBackoffMs() > 0 {
+		initialBackoffMs = hc.GetInitialBackoffMs()
+	}
+	backoff := retryBackoff(&bindpb.RetryPolicy{
+		InitialBackoffMs:  initialBackoffMs,
+		MaxBackoffMs:      hc.GetMaxBackoffMs(),
+		BackoffMultiplier: 2,
+	})
+
+	var errs []error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff(uint(attempt - 1))):
+			}
+		}
+		for range f.targets {
+			api, err := probeOTG(ctx, dialer, bopts, probeTimeout, opts)
+			if err == nil {
+				glog.Infof("Dialed OTG controller of %s at %s", a.Name(), f.target())
+				return api, nil
+			}
+			errs = append(errs, fmt.Errorf("attempt %d: %s: %w", attempt, f.target(), err))
+			f.skip()
+		}
+	}
+	return nil, fmt.Errorf("no healthy OTG controller for %s: %w", a.Name(), errors.Join(errs...))
+}
+
+// probeOTG dials the OTG controller and returns its API if it answers a
+// GetVersion within the timeout.
+func probeOTG(ctx context.Context, dialer *introspect.Dialer, bopts *bindpb.Options, timeout time.Duration, opts []grpc.DialOption) (gosnappi.Api, error) {
+	conn, err := dialer.Dial(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	api := gosnappiNewAPIFn()
+	transport := api.NewGrpcTransport().SetClientConnection(conn)
+	requestTimeout := transport.RequestTimeout()
+	if bopts.GetTimeout() != 0 {
+		requestTimeout = time.Duration(bopts.GetTimeout()) * time.Second
+	}
+	transport.SetRequestTimeout(timeout)
+	if _, err := api.GetVersion(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	transport.SetRequestTimeout(requestTimeout)
+	return api, nil
+}
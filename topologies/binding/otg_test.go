@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/open-traffic-generator/snappi/gosnappi/otg"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ondatra/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+)
+
+// versionOTG is an OTG controller that answers GetVersion if it is healthy.
+type versionOTG struct {
+	otg.UnimplementedOpenapiServer
+	healthy bool
+}
+
+func (s *versionOTG) GetVersion(context.Context, *emptypb.Empty) (*otg.GetVersionResponse, error) {
+	if !s.healthy {
+		return nil, status.Error(codes.Unavailable, "starting")
+	}
+	return &otg.GetVersionResponse{Version: &otg.Version{}}, nil
+}
+
+// startOTG starts an OTG controller and returns its address.
+func startOTG(t *testing.T, healthy bool) (string, *grpc.Server) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	otg.RegisterOpenapiServer(srv, &versionOTG{healthy: healthy})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String(), srv
+}
+
+// deadAddr returns an address that nothing listens on.
+func deadAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func newOTGATE(otgOpts *bindpb.Options) *staticATE {
+	return &staticATE{
+		AbstractATE: &binding.AbstractATE{Dims: &binding.Dims{Name: "ate"}},
+		r:           resolver{&bindpb.Binding{}},
+		dev:         &bindpb.Device{Name: "ate", Otg: otgOpts},
+	}
+}
+
+func TestDialOTGFailover(t *testing.T) {
+	origDialFn, origNewAPIFn := grpcDialContextFn, gosnappiNewAPIFn
+	defer func() { grpcDialContextFn, gosnappiNewAPIFn = origDialFn, origNewAPIFn }()
+	grpcDialContextFn = grpc.NewClient
+	gosnappiNewAPIFn = gosnappi.NewApi
+
+	healthy, _ := startOTG(t, true)
+	unhealthy, _ := startOTG(t, false)
+	dead := deadAddr(t)
+	fastRetry := &bindpb.HealthCheck{Timeout: 1, MaxAttempts: 2, InitialBackoffMs: 1}
+
+	tests := []struct {
+		desc         string
+		opts         *bindpb.Options
+		wantEndpoint string
+		wantErr      string
+	}{{
+		desc:         "health check",
+		opts:         &bindpb.Options{Target: healthy, Insecure: true, HealthCheck: fastRetry},
+		wantEndpoint: healthy,
+	}, {
+		desc:         "unreachable target",
+		opts:         &bindpb.Options{Target: dead, Insecure: true, FailoverTargets: []string{healthy}},
+		wantEndpoint: healthy,
+	}, {
+		desc:         "unhealthy target",
+		opts:         &bindpb.Options{Target: unhealthy, Insecure: true, FailoverTargets: []string{dead, healthy}, HealthCheck: fastRetry},
+		wantEndpoint: healthy,
+	}, {
+		desc:    "no healthy target",
+		opts:    &bindpb.Options{Target: unhealthy, Insecure: true, FailoverTargets: []string{dead}, HealthCheck: fastRetry},
+		wantErr: "no healthy OTG controller for ate",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			a := newOTGATE(test.opts)
+			api, err := a.DialOTG(context.Background())
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("DialOTG() got unexpected error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			defer api.Close()
+			if got := a.OTGEndpoint(); got != test.wantEndpoint {
+				t.Errorf("OTGEndpoint() got %q, want %q", got, test.wantEndpoint)
+			}
+		})
+	}
+}
+
+func TestDialOTGFailoverAfterRestart(t *testing.T) {
+	origDialFn, origNewAPIFn := grpcDialContextFn, gosnappiNewAPIFn
+	defer func() { grpcDialContextFn, gosnappiNewAPIFn = origDialFn, origNewAPIFn }()
+	grpcDialContextFn = grpc.NewClient
+	gosnappiNewAPIFn = gosnappi.NewApi
+
+	primary, primarySrv := startOTG(t, true)
+	standby, _ := startOTG(t, true)
+	a := newOTGATE(&bindpb.Options{
+		Target:          primary,
+		Insecure:        true,
+		WaitForReady:    true,
+		Retry:           &bindpb.RetryPolicy{MaxAttempts: 3, InitialBackoffMs: 1},
+		FailoverTargets: []string{standby},
+	})
+	api, err := a.DialOTG(context.Background())
+	if err != nil {
+		t.Fatalf("DialOTG() got unexpected error: %v", err)
+	}
+	defer api.Close()
+	if got := a.OTGEndpoint(); got != primary {
+		t.Fatalf("OTGEndpoint() got %q, want %q", got, primary)
+	}
+
+	primarySrv.Stop()
+	if _, err := api.GetVersion(); err != nil {
+		t.Fatalf("GetVersion() after the primary stopped got unexpected error: %v", err)
+	}
+	if got := a.OTGEndpoint(); got != standby {
+		t.Errorf("OTGEndpoint() after the primary stopped got %q, want %q", got, standby)
+	}
+}
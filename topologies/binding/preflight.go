@@ -0,0 +1,316 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/openconfig/ondatra/binding/introspect"
+	"golang.org/x/crypto/ssh"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// The gRPC services of the devices in the order they are checked.
+var (
+	dutServices = []introspect.Service{introspect.GNMI, introspect.GNOI, introspect.GNSI, introspect.GRIBI, introspect.P4RT, introspect.GNPSI}
+	ateServices = []introspect.Service{introspect.GNMI, introspect.OTG}
+)
+
+// The services of the devices that are not dialed with gRPC.
+const (
+	sshService       introspect.Service = "SSH"
+	ixNetworkService introspect.Service = "IxNetwork"
+)
+
+// CheckBinding validates a static binding against a testbed without
+// connecting to any device.  It runs the same resolution as a reservation,
+// and returns every problem found rather than only the first one, e.g. a
+// testbed device or port without a binding, a service that is configured for
+// a device that does not support it, a malformed target, or a missing TLS or
+// config file.
+func CheckBinding(ctx context.Context, b *bindpb.Binding, tb *opb.Testbed) []error {
+	r := resolver{b}
+	var errs []error
+	errs = append(errs, checkDevices("DUT", b.GetDuts())...)
+	errs = append(errs, checkDevices("ATE", b.GetAtes())...)
+
+	for _, dev := range b.GetDuts() {
+		if dev.GetOtg() != nil {
+			errs = append(errs, fmt.Errorf("DUT %q: OTG is not supported on a DUT", dev.GetId()))
+		}
+		if dev.GetIxnetwork() != nil {
+			errs = append(errs, fmt.Errorf("DUT %q: IxNetwork is not supported on a DUT", dev.GetId()))
+		}
+		for _, svc := range dutServices {
+			errs = append(errs, checkOptions("DUT", dev, svc, r.grpc(dev, dutSvcParams[svc]))...)
+		}
+		errs = append(errs, checkOptions("DUT", dev, sshService, r.ssh(dev))...)
+		for _, file := range append(dev.GetConfig().GetCliFile(), dev.GetConfig().GetGnmiSetFile()...) {
+			if _, err := os.Stat(file); err != nil {
+				errs = append(errs, fmt.Errorf("DUT %q: config file: %w", dev.GetId(), err))
+			}
+		}
+	}
+
+	for _, dev := range b.GetAtes() {
+		if err := checkATE(dev); err != nil {
+			errs = append(errs, fmt.Errorf("ATE %q: %w", dev.GetId(), err))
+		}
+		for _, svc := range []struct {
+			name introspect.Service
+			opts *bindpb.Options
+		}{
+			{sshService, dev.GetSsh()},
+			{introspect.GNOI, dev.GetGnoi()},
+			{introspect.GNSI, dev.GetGnsi()},
+			{introspect.GRIBI, dev.GetGribi()},
+			{introspect.P4RT, dev.GetP4Rt()},
+			{introspect.GNPSI, dev.GetGnpsi()},
+		} {
+			if svc.opts != nil {
+				errs = append(errs, fmt.Errorf("ATE %q: %s is not supported on an ATE", dev.GetId(), svc.name))
+			}
+		}
+		if dev.GetConfig() != nil {
+			errs = append(errs, fmt.Errorf("ATE %q: config is not supported on an ATE", dev.GetId()))
+		}
+		for _, svc := range ateServices {
+			if svc == introspect.OTG && dev.GetOtg() == nil {
+				continue
+			}
+			errs = append(errs, checkOptions("ATE", dev, svc, r.grpc(dev, ateSvcParams[svc]))...)
+		}
+		if dev.GetIxnetwork() != nil {
+			errs = append(errs, checkOptions("ATE", dev, ixNetworkService, r.ixnetwork(dev))...)
+		}
+	}
+
+	if r.Dynamic {
+		if _, err := dynamicReservation(ctx, tb, r); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		_, resvErrs := staticReservation(tb, r)
+		errs = append(errs, resvErrs...)
+	}
+	return errs
+}
+
+// checkDevices checks that the devices and their ports have unique IDs and
+// names.
+func checkDevices(role string, devs []*bindpb.Device) []error {
+	var errs []error
+	ids := make(map[string]bool)
+	for _, dev := range devs {
+		switch {
+		case dev.GetId() == "":
+			errs = append(errs, fmt.Errorf("%s %q: missing id", role, dev.GetName()))
+		case ids[dev.GetId()]:
+			errs = append(errs, fmt.Errorf("%s %q: duplicate id", role, dev.GetId()))
+		}
+		ids[dev.GetId()] = true
+		if dev.GetName() == "" {
+			errs = append(errs, fmt.Errorf("%s %q: missing name", role, dev.GetId()))
+		}
+		portIDs := make(map[string]bool)
+		for _, port := range dev.GetPorts() {
+			switch {
+			case port.GetId() == "":
+				errs = append(errs, fmt.Errorf("%s %q: port %q: missing id", role, dev.GetId(), port.GetName()))
+			case portIDs[port.GetId()]:
+				errs = append(errs, fmt.Errorf("%s %q: port %q: duplicate id", role, dev.GetId(), port.GetId()))
+			}
+			portIDs[port.GetId()] = true
+			if port.GetName() == "" {
+				errs = append(errs, fmt.Errorf("%s %q: port %q: missing name", role, dev.GetId(), port.GetId()))
+			}
+		}
+	}
+	return errs
+}
+
+// checkOptions checks the resolved options of a service of a device, and of
+// its dial profiles.  The targets are dialed with a port, except for
+// IxNetwork, and only gRPC uses the TLS files and the profiles.
+func checkOptions(role string, dev *bindpb.Device, svc introspect.Service, opts *bindpb.Options) []error {
+	var errs []error
+	addErr := func(err error) {
+		errs = append(errs, fmt.Errorf("%s %q: %s: %w", role, dev.GetId(), svc, err))
+	}
+	if c := opts.GetCredentials(); c != nil && c.GetSource() == nil {
+		addErr(errors.New("credentials have no source"))
+	}
+	for _, jh := range opts.GetJumpHosts() {
+		if _, _, err := net.SplitHostPort(jh.GetTarget()); err != nil {
+			addErr(fmt.Errorf("invalid jump host target: %w", err))
+		}
+	}
+	if opts.GetProxy() != "" {
+		if u, err := url.Parse(opts.GetProxy()); err != nil || u.Scheme != "socks5" && u.Scheme != "socks5h" {
+			addErr(fmt.Errorf("invalid SOCKS5 proxy URL %q", opts.GetProxy()))
+		}
+	}
+	if svc == ixNetworkService {
+		if opts.GetTarget() == "" {
+			addErr(errors.New("missing target"))
+		}
+		return errs
+	}
+	if _, _, err := net.SplitHostPort(opts.GetTarget()); err != nil {
+		addErr(fmt.Errorf("invalid target: %w", err))
+	}
+	for _, target := range opts.GetFailoverTargets() {
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			addErr(fmt.Errorf("invalid failover target: %w", err))
+		}
+	}
+	if _, err := retryCodes(opts.GetRetry()); err != nil {
+		addErr(err)
+	}
+	// The TLS files are only loaded when the connection is neither insecure
+	// nor unverified, see dialOpts.
+	if svc != sshService && opts.GetMutualTls() && !opts.GetInsecure() && !opts.GetSkipVerify() {
+		if _, _, err := loadCertificates(opts); err != nil {
+			addErr(err)
+		}
+	}
+	if svc != sshService {
+		for _, name := range slices.Sorted(maps.Keys(opts.GetProfiles())) {
+			popts, err := withProfile(opts, name)
+			if err != nil {
+				addErr(err)
+				continue
+			}
+			errs = append(errs, checkOptions(role, dev, introspect.Service(fmt.Sprintf("%s profile %q", svc, name)), popts)...)
+		}
+	}
+	return errs
+}
+
+// ProbeResult is the result of probing a service of a device.
+type ProbeResult struct {
+	// Device is the ID of the device in the testbed.
+	Device string
+	// Service is the probed service, e.g. "gNMI".
+	Service string
+	// Target is the address the service was dialed at.
+	Target string
+	// Err is nil if the service answered the probe.
+	Err error
+}
+
+// ProbeBinding reserves the testbed with a static binding and probes the
+// services of every DUT with a harmless RPC: gNMI Capabilities, gNOI
+// System.Time, gRIBI Get and an SSH login.  Each probe is bounded by the
+// timeout.  An error is returned only if the reservation fails.
+func ProbeBinding(ctx context.Context, b *bindpb.Binding, tb *opb.Testbed, timeout time.Duration) ([]*ProbeResult, error) {
+	r := resolver{b}
+	resv, err := reservation(ctx, tb, r)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for id := range resv.DUTs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var results []*ProbeResult
+	for _, id := range ids {
+		d := resv.DUTs[id].(*staticDUT)
+		for _, svc := range []introspect.Service{introspect.GNMI, introspect.GNOI, introspect.GRIBI} {
+			pctx, cancel := context.WithTimeout(ctx, timeout)
+			results = append(results, &ProbeResult{
+				Device:  id,
+				Service: string(svc),
+				Target:  r.grpc(d.dev, dutSvcParams[svc]).GetTarget(),
+				Err:     probeGRPC(pctx, d, svc),
+			})
+			cancel()
+		}
+		sshOpts := r.ssh(d.dev)
+		results = append(results, &ProbeResult{
+			Device:  id,
+			Service: string(sshService),
+			Target:  sshOpts.GetTarget(),
+			Err:     probeSSH(ctx, sshOpts, timeout),
+		})
+	}
+	return results, nil
+}
+
+func probeGRPC(ctx context.Context, d *staticDUT, svc introspect.Service) error {
+	conn, err := dialConn(ctx, d, svc, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	switch svc {
+	case introspect.GNMI:
+		_, err = gpb.NewGNMIClient(conn).Capabilities(ctx, &gpb.CapabilityRequest{})
+	case introspect.GNOI:
+		_, err = spb.NewSystemClient(conn).Time(ctx, &spb.TimeRequest{})
+	case introspect.GRIBI:
+		var stream grpb.GRIBI_GetClient
+		stream, err = grpb.NewGRIBIClient(conn).Get(ctx, &grpb.GetRequest{
+			NetworkInstance: &grpb.GetRequest_All{All: &grpb.Empty{}},
+			Aft:             grpb.AFTType_ALL,
+		})
+		if err == nil {
+			// An empty RIB ends the stream right away.
+			if _, err = stream.Recv(); errors.Is(err, io.EOF) {
+				err = nil
+			}
+		}
+	default:
+		err = fmt.Errorf("no probe for service %v", svc)
+	}
+	return err
+}
+
+func probeSSH(ctx context.Context, sshOpts *bindpb.Options, timeout time.Duration) error {
+	username, password, err := userPass(ctx, sshOpts)
+	if err != nil {
+		return err
+	}
+	config := &ssh.ClientConfig{
+		User: username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(password),
+			ssh.KeyboardInteractive(sshInteractive(password)),
+		},
+		Timeout: timeout,
+	}
+	sc, err := createSSHClient(ctx, config, sshOpts)
+	if err != nil {
+		return err
+	}
+	return sc.Close()
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/fakedevice"
+	"google.golang.org/grpc"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+func TestCheckBinding(t *testing.T) {
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{
+			Id:    "dut",
+			Ports: []*opb.Port{{Id: "port1"}, {Id: "port2"}},
+		}},
+		Ates: []*opb.Device{{
+			Id:    "ate",
+			Ports: []*opb.Port{{Id: "port1"}},
+		}},
+	}
+	missingFile := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		desc     string
+		b        *bindpb.Binding
+		wantErrs []string
+	}{{
+		desc: "valid",
+		b: &bindpb.Binding{
+			Duts: []*bindpb.Device{{
+				Id:    "dut",
+				Name:  "dut.example.com",
+				Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}, {Id: "port2", Name: "Ethernet2"}},
+				Ssh:   &bindpb.Options{Target: "dut.example.com:22"},
+			}},
+			Ates: []*bindpb.Device{{
+				Id:    "ate",
+				Name:  "ate.example.com",
+				Ports: []*bindpb.Port{{Id: "port1", Name: "1/1"}},
+				Otg:   &bindpb.Options{Target: "otg.example.com:40051"},
+			}},
+		},
+	}, {
+		desc: "every problem",
+		b: &bindpb.Binding{
+			Options: &bindpb.Options{MutualTls: true, CertFile: missingFile, KeyFile: missingFile, TrustBundleFile: missingFile},
+			Duts: []*bindpb.Device{{
+				Id:    "dut",
+				Name:  "dut.example.com",
+				Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}, {Id: "port1"}},
+				Gnmi:  &bindpb.Options{Insecure: true, Target: "dut.example.com", JumpHosts: []*bindpb.Options{{Target: "bastion"}}},
+				Gnoi:  &bindpb.Options{},
+				Gnsi:  &bindpb.Options{Insecure: true},
+				Gribi: &bindpb.Options{Insecure: true, Profiles: map[string]*bindpb.Options{"backup": {Target: "dut.example.com"}}},
+				P4Rt:  &bindpb.Options{Insecure: true},
+				Gnpsi: &bindpb.Options{Insecure: true},
+				Otg:   &bindpb.Options{},
+				Config: &bindpb.Configs{
+					CliFile: []string{missingFile},
+				},
+			}},
+			Ates: []*bindpb.Device{{
+				Id:        "ate",
+				Name:      "ate.example.com",
+				Ports:     []*bindpb.Port{{Id: "port2", Name: "1/2"}},
+				Gnmi:      &bindpb.Options{Insecure: true},
+				Otg:       &bindpb.Options{Insecure: true},
+				Ixnetwork: &bindpb.Options{},
+				Gribi:     &bindpb.Options{},
+			}},
+		},
+		wantErrs: []string{
+			`DUT "dut": port "port1": duplicate id`,
+			`DUT "dut": port "port1": missing name`,
+			`DUT "dut": OTG is not supported on a DUT`,
+			`DUT "dut": gNMI: invalid jump host target`,
+			`DUT "dut": gNMI: invalid target`,
+			`DUT "dut": gNOI: open`,
+			`DUT "dut": gRIBI profile "backup": invalid target`,
+			`DUT "dut": SSH: invalid target`,
+			`DUT "dut": config file:`,
+			`ATE "ate": otg and ixnetwork are mutually exclusive`,
+			`ATE "ate": gRIBI is not supported on an ATE`,
+			`missing binding for port "port2" on "dut"`,
+			`missing binding for port "port1" on "ate"`,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			errs := CheckBinding(context.Background(), test.b, tb)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(test.wantErrs) {
+				t.Fatalf("CheckBinding() got %d errors, want %d:\n%s", len(got), len(test.wantErrs), strings.Join(got, "\n"))
+			}
+			for i, want := range test.wantErrs {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("CheckBinding() got error %q, want %q", got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestProbeBinding(t *testing.T) {
+	dev, err := fakedevice.NewDUT("dut", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+	origDialFn := grpcDialContextFn
+	defer func() { grpcDialContextFn = origDialFn }()
+	grpcDialContextFn = func(_ string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return dev.Dial(context.Background(), opts...)
+	}
+
+	b := &bindpb.Binding{
+		Options: &bindpb.Options{Insecure: true},
+		Duts: []*bindpb.Device{{
+			Id:   "dut",
+			Name: "dut.example.com",
+			// Nothing listens on port 1 of the loopback.
+			Ssh: &bindpb.Options{Target: "127.0.0.1:1", SkipVerify: true},
+		}},
+	}
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut"}}}
+	results, err := ProbeBinding(context.Background(), b, tb, 5*time.Second)
+	if err != nil {
+		t.Fatalf("ProbeBinding() got unexpected error: %v", err)
+	}
+
+	type result struct {
+		Device, Service, Target string
+		OK                      bool
+	}
+	var got []result
+	for _, r := range results {
+		got = append(got, result{r.Device, r.Service, r.Target, r.Err == nil})
+	}
+	want := []result{
+		{"dut", "gNMI", "dut.example.com:9339", true},
+		{"dut", "gNOI", "dut.example.com:9339", true},
+		{"dut", "gRIBI", "dut.example.com:9340", true},
+		{"dut", "SSH", "127.0.0.1:1", false},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ProbeBinding() -want,+got:\n%s", diff)
+	}
+}
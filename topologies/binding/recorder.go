@@ -0,0 +1,303 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/binding/introspect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	binlogpb "google.golang.org/grpc/binarylog/grpc_binarylog_v1"
+)
+
+var (
+	recordGRPC = flag.Bool("record-grpc", false, "record the gRPC calls to every DUT and ATE service as gRPC binary logs in -outputs_dir (static binding only)")
+
+	// recorders are the open recorders, keyed by device and service.
+	recordersMu sync.Mutex
+	recorders   = make(map[string]*recorder)
+
+	// Stub out for unit tests.
+	recordDirFn = outputsDir
+)
+
+// redactedMetadata are the metadata keys that are not recorded.
+var redactedMetadata = map[string]bool{
+	"password": true,
+}
+
+// outputsDir returns the value of the -outputs_dir flag, which is defined by
+// fptest.  The flag is looked up because fptest depends on this package.
+func outputsDir() string {
+	if f := flag.Lookup("outputs_dir"); f != nil {
+		return f.Value.String()
+	}
+	return os.Getenv("TEST_UNDECLARED_OUTPUTS_DIR")
+}
+
+// recordDialer makes the dialer record the gRPC calls of the service of a
+// device, when -record-grpc is set.
+func recordDialer(dialer *introspect.Dialer, dev binding.Device, svc introspect.Service) error {
+	if !*recordGRPC {
+		return nil
+	}
+	rec, err := recorderFor(dev.Name(), svc)
+	if err != nil {
+		return err
+	}
+	dialer.DialOpts = append(dialer.DialOpts,
+		grpc.WithChainUnaryInterceptor(rec.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(rec.streamInterceptor),
+	)
+	return nil
+}
+
+// recorderFor returns the recorder of the service of a device, so that all
+// the connections to the service are recorded in the same file.
+func recorderFor(dev string, svc introspect.Service) (*recorder, error) {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	name := recordFile(dev, svc)
+	if rec, ok := recorders[name]; ok {
+		return rec, nil
+	}
+	dir := recordDirFn()
+	if dir == "" {
+		return nil, errors.New("-record-grpc requires -outputs_dir")
+	}
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create gRPC record of %s %v: %w", dev, svc, err)
+	}
+	rec := &recorder{w: f}
+	recorders[name] = rec
+	return rec, nil
+}
+
+// recordFile returns the name of the file recording the gRPC calls of the
+// service of a device.
+func recordFile(dev string, svc introspect.Service) string {
+	name := strings.NewReplacer("/", "_", ":", "_").Replace(dev)
+	return fmt.Sprintf("grpc.%s.%s.binlog", name, strings.ToLower(string(svc)))
+}
+
+// closeRecorders closes all the open recorders.
+func closeRecorders() error {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	var errs []error
+	for name, rec := range recorders {
+		errs = append(errs, rec.close())
+		delete(recorders, name)
+	}
+	return errors.Join(errs...)
+}
+
+// recorder writes the gRPC calls of a connection in the gRPC binary log
+// format: each GrpcLogEntry is prefixed by its length as a big-endian
+// uint32.  This is the format parsed by github.com/openconfig/replayer.
+type recorder struct {
+	mu     sync.Mutex
+	w      io.WriteCloser
+	callID uint64
+	err    error
+}
+
+func (r *recorder) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.w.Close()
+}
+
+func (r *recorder) write(entry *binlogpb.GrpcLogEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	b, err := proto.Marshal(entry)
+	if err == nil {
+		var hdr [4]byte
+		binary.BigEndian.PutUint32(hdr[:], uint32(len(b)))
+		if _, err = r.w.Write(hdr[:]); err == nil {
+			_, err = r.w.Write(b)
+		}
+	}
+	if err != nil {
+		// Only log the first error, and stop recording.
+		r.err = err
+		glog.Errorf("Stopped recording gRPC calls: %v", err)
+	}
+}
+
+// newCall records the header of a new call.
+func (r *recorder) newCall(ctx context.Context, method, authority string) *recordedCall {
+	r.mu.Lock()
+	r.callID++
+	c := &recordedCall{r: r, id: r.callID}
+	r.mu.Unlock()
+
+	hdr := &binlogpb.ClientHeader{
+		MethodName: method,
+		Authority:  authority,
+		Metadata:   &binlogpb.Metadata{},
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		for k, vs := range md {
+			if redactedMetadata[k] {
+				continue
+			}
+			for _, v := range vs {
+				hdr.Metadata.Entry = append(hdr.Metadata.Entry, &binlogpb.MetadataEntry{Key: k, Value: []byte(v)})
+			}
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		hdr.Timeout = durationpb.New(time.Until(deadline))
+	}
+	c.write(&binlogpb.GrpcLogEntry{
+		Type:    binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HEADER,
+		Payload: &binlogpb.GrpcLogEntry_ClientHeader{ClientHeader: hdr},
+	})
+	return c
+}
+
+// recordedCall records the events of a call.
+type recordedCall struct {
+	r           *recorder
+	id          uint64
+	mu          sync.Mutex
+	seq         uint64
+	trailerOnce sync.Once
+}
+
+func (c *recordedCall) write(entry *binlogpb.GrpcLogEntry) {
+	c.mu.Lock()
+	c.seq++
+	entry.SequenceIdWithinCall = c.seq
+	c.mu.Unlock()
+	entry.Timestamp = timestamppb.Now()
+	entry.CallId = c.id
+	entry.Logger = binlogpb.GrpcLogEntry_LOGGER_CLIENT
+	c.r.write(entry)
+}
+
+func (c *recordedCall) message(typ binlogpb.GrpcLogEntry_EventType, m any) {
+	var data []byte
+	if pm, ok := m.(proto.Message); ok {
+		data, _ = proto.Marshal(pm)
+	}
+	c.write(&binlogpb.GrpcLogEntry{
+		Type: typ,
+		Payload: &binlogpb.GrpcLogEntry_Message{Message: &binlogpb.Message{
+			Length: uint32(len(data)),
+			Data:   data,
+		}},
+	})
+}
+
+func (c *recordedCall) halfClose() {
+	c.write(&binlogpb.GrpcLogEntry{Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HALF_CLOSE})
+}
+
+// trailer records the status of the call, at most once.
+func (c *recordedCall) trailer(err error) {
+	c.trailerOnce.Do(func() {
+		st := status.Convert(err)
+		details, _ := proto.Marshal(st.Proto())
+		c.write(&binlogpb.GrpcLogEntry{
+			Type: binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_TRAILER,
+			Payload: &binlogpb.GrpcLogEntry_Trailer{Trailer: &binlogpb.Trailer{
+				Metadata:      &binlogpb.Metadata{},
+				StatusCode:    uint32(st.Code()),
+				StatusMessage: st.Message(),
+				StatusDetails: details,
+			}},
+		})
+	})
+}
+
+func (r *recorder) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c := r.newCall(ctx, method, cc.Target())
+	c.message(binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_MESSAGE, req)
+	c.halfClose()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		c.message(binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_MESSAGE, reply)
+	}
+	c.trailer(err)
+	return err
+}
+
+func (r *recorder) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	c := r.newCall(ctx, method, cc.Target())
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		c.trailer(err)
+		return nil, err
+	}
+	return &recordedStream{ClientStream: cs, c: c}, nil
+}
+
+// recordedStream records the messages sent and received on a stream.
+type recordedStream struct {
+	grpc.ClientStream
+	c *recordedCall
+}
+
+func (s *recordedStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.c.message(binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_MESSAGE, m)
+	}
+	return err
+}
+
+func (s *recordedStream) CloseSend() error {
+	err := s.ClientStream.CloseSend()
+	s.c.halfClose()
+	return err
+}
+
+func (s *recordedStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.c.message(binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_MESSAGE, m)
+	case errors.Is(err, io.EOF):
+		s.c.trailer(nil)
+	default:
+		s.c.trailer(err)
+	}
+	return err
+}
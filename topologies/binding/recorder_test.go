@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/binding/introspect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	binlogpb "google.golang.org/grpc/binarylog/grpc_binarylog_v1"
+)
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+// readRecords parses the entries written by a recorder.
+func readRecords(t *testing.T, b []byte) []*binlogpb.GrpcLogEntry {
+	t.Helper()
+	var entries []*binlogpb.GrpcLogEntry
+	for len(b) > 0 {
+		if len(b) < 4 {
+			t.Fatalf("Truncated record header: %v", b)
+		}
+		n := binary.BigEndian.Uint32(b)
+		b = b[4:]
+		if len(b) < int(n) {
+			t.Fatalf("Truncated record: got %d bytes, want %d", len(b), n)
+		}
+		entry := new(binlogpb.GrpcLogEntry)
+		if err := proto.Unmarshal(b[:n], entry); err != nil {
+			t.Fatalf("Cannot unmarshal record: %v", err)
+		}
+		entries = append(entries, entry)
+		b = b[n:]
+	}
+	return entries
+}
+
+// event summarizes an entry for comparison.
+type event struct {
+	CallID, Seq uint64
+	Type        binlogpb.GrpcLogEntry_EventType
+	Method      string
+	Message     proto.Message
+	Code        codes.Code
+}
+
+func events(t *testing.T, entries []*binlogpb.GrpcLogEntry) []event {
+	t.Helper()
+	var evs []event
+	for _, e := range entries {
+		ev := event{CallID: e.GetCallId(), Seq: e.GetSequenceIdWithinCall(), Type: e.GetType()}
+		switch e.GetType() {
+		case binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HEADER:
+			ev.Method = e.GetClientHeader().GetMethodName()
+			for _, md := range e.GetClientHeader().GetMetadata().GetEntry() {
+				if md.GetKey() == "password" {
+					t.Errorf("Recorded metadata contains the password: %v", md)
+				}
+			}
+		case binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_MESSAGE:
+			ev.Message = new(gpb.GetRequest)
+		case binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_MESSAGE:
+			ev.Message = new(gpb.GetResponse)
+		case binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_TRAILER:
+			ev.Code = codes.Code(e.GetTrailer().GetStatusCode())
+		}
+		if ev.Message != nil {
+			if err := proto.Unmarshal(e.GetMessage().GetData(), ev.Message); err != nil {
+				t.Fatalf("Cannot unmarshal message: %v", err)
+			}
+		}
+		evs = append(evs, ev)
+	}
+	return evs
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	recv []*gpb.GetResponse
+	err  error
+}
+
+func (s *fakeClientStream) SendMsg(any) error { return nil }
+func (s *fakeClientStream) CloseSend() error  { return nil }
+
+func (s *fakeClientStream) RecvMsg(m any) error {
+	if len(s.recv) == 0 {
+		return s.err
+	}
+	proto.Merge(m.(proto.Message), s.recv[0])
+	s.recv = s.recv[1:]
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	cc, err := grpc.NewClient("passthrough:///dut:9339", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	var buf bytes.Buffer
+	r := &recorder{w: nopCloser{&buf}}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "username", "admin", "password", "secret")
+
+	req := &gpb.GetRequest{Prefix: &gpb.Path{Target: "dut"}}
+	resp := &gpb.GetResponse{Notification: []*gpb.Notification{{Timestamp: 42}}}
+
+	// Successful unary call.
+	err = r.unaryInterceptor(ctx, "/gnmi.gNMI/Get", req, new(gpb.GetResponse), cc,
+		func(_ context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			proto.Merge(reply.(proto.Message), resp)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unaryInterceptor() got unexpected error: %v", err)
+	}
+
+	// Stream ending with an error.
+	cs, err := r.streamInterceptor(ctx, &grpc.StreamDesc{}, cc, "/gnmi.gNMI/Subscribe",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &fakeClientStream{recv: []*gpb.GetResponse{resp}, err: status.Error(codes.Unavailable, "gone")}, nil
+		})
+	if err != nil {
+		t.Fatalf("streamInterceptor() got unexpected error: %v", err)
+	}
+	if err := cs.SendMsg(req); err != nil {
+		t.Fatalf("SendMsg() got unexpected error: %v", err)
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatalf("CloseSend() got unexpected error: %v", err)
+	}
+	for cs.RecvMsg(new(gpb.GetResponse)) == nil {
+	}
+
+	got := events(t, readRecords(t, buf.Bytes()))
+	want := []event{
+		{CallID: 1, Seq: 1, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HEADER, Method: "/gnmi.gNMI/Get"},
+		{CallID: 1, Seq: 2, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_MESSAGE, Message: req},
+		{CallID: 1, Seq: 3, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HALF_CLOSE},
+		{CallID: 1, Seq: 4, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_MESSAGE, Message: resp},
+		{CallID: 1, Seq: 5, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_TRAILER, Code: codes.OK},
+		{CallID: 2, Seq: 1, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HEADER, Method: "/gnmi.gNMI/Subscribe"},
+		{CallID: 2, Seq: 2, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_MESSAGE, Message: req},
+		{CallID: 2, Seq: 3, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_CLIENT_HALF_CLOSE},
+		{CallID: 2, Seq: 4, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_MESSAGE, Message: resp},
+		{CallID: 2, Seq: 5, Type: binlogpb.GrpcLogEntry_EVENT_TYPE_SERVER_TRAILER, Code: codes.Unavailable},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Recorded events -want,+got:\n%s", diff)
+	}
+}
+
+func TestRecordDialer(t *testing.T) {
+	dir := t.TempDir()
+	dut := &staticDUT{AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: "dut"}}}
+	origDir, origRecord := recordDirFn, *recordGRPC
+	defer func() {
+		recordDirFn, *recordGRPC = origDir, origRecord
+	}()
+	recordDirFn = func() string { return dir }
+
+	*recordGRPC = false
+	dialer := &introspect.Dialer{}
+	if err := recordDialer(dialer, dut, introspect.GNMI); err != nil {
+		t.Fatalf("recordDialer() got unexpected error: %v", err)
+	}
+	if len(dialer.DialOpts) != 0 {
+		t.Errorf("recordDialer() without -record-grpc added %d dial options, want 0", len(dialer.DialOpts))
+	}
+
+	*recordGRPC = true
+	for _, svc := range []introspect.Service{introspect.GNMI, introspect.GNMI, introspect.GRIBI} {
+		dialer := &introspect.Dialer{}
+		if err := recordDialer(dialer, dut, svc); err != nil {
+			t.Fatalf("recordDialer(%v) got unexpected error: %v", svc, err)
+		}
+		if got, want := len(dialer.DialOpts), 2; got != want {
+			t.Errorf("recordDialer(%v) added %d dial options, want %d", svc, got, want)
+		}
+	}
+	if got, want := len(recorders), 2; got != want {
+		t.Errorf("recordDialer() opened %d recorders, want %d", got, want)
+	}
+	if err := closeRecorders(); err != nil {
+		t.Fatalf("closeRecorders() got unexpected error: %v", err)
+	}
+	for _, name := range []string{"grpc.dut.gnmi.binlog", "grpc.dut.gribi.binlog"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Record %s not created: %v", name, err)
+		}
+	}
+
+	recordDirFn = func() string { return "" }
+	if err := recordDialer(&introspect.Dialer{}, dut, introspect.GNMI); err == nil {
+		t.Errorf("recordDialer() without -outputs_dir unexpectedly succeeded")
+	}
+}
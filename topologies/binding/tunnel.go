@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/proxy"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+)
+
+// dialFunc dials a network connection.
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// tunneled returns whether the target of the options is reached through a
+// proxy or jump hosts.
+func tunneled(bopts *bindpb.Options) bool {
+	return bopts.GetProxy() != "" || len(bopts.GetJumpHosts()) > 0
+}
+
+// dialTunnel dials the address through the proxy and the jump hosts of the
+// options.  The SSH connections to the jump hosts are closed with the
+// returned connection.
+func dialTunnel(ctx context.Context, bopts *bindpb.Options, addr string) (net.Conn, error) {
+	dial := (&net.Dialer{}).DialContext
+	if bopts.GetProxy() != "" {
+		u, err := url.Parse(bopts.GetProxy())
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		d, err := proxy.FromURL(u, proxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", u.Redacted(), err)
+		}
+		cd, ok := d.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("proxy %s does not support dialing with a context", u.Redacted())
+		}
+		dial = cd.DialContext
+	}
+
+	var jumps []*ssh.Client
+	closeJumps := func() {
+		for i := len(jumps) - 1; i >= 0; i-- {
+			jumps[i].Close()
+		}
+	}
+	for _, jh := range bopts.GetJumpHosts() {
+		client, err := dialJumpHost(ctx, dial, jh)
+		if err != nil {
+			closeJumps()
+			return nil, err
+		}
+		jumps = append(jumps, client)
+		dial = client.DialContext
+	}
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		closeJumps()
+		return nil, fmt.Errorf("cannot dial %s through the tunnel: %w", addr, err)
+	}
+	if len(jumps) == 0 {
+		return conn, nil
+	}
+	return &tunnelConn{Conn: conn, jumps: jumps}, nil
+}
+
+// dialJumpHost connects to an SSH jump host with the dial function.
+func dialJumpHost(ctx context.Context, dial dialFunc, jh *bindpb.Options) (*ssh.Client, error) {
+	username, password, err := userPass(ctx, jh)
+	if err != nil {
+		return nil, err
+	}
+	config := &ssh.ClientConfig{User: username}
+	if jh.GetKeyFile() != "" {
+		key, err := os.ReadFile(jh.GetKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("cannot read key of jump host %s: %w", jh.GetTarget(), err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key of jump host %s: %w", jh.GetTarget(), err)
+		}
+		config.Auth = append(config.Auth, ssh.PublicKeys(signer))
+	}
+	if password != "" {
+		config.Auth = append(config.Auth,
+			ssh.Password(password),
+			ssh.KeyboardInteractive(sshInteractive(password)),
+		)
+	}
+	if jh.GetSkipVerify() {
+		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		cb, err := knownHostsCallback()
+		if err != nil {
+			return nil, err
+		}
+		config.HostKeyCallback = cb
+	}
+
+	conn, err := dial(ctx, "tcp", jh.GetTarget())
+	if err != nil {
+		return nil, fmt.Errorf("cannot dial jump host %s: %w", jh.GetTarget(), err)
+	}
+	sc, chans, reqs, err := ssh.NewClientConn(conn, jh.GetTarget(), config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("cannot connect to jump host %s: %w", jh.GetTarget(), err)
+	}
+	return ssh.NewClient(sc, chans, reqs), nil
+}
+
+// tunnelConn is a connection through jump hosts, which closes the
+// connections to the jump hosts when it is closed.
+type tunnelConn struct {
+	net.Conn
+	jumps []*ssh.Client
+}
+
+func (c *tunnelConn) Close() error {
+	err := c.Conn.Close()
+	for i := len(c.jumps) - 1; i >= 0; i-- {
+		c.jumps[i].Close()
+	}
+	return err
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"google.golang.org/grpc"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// startJumpHost starts an SSH server that forwards TCP connections, like a
+// bastion host, and returns its address.
+func startJumpHost(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "alice" && string(password) == "bob" {
+				return nil, nil
+			}
+			return nil, errors.New("login error")
+		},
+	}
+	config.AddHostKey(sshServerSigner)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveJumpHost(conn, config)
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func serveJumpHost(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for nch := range chans {
+		if nch.ChannelType() != "direct-tcpip" {
+			nch.Reject(ssh.UnknownChannelType, "only direct-tcpip is supported")
+			continue
+		}
+		var payload struct {
+			Host     string
+			Port     uint32
+			OrigHost string
+			OrigPort uint32
+		}
+		if err := ssh.Unmarshal(nch.ExtraData(), &payload); err != nil {
+			nch.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+		if err != nil {
+			nch.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		ch, chReqs, err := nch.Accept()
+		if err != nil {
+			target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(chReqs)
+		go func() {
+			io.Copy(ch, target)
+			ch.Close()
+		}()
+		go func() {
+			io.Copy(target, ch)
+			target.Close()
+		}()
+	}
+}
+
+func TestDialTunnel(t *testing.T) {
+	// A gNMI server that is only reachable through the jump hosts.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	gpb.RegisterGNMIServer(srv, &flakyGNMI{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jump1, jump2 := startJumpHost(t), startJumpHost(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(jump1)}, sshServerSigner.PublicKey())
+	if err := os.WriteFile(knownHosts, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	origKnownHostsFiles := knownHostsFiles
+	defer func() { knownHostsFiles = origKnownHostsFiles }()
+	knownHostsFiles = []string{knownHosts}
+	origDialFn := grpcDialContextFn
+	defer func() { grpcDialContextFn = origDialFn }()
+	grpcDialContextFn = grpc.NewClient
+
+	tests := []struct {
+		desc    string
+		jumps   []*bindpb.Options
+		proxy   string
+		wantErr string
+	}{{
+		desc: "two jump hosts",
+		jumps: []*bindpb.Options{
+			{Target: jump1, Username: "alice", Password: "bob"},
+			{Target: jump2, Username: "alice", Password: "bob", SkipVerify: true},
+		},
+	}, {
+		desc: "unknown host key",
+		jumps: []*bindpb.Options{
+			{Target: jump2, Username: "alice", Password: "bob"},
+		},
+		wantErr: "key is unknown",
+	}, {
+		desc: "wrong password",
+		jumps: []*bindpb.Options{
+			{Target: jump1, Username: "alice", Password: "eve"},
+		},
+		wantErr: "unable to authenticate",
+	}, {
+		desc:    "unsupported proxy",
+		proxy:   "http://proxy.example.com:3128",
+		wantErr: "invalid proxy",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			// The target is resolved by the last jump host.
+			bopts := &bindpb.Options{
+				Target:    net.JoinHostPort("localhost", port),
+				Insecure:  true,
+				JumpHosts: test.jumps,
+				Proxy:     test.proxy,
+			}
+			conn, err := dialTunnel(ctx, bopts, bopts.GetTarget())
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("dialTunnel() got unexpected error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			conn.Close()
+
+			dialer, err := makeDialer(&svcParams{}, bopts)
+			if err != nil {
+				t.Fatalf("makeDialer() got unexpected error: %v", err)
+			}
+			gconn, err := dialer.Dial(ctx)
+			if err != nil {
+				t.Fatalf("Dial() got unexpected error: %v", err)
+			}
+			defer gconn.Close()
+			if _, err := gpb.NewGNMIClient(gconn).Capabilities(ctx, &gpb.CapabilityRequest{}); err != nil {
+				t.Errorf("Capabilities() through the tunnel got unexpected error: %v", err)
+			}
+		})
+	}
+}